@@ -8,9 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"os"
 	"reflect"
@@ -20,6 +19,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/openfga/go-sdk/internal/utils/retryutils"
+	"github.com/openfga/go-sdk/oauth2"
 	"github.com/openfga/go-sdk/telemetry"
 )
 
@@ -41,6 +41,13 @@ type APIClient struct {
 	cfg    *Configuration
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
+	retryPolicies   *retryPolicies
+	circuitBreakers *circuitBreakers
+	rateLimiter     *rateLimiter
+	hedging         *hedging
+	logger          *slog.Logger
+	telemetry       *telemetry.Telemetry
+
 	// API Services
 
 	OpenFgaApi OpenFgaApi
@@ -57,20 +64,31 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	if cfg.Telemetry == nil {
 		cfg.Telemetry = telemetry.DefaultTelemetryConfiguration()
 	}
+	logger := newLogger(cfg)
+	// the client owns its telemetry, so that the clients of a process can report to different providers
+	instance, _ := telemetry.Configure(cfg.Telemetry)
 	if cfg.HTTPClient == nil {
+		// the client sending the requests, and the token requests of the credentials, bounds the time to connect
+		httpClient := cfg.Timeouts.httpClient()
 		if cfg.Credentials == nil {
-			cfg.HTTPClient = http.DefaultClient
+			cfg.HTTPClient = httpClient
 		} else {
-			cfg.Credentials.Context = context.Background()
-			telemetry.Bind(cfg.Credentials.Context, telemetry.Get(telemetry.TelemetryFactoryParameters{Configuration: cfg.Telemetry}))
-			var httpClient, headers = cfg.Credentials.GetHttpClientAndHeaderOverrides(retryutils.GetRetryParamsOrDefault(cfg.RetryParams), cfg.Debug)
+			cfg.Credentials.Context = context.WithValue(telemetry.Bind(context.Background(), instance), oauth2.HTTPClient, httpClient)
+			if cfg.Credentials.Logger == nil {
+				cfg.Credentials.Logger = logger
+			}
+			var credentialsClient, headers = cfg.Credentials.GetHttpClientAndHeaderOverrides(retryutils.GetRetryParamsOrDefault(cfg.RetryParams), cfg.Debug)
 			if len(headers) > 0 {
 				for idx := range headers {
 					cfg.AddDefaultHeader(headers[idx].Key, headers[idx].Value)
 				}
 			}
-			if httpClient != nil {
-				cfg.HTTPClient = httpClient
+			// the credentials without a token issuer return the default client
+			if credentialsClient == http.DefaultClient {
+				credentialsClient = httpClient
+			}
+			if credentialsClient != nil {
+				cfg.HTTPClient = credentialsClient
 			}
 		}
 	}
@@ -79,6 +97,12 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.cfg = cfg
 	c.common.client = c
 	c.common.RetryParams = cfg.RetryParams
+	c.retryPolicies = newRetryPolicies(cfg)
+	c.telemetry = instance
+	c.circuitBreakers = newCircuitBreakers(cfg, instance.Metrics)
+	c.rateLimiter = newRateLimiter(cfg)
+	c.hedging = newHedging(cfg)
+	c.logger = logger
 
 	// API Services
 	c.OpenFgaApi = (*OpenFgaApiService)(&c.common)
@@ -170,14 +194,6 @@ func parameterToJson(obj interface{}) (string, error) {
 
 // callAPI do the request.
 func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
-	if c.cfg.Debug {
-		dump, err := httputil.DumpRequestOut(request, true)
-		if err != nil {
-			return nil, err
-		}
-		log.Printf("\n%s\n", string(dump))
-	}
-
 	resp, err := c.cfg.HTTPClient.Do(request)
 	if err != nil {
 		if resp != nil && resp.Request == nil {
@@ -187,20 +203,6 @@ func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
 		return resp, err
 	}
 
-	if c.cfg.Debug {
-		// for debugging, don't dump the body for streaming resp. as it would buffer the entire response
-		// only dump headers
-		isStreamingResponse := resp.Header.Get("Content-Type") == "application/x-ndjson"
-		dump, err := httputil.DumpResponse(resp, !isStreamingResponse)
-		if err != nil {
-			return resp, err
-		}
-		log.Printf("\n%s\n", string(dump))
-		if isStreamingResponse {
-			log.Printf("Streaming response body - not dumped to preserve streaming\n")
-		}
-	}
-
 	if resp.Request == nil {
 		resp.Request = request
 	}
@@ -286,6 +288,7 @@ func (c *APIClient) prepareRequest(
 	if ctx != nil {
 		// add context to the request
 		localVarRequest = localVarRequest.WithContext(ctx)
+		c.traces().Inject(ctx, localVarRequest.Header)
 	}
 
 	return localVarRequest, nil
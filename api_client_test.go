@@ -1,10 +1,21 @@
 package openfga
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/openfga/go-sdk/credentials"
 	"github.com/openfga/go-sdk/telemetry"
 )
 
@@ -22,3 +33,272 @@ func TestApiClientCreatedWithDefaultTelemetry(t *testing.T) {
 		t.Fatalf("Telemetry instance should be the same")
 	}
 }
+
+func TestApiClientTracesRequestAttempts(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	var lock sync.Mutex
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		attempt := len(traceparents)
+		lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code":"internal_error","message":"unavailable"}`))
+			return
+		}
+		w.Header().Set("fga-query-duration-ms", "7")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg, err := NewConfiguration(Configuration{
+		ApiUrl:      server.URL,
+		RetryParams: &RetryParams{MaxRetry: 1, MinWaitInMs: 1},
+		HTTPClient:  &http.Client{},
+	})
+	if err != nil {
+		t.Fatalf("failed to create configuration: %v", err)
+	}
+	apiClient := NewAPIClient(cfg)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	_, _, err = apiClient.OpenFgaApi.Check(ctx, "01GXSB9YR785C4FYS3C0RTG7B2").
+		Body(CheckRequest{TupleKey: CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}).
+		Execute()
+	parent.End()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var attempts []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "POST Check" {
+			attempts = append(attempts, span)
+		}
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("Expected a span per attempt, got %d", len(attempts))
+	}
+
+	expected := []map[string]string{
+		{telemetry.ATTR_HTTP_REQUEST_RESEND_COUNT: "0", telemetry.ATTR_HTTP_RESPONSE_STATUS_CODE: "503"},
+		{telemetry.ATTR_HTTP_REQUEST_RESEND_COUNT: "1", telemetry.ATTR_HTTP_RESPONSE_STATUS_CODE: "200", telemetry.ATTR_HTTP_SERVER_REQUEST_DURATION: "7"},
+	}
+	for index, attempt := range attempts {
+		if attempt.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Expected attempt %d to be a child of the span of the caller", index)
+		}
+
+		attrs := make(map[string]string)
+		for _, attr := range attempt.Attributes() {
+			attrs[string(attr.Key)] = attr.Value.AsString()
+		}
+		expected[index][telemetry.ATTR_FGA_CLIENT_REQUEST_STORE_ID] = "01GXSB9YR785C4FYS3C0RTG7B2"
+		for name, value := range expected[index] {
+			if attrs[name] != value {
+				t.Errorf("Expected %s of attempt %d to be %q, got %q", name, index, value, attrs[name])
+			}
+		}
+
+		traceparent := "00-" + attempt.SpanContext().TraceID().String() + "-" + attempt.SpanContext().SpanID().String() + "-01"
+		if traceparents[index] != traceparent {
+			t.Errorf("Expected attempt %d to propagate its trace context, got %q", index, traceparents[index])
+		}
+	}
+}
+
+// newTestMetricsReader records the metrics of the telemetry configuration in the returned reader
+// newTestAPIClient returns a client of a test server answering with handler, which retries the failed requests after
+// 1ms. The options change the configuration of the client before it is created.
+func newTestAPIClient(t *testing.T, handler http.HandlerFunc, options ...func(*Configuration)) *APIClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	configuration := Configuration{
+		ApiUrl:      server.URL,
+		RetryParams: &RetryParams{MaxRetry: 3, MinWaitInMs: 1},
+		HTTPClient:  &http.Client{},
+	}
+	for _, option := range options {
+		option(&configuration)
+	}
+
+	cfg, err := NewConfiguration(configuration)
+	if err != nil {
+		t.Fatalf("failed to create configuration: %v", err)
+	}
+
+	return NewAPIClient(cfg)
+}
+
+func newTestMetricsReader(t *testing.T, configuration *telemetry.Configuration) *sdkmetric.ManualReader {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	configuration.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	return reader
+}
+
+// collectMetric returns the data of a metric collected by reader, nil when it was not recorded
+func collectMetric(t *testing.T, reader *sdkmetric.ManualReader, name string) metricdata.Aggregation {
+	t.Helper()
+	var resourceMetrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &resourceMetrics); err != nil {
+		t.Fatalf("failed to collect the metrics: %v", err)
+	}
+
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestApiClientRecordsRetriesAndRequestsInFlight(t *testing.T) {
+	var attempts int32
+	var lock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		attempts++
+		attempt := attempts
+		lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch attempt {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"code":"rate_limit_exceeded","message":"rate limited"}`))
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code":"internal_error","message":"unavailable"}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"allowed":true}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	telemetryConfiguration := telemetry.DefaultTelemetryConfiguration()
+	reader := newTestMetricsReader(t, telemetryConfiguration)
+	cfg, err := NewConfiguration(Configuration{
+		ApiUrl:      server.URL,
+		RetryParams: &RetryParams{MaxRetry: 3, MinWaitInMs: 1},
+		HTTPClient:  &http.Client{},
+		Telemetry:   telemetryConfiguration,
+	})
+	if err != nil {
+		t.Fatalf("failed to create configuration: %v", err)
+	}
+
+	if _, err := checkWith(NewAPIClient(cfg)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	retries, ok := collectMetric(t, reader, telemetry.METRIC_COUNTER_REQUEST_RETRY).(metricdata.Sum[int64])
+	if !ok || len(retries.DataPoints) != 2 {
+		t.Fatalf("Expected a retry per reason, got %v", retries)
+	}
+	reasons := map[string]string{}
+	for _, point := range retries.DataPoints {
+		reason, _ := point.Attributes.Value(attribute.Key(telemetry.ATTR_FGA_CLIENT_REQUEST_RETRY_REASON))
+		statusCode, _ := point.Attributes.Value(attribute.Key(telemetry.ATTR_HTTP_RESPONSE_STATUS_CODE))
+		if point.Value != 1 {
+			t.Errorf("Expected a single retry for %v, got %d", reason.AsString(), point.Value)
+		}
+		reasons[reason.AsString()] = statusCode.AsString()
+	}
+	if reasons[retryReasonRateLimit] != "429" || reasons[retryReasonServerError] != "503" {
+		t.Fatalf("Expected the retries of a rate limit and a server error, got %v", reasons)
+	}
+
+	inFlight, ok := collectMetric(t, reader, telemetry.METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT).(metricdata.Sum[int64])
+	if !ok || len(inFlight.DataPoints) != 1 {
+		t.Fatalf("Expected the requests in flight of Check, got %v", inFlight)
+	}
+	if method, _ := inFlight.DataPoints[0].Attributes.Value(attribute.Key(telemetry.ATTR_FGA_CLIENT_REQUEST_METHOD)); method.AsString() != "Check" || inFlight.DataPoints[0].Value != 0 {
+		t.Fatalf("Expected no request of Check in flight once it completed, got %v", inFlight.DataPoints[0])
+	}
+}
+
+func TestApiClientsReportToTheirProviders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	newClient := func(requests int) (*sdkmetric.ManualReader, *tracetest.SpanRecorder) {
+		recorder := tracetest.NewSpanRecorder()
+		telemetryConfiguration := telemetry.DefaultTelemetryConfiguration()
+		reader := newTestMetricsReader(t, telemetryConfiguration)
+		telemetryConfiguration.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		cfg, err := NewConfiguration(Configuration{
+			ApiUrl:     server.URL,
+			HTTPClient: &http.Client{},
+			Telemetry:  telemetryConfiguration,
+		})
+		if err != nil {
+			t.Fatalf("failed to create configuration: %v", err)
+		}
+
+		apiClient := NewAPIClient(cfg)
+		for i := 0; i < requests; i++ {
+			if _, err := checkWith(apiClient); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+
+		return reader, recorder
+	}
+
+	for requests := 1; requests <= 2; requests++ {
+		reader, recorder := newClient(requests)
+
+		requestDuration, ok := collectMetric(t, reader, telemetry.METRIC_HISTOGRAM_REQUEST_DURATION).(metricdata.Histogram[float64])
+		if !ok || len(requestDuration.DataPoints) != 1 || requestDuration.DataPoints[0].Count != uint64(requests) {
+			t.Fatalf("Expected %d requests recorded by the meter provider of the client, got %v", requests, requestDuration)
+		}
+		if spans := recorder.Ended(); len(spans) != requests {
+			t.Fatalf("Expected %d spans started by the tracer provider of the client, got %d", requests, len(spans))
+		}
+	}
+}
+
+func TestApiClientBindsTelemetryToCredentials(t *testing.T) {
+	cfg, err := NewConfiguration(Configuration{
+		ApiUrl: "https://api.fga.example",
+		Credentials: &credentials.Credentials{
+			Method: credentials.CredentialsMethodClientCredentials,
+			Config: &credentials.Config{
+				ClientCredentialsClientId:       "client",
+				ClientCredentialsClientSecret:   "secret",
+				ClientCredentialsApiAudience:    "https://api.fga.example/",
+				ClientCredentialsApiTokenIssuer: "issuer.fga.example",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create configuration: %v", err)
+	}
+
+	apiClient := NewAPIClient(cfg)
+
+	if instance := telemetry.Extract(cfg.Credentials.Context); instance == nil || instance != apiClient.Telemetry() {
+		t.Fatalf("Expected the token requests to report to the telemetry of the client")
+	}
+}
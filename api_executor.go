@@ -0,0 +1,295 @@
+package openfga
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/openfga/go-sdk/telemetry"
+)
+
+// apiRequest is a request of an operation of the OpenFGA API
+type apiRequest struct {
+	ctx           context.Context
+	operationName string
+	method        string
+	path          string
+	// storeId is empty for the operations which are not scoped to a store
+	storeId      string
+	body         interface{}
+	headerParams map[string]string
+	queryParams  url.Values
+	started      time.Time
+	// attempt is the number of the attempt being sent, 0 for the first one
+	attempt int
+}
+
+// apiResponse is the result of sending a request once
+type apiResponse struct {
+	req          *http.Request
+	httpResponse *http.Response
+	responseBody []byte
+	// networkErr is the error of a request which did not receive a response
+	networkErr error
+	// readErr is the error of reading the body of the response
+	readErr error
+	// abortErr is the error of a request which could not be sent and must not be retried
+	abortErr error
+}
+
+// final reports whether the response is an answer of the API which sending the request again would not change
+func (r apiResponse) final() bool {
+	if r.abortErr != nil || r.networkErr != nil || r.readErr != nil || r.httpResponse == nil {
+		return false
+	}
+
+	statusCode := r.httpResponse.StatusCode
+	return statusCode != http.StatusTooManyRequests &&
+		(statusCode < http.StatusInternalServerError || statusCode == http.StatusNotImplemented)
+}
+
+// send waits for the rate limiter, then sends the request once with ctx and reads its response
+func (c *APIClient) send(ctx context.Context, r apiRequest) apiResponse {
+	release, err := c.rateLimiter.acquire(ctx)
+	if err != nil {
+		return apiResponse{abortErr: err}
+	}
+
+	ctx, span := c.traces().StartRequest(ctx, r.method, r.operationName, r.attempt)
+	req, err := c.prepareRequest(ctx, r.path, r.method, r.body, r.headerParams, r.queryParams)
+	if err != nil {
+		release(rateLimiterIgnored)
+		span.End(err)
+		return apiResponse{abortErr: err}
+	}
+	span.SetRequestAttributes(req, r.telemetryParameters())
+	c.logRequestStarted(ctx, r, req)
+
+	attemptStarted := time.Now()
+	done := c.trackInFlight(r.operationName)
+	httpResponse, err := c.callAPI(req)
+	c.logRequestCompleted(ctx, r, httpResponse, err, attemptStarted)
+	if err != nil || httpResponse == nil {
+		done()
+		release(rateLimiterIgnored)
+		span.End(err)
+		return apiResponse{req: req, httpResponse: httpResponse, networkErr: err}
+	}
+	span.SetResponseAttributes(httpResponse)
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	_ = httpResponse.Body.Close()
+	done()
+	httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
+	release(rateLimiterOutcomeOf(httpResponse.StatusCode))
+	span.End(err)
+
+	return apiResponse{req: req, httpResponse: httpResponse, responseBody: responseBody, readErr: err}
+}
+
+// executeRequest sends an API request, retrying the failed attempts according to the retry policy of its operation.
+// It returns the response with its body read, or the error of the last attempt.
+//
+// Each attempt waits for the rate limiter, when enabled, and is hedged when its operation has a hedging policy. When
+// the circuit breaker is enabled, a request on an open circuit fails with an FgaCircuitOpenError, and the retries
+// stop as soon as the circuit opens. When timeouts are set, each attempt has its own timeout, and the deadline of the
+// request stops the retries which would wait past it.
+func (c *APIClient) executeRequest(r apiRequest) (_ *http.Response, _ []byte, err error) {
+	defer func() {
+		if err != nil {
+			c.logRequestFailed(r, err)
+		}
+	}()
+
+	attemptTimeout, deadline := c.cfg.Timeouts.timeouts(r.operationName)
+	if deadline > 0 {
+		ctx, cancel := context.WithTimeout(r.ctx, deadline)
+		defer cancel()
+		r.ctx = ctx
+	}
+
+	circuit := c.circuitBreakers.get(r.operationName, r.storeId)
+	c.retryPolicies.budget.deposit()
+	for i := 0; ; i++ {
+		generation, err := circuit.allow(r.operationName, r.storeId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		r.attempt = i
+		response := c.sendAttempt(r, attemptTimeout)
+		if response.abortErr != nil {
+			circuit.record(generation, circuitIgnored)
+			return nil, nil, response.abortErr
+		}
+
+		req, httpResponse, responseBody := response.req, response.httpResponse, response.responseBody
+		if response.networkErr != nil || httpResponse == nil {
+			err := response.networkErr
+			// a request cancelled by the caller says nothing about the health of the API
+			if r.ctx.Err() != nil {
+				circuit.record(generation, circuitIgnored)
+			} else {
+				circuit.record(generation, circuitFailure)
+			}
+			attempt := RetryAttempt{OperationName: r.operationName, Attempt: i, Err: err}
+			if timeToWait, ok := c.retryDelay(r, circuit, attempt); ok {
+				c.logRetryScheduled(r, attempt, timeToWait)
+				if waitToRetry(r.ctx, timeToWait) {
+					c.recordRetry(r, attempt)
+					continue
+				}
+			}
+			return httpResponse, nil, err
+		}
+
+		if err := response.readErr; err != nil {
+			circuit.record(generation, circuitFailure)
+			attempt := RetryAttempt{OperationName: r.operationName, Attempt: i, Err: err, Response: httpResponse}
+			if timeToWait, ok := c.retryDelay(r, circuit, attempt); ok {
+				c.logRetryScheduled(r, attempt, timeToWait)
+				if waitToRetry(r.ctx, timeToWait) {
+					c.recordRetry(r, attempt)
+					continue
+				}
+			}
+			return httpResponse, nil, err
+		}
+
+		if httpResponse.StatusCode >= http.StatusMultipleChoices {
+			err := c.handleAPIError(httpResponse, responseBody, r.body, r.operationName, r.storeId)
+			circuit.record(generation, circuitOutcomeOf(httpResponse.StatusCode))
+			if err != nil {
+				attempt := RetryAttempt{OperationName: r.operationName, Attempt: i, Err: err, Response: httpResponse}
+				if timeToWait, ok := c.retryDelay(r, circuit, attempt); ok {
+					c.logRetryScheduled(r, attempt, timeToWait)
+					if waitToRetry(r.ctx, timeToWait) {
+						c.recordRetry(r, attempt)
+						continue
+					}
+				}
+			}
+
+			return httpResponse, responseBody, err
+		}
+
+		circuit.record(generation, circuitSuccess)
+		c.recordRequestMetrics(r, req, httpResponse, i)
+
+		return httpResponse, responseBody, nil
+	}
+}
+
+// retryDelay returns the time to wait before retrying a failed attempt of a request, or false when it is not retried
+// because of its retry policy, its retry time budget, the retry budget of the client or an open circuit
+func (c *APIClient) retryDelay(r apiRequest, circuit *circuit, attempt RetryAttempt) (time.Duration, bool) {
+	if circuit.rejects() {
+		return 0, false
+	}
+
+	return c.retryPolicies.retryDelay(r.ctx, attempt, r.started)
+}
+
+// sendAttempt sends an attempt of a request, bounded by timeout when it is set
+func (c *APIClient) sendAttempt(r apiRequest, timeout time.Duration) apiResponse {
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(r.ctx, timeout)
+		defer cancel()
+		r.ctx = ctx
+	}
+
+	return c.sendHedged(r)
+}
+
+// waitToRetry waits before retrying a request, returning false when ctx is done first
+func waitToRetry(ctx context.Context, timeToWait time.Duration) bool {
+	timer := time.NewTimer(timeToWait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// recordRequestMetrics records the request and query durations of a successful request
+func (c *APIClient) recordRequestMetrics(r apiRequest, req *http.Request, httpResponse *http.Response, retryCount int) {
+	metrics := c.metrics()
+
+	var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
+		r.operationName,
+		r.telemetryParameters(),
+		req,
+		httpResponse,
+		r.started,
+		retryCount,
+	)
+
+	if requestDuration > 0 {
+		_, _ = metrics.RequestDuration(requestDuration, attrs)
+	}
+
+	if queryDuration > 0 {
+		_, _ = metrics.QueryDuration(queryDuration, attrs)
+	}
+}
+
+// recordRetry records the retry of a failed attempt of a request, by its reason
+func (c *APIClient) recordRetry(r apiRequest, attempt RetryAttempt) {
+	attrs := map[*telemetry.Attribute]string{
+		telemetry.FGAClientRequestMethod:      r.operationName,
+		telemetry.FGAClientRequestRetryReason: retryReason(attempt),
+	}
+	if r.storeId != "" {
+		attrs[telemetry.FGAClientRequestStoreID] = r.storeId
+	}
+	if attempt.Response != nil {
+		attrs[telemetry.HTTPResponseStatusCode] = strconv.Itoa(attempt.Response.StatusCode)
+	}
+
+	_, _ = c.metrics().RequestRetry(1, attrs)
+}
+
+// trackInFlight counts a request of an operation as in flight, until the returned function is called
+func (c *APIClient) trackInFlight(operationName string) func() {
+	metrics := c.metrics()
+	attrs := map[*telemetry.Attribute]string{telemetry.FGAClientRequestMethod: operationName}
+	_, _ = metrics.RequestsInFlight(1, attrs)
+
+	return func() {
+		_, _ = metrics.RequestsInFlight(-1, attrs)
+	}
+}
+
+// telemetryParameters returns the parameters of the request from which the telemetry attributes are built
+func (r apiRequest) telemetryParameters() map[string]interface{} {
+	params := map[string]interface{}{
+		"body": r.body,
+	}
+	if r.storeId != "" {
+		params["storeId"] = r.storeId
+	}
+
+	return params
+}
+
+// metrics returns the metrics of the client
+func (c *APIClient) metrics() telemetry.MetricsInterface {
+	return c.Telemetry().Metrics
+}
+
+// traces returns the traces of the client, which start no span when tracing is disabled
+func (c *APIClient) traces() *telemetry.Traces {
+	return c.Telemetry().Traces
+}
+
+// Telemetry returns the telemetry of the client, which reports with the providers of its telemetry configuration
+func (c *APIClient) Telemetry() *telemetry.Telemetry {
+	return c.telemetry
+}
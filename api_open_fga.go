@@ -13,18 +13,11 @@
 package openfga
 
 import (
-	"bytes"
 	"context"
-	"errors"
-	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
-
-	"github.com/openfga/go-sdk/internal/utils/retryutils"
-	"github.com/openfga/go-sdk/telemetry"
 )
 
 // Linger please
@@ -1067,106 +1060,31 @@ func (a *OpenFgaApiService) BatchCheckExecute(r ApiBatchCheckRequest) (BatchChec
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiCheckRequest struct {
@@ -1398,106 +1316,31 @@ func (a *OpenFgaApiService) CheckExecute(r ApiCheckRequest) (CheckResponse, *htt
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiCreateStoreRequest struct {
@@ -1582,105 +1425,30 @@ func (a *OpenFgaApiService) CreateStoreExecute(r ApiCreateStoreRequest) (CreateS
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, "")
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"body": requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiDeleteStoreRequest struct {
@@ -1760,97 +1528,18 @@ func (a *OpenFgaApiService) DeleteStoreExecute(r ApiDeleteStoreRequest) (*http.R
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return httpResponse, err
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
-
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
-		}
-
-		return httpResponse, nil
-	}
-
-	// should never have reached this
-	return nil, reportError("Error not handled properly")
+	httpResponse, _, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	return httpResponse, err
 }
 
 type ApiExpandRequest struct {
@@ -2120,106 +1809,31 @@ func (a *OpenFgaApiService) ExpandExecute(r ApiExpandRequest) (ExpandResponse, *
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiGetStoreRequest struct {
@@ -2301,106 +1915,31 @@ func (a *OpenFgaApiService) GetStoreExecute(r ApiGetStoreRequest) (GetStoreRespo
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiListObjectsRequest struct {
@@ -2502,106 +2041,31 @@ func (a *OpenFgaApiService) ListObjectsExecute(r ApiListObjectsRequest) (ListObj
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiListStoresRequest struct {
@@ -2703,105 +2167,30 @@ func (a *OpenFgaApiService) ListStoresExecute(r ApiListStoresRequest) (ListStore
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, "")
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"body": requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiListUsersRequest struct {
@@ -2904,106 +2293,31 @@ func (a *OpenFgaApiService) ListUsersExecute(r ApiListUsersRequest) (ListUsersRe
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiReadRequest struct {
@@ -3207,106 +2521,31 @@ func (a *OpenFgaApiService) ReadExecute(r ApiReadRequest) (ReadResponse, *http.R
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiReadAssertionsRequest struct {
@@ -3396,106 +2635,31 @@ func (a *OpenFgaApiService) ReadAssertionsExecute(r ApiReadAssertionsRequest) (R
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiReadAuthorizationModelRequest struct {
@@ -3628,106 +2792,31 @@ func (a *OpenFgaApiService) ReadAuthorizationModelExecute(r ApiReadAuthorization
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiReadAuthorizationModelsRequest struct {
@@ -3867,106 +2956,31 @@ func (a *OpenFgaApiService) ReadAuthorizationModelsExecute(r ApiReadAuthorizatio
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiReadChangesRequest struct {
@@ -4086,106 +3100,31 @@ func (a *OpenFgaApiService) ReadChangesExecute(r ApiReadChangesRequest) (ReadCha
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiStreamedListObjectsRequest struct {
@@ -4282,106 +3221,31 @@ func (a *OpenFgaApiService) StreamedListObjectsExecute(r ApiStreamedListObjectsR
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiWriteRequest struct {
@@ -4521,106 +3385,31 @@ func (a *OpenFgaApiService) WriteExecute(r ApiWriteRequest) (map[string]interfac
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
 
 type ApiWriteAssertionsRequest struct {
@@ -4719,97 +3508,18 @@ func (a *OpenFgaApiService) WriteAssertionsExecute(r ApiWriteAssertionsRequest)
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return httpResponse, err
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
-
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
-		}
-
-		return httpResponse, nil
-	}
-
-	// should never have reached this
-	return nil, reportError("Error not handled properly")
+	httpResponse, _, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	return httpResponse, err
 }
 
 type ApiWriteAuthorizationModelRequest struct {
@@ -4947,104 +3657,29 @@ func (a *OpenFgaApiService) WriteAuthorizationModelExecute(r ApiWriteAuthorizati
 		localVarHeaderParams[header] = val
 	}
 
-	retryParams := a.client.cfg.RetryParams
-	for i := 0; i < retryParams.MaxRetry+1; i++ {
-		req, err := a.client.prepareRequest(r.ctx, path, httpMethod, requestBody, localVarHeaderParams, localVarQueryParams)
-		if err != nil {
-			return returnValue, nil, err
-		}
-
-		httpResponse, err := a.client.callAPI(req)
-		if err != nil || httpResponse == nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, http.Header{}, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to network error (error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		responseBody, err := io.ReadAll(httpResponse.Body)
-		_ = httpResponse.Body.Close()
-		httpResponse.Body = io.NopCloser(bytes.NewBuffer(responseBody))
-		if err != nil {
-			if i < retryParams.MaxRetry {
-				timeToWait := retryutils.GetTimeToWait(i, retryParams.MaxRetry, retryParams.MinWaitInMs, httpResponse.Header, operationName)
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to error parsing response body (err=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-			return returnValue, httpResponse, err
-		}
-
-		if httpResponse.StatusCode >= http.StatusMultipleChoices {
-			err := a.client.handleAPIError(httpResponse, responseBody, requestBody, operationName, r.storeId)
-			if err != nil && i < retryParams.MaxRetry {
-				timeToWait := time.Duration(0)
-				var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
-				var fgaApiInternalError FgaApiInternalError
-				switch {
-				case errors.As(err, &fgaApiRateLimitExceededError):
-					timeToWait = err.(FgaApiRateLimitExceededError).GetTimeToWait(i, *retryParams)
-				case errors.As(err, &fgaApiInternalError):
-					timeToWait = err.(FgaApiInternalError).GetTimeToWait(i, *retryParams)
-				}
-
-				if timeToWait > 0 {
-					if a.client.cfg.Debug {
-						log.Printf("\nWaiting %v to retry %v (%v %v) due to api retryable error (status code %v, error=%v) on attempt %v. Request body: %v\n", timeToWait, operationName, req.Method, req.URL, httpResponse.StatusCode, err, i, requestBody)
-					}
-					time.Sleep(timeToWait)
-					continue
-				}
-			}
-
-			return returnValue, httpResponse, err
-		}
-
-		err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
-		if err != nil {
-			newErr := GenericOpenAPIError{
-				body:  responseBody,
-				error: err.Error(),
-			}
-			return returnValue, httpResponse, newErr
-		}
-
-		metrics := telemetry.GetMetrics(telemetry.TelemetryFactoryParameters{Configuration: a.client.cfg.Telemetry})
-
-		var attrs, queryDuration, requestDuration, _ = metrics.BuildTelemetryAttributes(
-			operationName,
-			map[string]interface{}{
-				"storeId": r.storeId,
-				"body":    requestBody,
-			},
-			req,
-			httpResponse,
-			requestStarted,
-			i,
-		)
-
-		if requestDuration > 0 {
-			_, _ = metrics.RequestDuration(requestDuration, attrs)
-		}
+	httpResponse, responseBody, err := a.client.executeRequest(apiRequest{
+		ctx:           r.ctx,
+		operationName: operationName,
+		method:        httpMethod,
+		path:          path,
+		storeId:       r.storeId,
+		body:          requestBody,
+		headerParams:  localVarHeaderParams,
+		queryParams:   localVarQueryParams,
+		started:       requestStarted,
+	})
+	if err != nil {
+		return returnValue, httpResponse, err
+	}
 
-		if queryDuration > 0 {
-			_, _ = metrics.QueryDuration(queryDuration, attrs)
+	err = a.client.decode(&returnValue, responseBody, httpResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := GenericOpenAPIError{
+			body:  responseBody,
+			error: err.Error(),
 		}
-
-		return returnValue, httpResponse, nil
+		return returnValue, httpResponse, newErr
 	}
 
-	// should never have reached this
-	return returnValue, nil, reportError("Error not handled properly")
+	return returnValue, httpResponse, nil
 }
@@ -0,0 +1,406 @@
+package openfga
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/openfga/go-sdk/internal/constants"
+	"github.com/openfga/go-sdk/telemetry"
+)
+
+// CircuitBreakerScope selects the requests sharing a circuit
+type CircuitBreakerScope string
+
+// List of CircuitBreakerScope
+const (
+	// CIRCUIT_BREAKER_SCOPE_CLIENT shares one circuit between all the requests of the client
+	CIRCUIT_BREAKER_SCOPE_CLIENT CircuitBreakerScope = "client"
+	// CIRCUIT_BREAKER_SCOPE_ENDPOINT has one circuit per API operation, e.g. Check or Write
+	CIRCUIT_BREAKER_SCOPE_ENDPOINT CircuitBreakerScope = "endpoint"
+	// CIRCUIT_BREAKER_SCOPE_STORE has one circuit per store, the operations not scoped to a store sharing another one
+	CIRCUIT_BREAKER_SCOPE_STORE CircuitBreakerScope = "store"
+)
+
+// CircuitBreakerState is the state of a circuit
+type CircuitBreakerState string
+
+// List of CircuitBreakerState
+const (
+	// CIRCUIT_BREAKER_STATE_CLOSED lets requests through
+	CIRCUIT_BREAKER_STATE_CLOSED CircuitBreakerState = "closed"
+	// CIRCUIT_BREAKER_STATE_OPEN rejects requests with ErrCircuitOpen
+	CIRCUIT_BREAKER_STATE_OPEN CircuitBreakerState = "open"
+	// CIRCUIT_BREAKER_STATE_HALF_OPEN lets a few probe requests through to decide whether to close the circuit
+	CIRCUIT_BREAKER_STATE_HALF_OPEN CircuitBreakerState = "half_open"
+)
+
+// CircuitBreakerConfiguration configures the circuit breaker of the API client. A circuit opens when either threshold
+// is reached, then rejects requests until OpenDurationInMs elapses. It then lets HalfOpenProbes requests through, and
+// closes once they all succeed or opens again on the first failure.
+//
+// Network errors and server errors other than 501 Not Implemented count as failures. Rate limited requests are
+// neither failures nor successes.
+type CircuitBreakerConfiguration struct {
+	// Scope selects the requests sharing a circuit (default = client)
+	Scope CircuitBreakerScope `json:"scope,omitempty"`
+	// ConsecutiveFailures is the number of failures in a row opening the circuit (default = 5 when FailureRatio is not
+	// set either)
+	ConsecutiveFailures int `json:"consecutive_failures,omitempty"`
+	// FailureRatio is the ratio of failed requests over the window opening the circuit, between 0 and 1
+	FailureRatio float64 `json:"failure_ratio,omitempty"`
+	// MinRequests is the number of requests of the window before FailureRatio applies (default = 10)
+	MinRequests int `json:"min_requests,omitempty"`
+	// WindowInMs is the duration of the window over which FailureRatio is computed (default = 10s)
+	WindowInMs int `json:"window_in_ms,omitempty"`
+	// OpenDurationInMs is how long an open circuit rejects requests before letting probes through (default = 30s)
+	OpenDurationInMs int `json:"open_duration_in_ms,omitempty"`
+	// HalfOpenProbes is the number of requests let through a half-open circuit (default = 1)
+	HalfOpenProbes int `json:"half_open_probes,omitempty"`
+}
+
+// Validate ensures that the circuit breaker configuration is valid
+func (c *CircuitBreakerConfiguration) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	switch c.Scope {
+	case "", CIRCUIT_BREAKER_SCOPE_CLIENT, CIRCUIT_BREAKER_SCOPE_ENDPOINT, CIRCUIT_BREAKER_SCOPE_STORE:
+	default:
+		return reportError("CircuitBreaker.Scope (%s) must be one of client, endpoint or store", c.Scope)
+	}
+
+	if c.FailureRatio < 0 || c.FailureRatio > 1 {
+		return reportError("CircuitBreaker.FailureRatio (%v) must be between 0 and 1", c.FailureRatio)
+	}
+
+	if c.ConsecutiveFailures < 0 || c.MinRequests < 0 || c.WindowInMs < 0 || c.OpenDurationInMs < 0 || c.HalfOpenProbes < 0 {
+		return reportError("CircuitBreaker thresholds and durations cannot be negative")
+	}
+
+	return nil
+}
+
+// withDefaults returns the configuration with the defaults of the unset fields
+func (c CircuitBreakerConfiguration) withDefaults() CircuitBreakerConfiguration {
+	if c.Scope == "" {
+		c.Scope = CIRCUIT_BREAKER_SCOPE_CLIENT
+	}
+	if c.ConsecutiveFailures == 0 && c.FailureRatio == 0 {
+		c.ConsecutiveFailures = constants.DefaultCircuitBreakerConsecutiveFailures
+	}
+	if c.MinRequests == 0 {
+		c.MinRequests = constants.DefaultCircuitBreakerMinRequests
+	}
+	if c.WindowInMs == 0 {
+		c.WindowInMs = constants.DefaultCircuitBreakerWindowInMs
+	}
+	if c.OpenDurationInMs == 0 {
+		c.OpenDurationInMs = constants.DefaultCircuitBreakerOpenDurationInMs
+	}
+	if c.HalfOpenProbes == 0 {
+		c.HalfOpenProbes = constants.DefaultCircuitBreakerHalfOpenProbes
+	}
+	return c
+}
+
+// circuitOutcome is how the result of a request counts towards its circuit
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	circuitIgnored
+)
+
+// circuitOutcomeOf returns how a response counts towards its circuit
+func circuitOutcomeOf(statusCode int) circuitOutcome {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return circuitIgnored
+	case statusCode >= http.StatusInternalServerError && statusCode != http.StatusNotImplemented:
+		return circuitFailure
+	default:
+		return circuitSuccess
+	}
+}
+
+// circuitBreakers holds the circuits of an API client
+type circuitBreakers struct {
+	config  CircuitBreakerConfiguration
+	metrics telemetry.MetricsInterface
+	host    string
+	now     func() time.Time
+
+	lock     sync.Mutex
+	circuits map[string]*circuit
+}
+
+func newCircuitBreakers(cfg *Configuration, metrics telemetry.MetricsInterface) *circuitBreakers {
+	if cfg.CircuitBreaker == nil {
+		return nil
+	}
+
+	host := ""
+	if apiUrl, err := url.Parse(cfg.ApiUrl); err == nil {
+		host = apiUrl.Host
+	}
+
+	return &circuitBreakers{
+		config:   cfg.CircuitBreaker.withDefaults(),
+		metrics:  metrics,
+		host:     host,
+		now:      time.Now,
+		circuits: map[string]*circuit{},
+	}
+}
+
+// get returns the circuit of a request, or nil when the circuit breaker is disabled
+func (b *circuitBreakers) get(operationName string, storeId string) *circuit {
+	if b == nil {
+		return nil
+	}
+
+	key := ""
+	switch b.config.Scope {
+	case CIRCUIT_BREAKER_SCOPE_ENDPOINT:
+		key = operationName
+	case CIRCUIT_BREAKER_SCOPE_STORE:
+		key = storeId
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if existing, ok := b.circuits[key]; ok {
+		return existing
+	}
+	created := &circuit{breakers: b, key: key, state: CIRCUIT_BREAKER_STATE_CLOSED, windowStarted: b.now()}
+	b.circuits[key] = created
+	return created
+}
+
+// circuit tracks the outcomes of the requests of a scope
+type circuit struct {
+	breakers *circuitBreakers
+	// key is the operation or the store of the circuit, depending on the scope
+	key string
+
+	lock  sync.Mutex
+	state CircuitBreakerState
+	// generation changes with the state, so the outcomes of requests allowed in a previous state are dropped
+	generation          uint64
+	consecutiveFailures int
+	windowStarted       time.Time
+	requests            int
+	failures            int
+	openedAt            time.Time
+	probes              int
+	probeSuccesses      int
+}
+
+// allow returns the generation a request is counted in, or an FgaCircuitOpenError when the circuit rejects it
+func (c *circuit) allow(operationName string, storeId string) (uint64, error) {
+	if c == nil {
+		return 0, nil
+	}
+
+	c.lock.Lock()
+	config := c.breakers.config
+	now := c.breakers.now()
+	changed := false
+	if c.state == CIRCUIT_BREAKER_STATE_OPEN {
+		openDuration := time.Duration(config.OpenDurationInMs) * time.Millisecond
+		if elapsed := now.Sub(c.openedAt); elapsed < openDuration {
+			c.lock.Unlock()
+			return 0, FgaCircuitOpenError{
+				operationName: operationName,
+				storeId:       storeId,
+				retryAfter:    openDuration - elapsed,
+			}
+		}
+		c.setState(CIRCUIT_BREAKER_STATE_HALF_OPEN, now)
+		changed = true
+	}
+
+	if c.state == CIRCUIT_BREAKER_STATE_HALF_OPEN {
+		if c.probes >= config.HalfOpenProbes {
+			c.lock.Unlock()
+			c.recordStateChange(changed, CIRCUIT_BREAKER_STATE_HALF_OPEN)
+			return 0, FgaCircuitOpenError{operationName: operationName, storeId: storeId}
+		}
+		c.probes++
+	}
+	generation, state := c.generation, c.state
+	c.lock.Unlock()
+
+	c.recordStateChange(changed, state)
+	return generation, nil
+}
+
+// record counts the outcome of a request allowed in the generation
+func (c *circuit) record(generation uint64, outcome circuitOutcome) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	if generation != c.generation {
+		c.lock.Unlock()
+		return
+	}
+
+	config := c.breakers.config
+	now := c.breakers.now()
+	previous := c.state
+	switch c.state {
+	case CIRCUIT_BREAKER_STATE_HALF_OPEN:
+		switch outcome {
+		case circuitSuccess:
+			c.probeSuccesses++
+			if c.probeSuccesses >= config.HalfOpenProbes {
+				c.setState(CIRCUIT_BREAKER_STATE_CLOSED, now)
+			}
+		case circuitFailure:
+			c.setState(CIRCUIT_BREAKER_STATE_OPEN, now)
+		case circuitIgnored:
+			c.probes--
+		}
+	case CIRCUIT_BREAKER_STATE_CLOSED:
+		if outcome == circuitIgnored {
+			break
+		}
+		if now.Sub(c.windowStarted) >= time.Duration(config.WindowInMs)*time.Millisecond {
+			c.windowStarted, c.requests, c.failures = now, 0, 0
+		}
+		c.requests++
+		if outcome == circuitSuccess {
+			c.consecutiveFailures = 0
+			break
+		}
+		c.failures++
+		c.consecutiveFailures++
+		if (config.ConsecutiveFailures > 0 && c.consecutiveFailures >= config.ConsecutiveFailures) ||
+			(config.FailureRatio > 0 && c.requests >= config.MinRequests && float64(c.failures)/float64(c.requests) >= config.FailureRatio) {
+			c.setState(CIRCUIT_BREAKER_STATE_OPEN, now)
+		}
+	}
+	state := c.state
+	c.lock.Unlock()
+
+	c.recordStateChange(state != previous, state)
+}
+
+// rejects reports whether a request sent now would be rejected, so that retries are not scheduled on an open circuit
+func (c *circuit) rejects() bool {
+	if c == nil {
+		return false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	switch c.state {
+	case CIRCUIT_BREAKER_STATE_OPEN:
+		return c.breakers.now().Sub(c.openedAt) < time.Duration(c.breakers.config.OpenDurationInMs)*time.Millisecond
+	case CIRCUIT_BREAKER_STATE_HALF_OPEN:
+		return c.probes >= c.breakers.config.HalfOpenProbes
+	}
+	return false
+}
+
+// State returns the current state of the circuit
+func (c *circuit) State() CircuitBreakerState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.state
+}
+
+// setState moves the circuit to a state and resets its counters, the lock being held
+func (c *circuit) setState(state CircuitBreakerState, now time.Time) {
+	c.state = state
+	c.generation++
+	c.consecutiveFailures, c.requests, c.failures = 0, 0, 0
+	c.probes, c.probeSuccesses = 0, 0
+	c.windowStarted = now
+	if state == CIRCUIT_BREAKER_STATE_OPEN {
+		c.openedAt = now
+	}
+}
+
+// recordStateChange emits the state change metric when the state changed
+func (c *circuit) recordStateChange(changed bool, state CircuitBreakerState) {
+	if !changed || c.breakers.metrics == nil {
+		return
+	}
+
+	attrs := map[*telemetry.Attribute]string{
+		telemetry.FGAClientCircuitBreakerState: string(state),
+	}
+	if c.breakers.host != "" {
+		attrs[telemetry.HTTPHost] = c.breakers.host
+	}
+	switch c.breakers.config.Scope {
+	case CIRCUIT_BREAKER_SCOPE_ENDPOINT:
+		attrs[telemetry.FGAClientRequestMethod] = c.key
+	case CIRCUIT_BREAKER_SCOPE_STORE:
+		if c.key != "" {
+			attrs[telemetry.FGAClientRequestStoreID] = c.key
+		}
+	}
+
+	_, _ = c.breakers.metrics.CircuitBreakerStateChange(1, attrs)
+}
+
+// CircuitBreakerState returns the state of the circuit of an operation and store, or an empty state when the circuit
+// breaker is disabled. The store id is ignored unless the scope is store, and the operation unless it is endpoint.
+func (c *APIClient) CircuitBreakerState(operationName string, storeId string) CircuitBreakerState {
+	circuit := c.circuitBreakers.get(operationName, storeId)
+	if circuit == nil {
+		return ""
+	}
+	return circuit.State()
+}
+
+// ErrCircuitOpen is matched by the errors of the requests rejected by the circuit breaker
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// FgaCircuitOpenError is returned without sending the request when the circuit breaker rejects it
+type FgaCircuitOpenError struct {
+	operationName string
+	storeId       string
+	retryAfter    time.Duration
+}
+
+// Error returns non-empty string if there was an error.
+func (e FgaCircuitOpenError) Error() string {
+	if e.retryAfter > 0 {
+		return fmt.Sprintf("%s rejected: circuit breaker is open, retry in %v", e.operationName, e.retryAfter)
+	}
+	return fmt.Sprintf("%s rejected: circuit breaker is half-open and awaiting its probes", e.operationName)
+}
+
+// Is makes the error match ErrCircuitOpen
+func (e FgaCircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// EndpointCategory returns the original API category
+func (e FgaCircuitOpenError) EndpointCategory() string {
+	return e.operationName
+}
+
+// StoreId returns the store ID for the API that causes the error
+func (e FgaCircuitOpenError) StoreId() string {
+	return e.storeId
+}
+
+// RetryAfter returns how long the circuit stays open, or 0 when it is half-open
+func (e FgaCircuitOpenError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
@@ -0,0 +1,263 @@
+package openfga
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestCircuit returns a circuit whose clock is advanced by the returned function
+func newTestCircuit(config CircuitBreakerConfiguration) (*circuit, func(time.Duration)) {
+	now := time.Unix(0, 0)
+	breakers := newCircuitBreakers(&Configuration{ApiUrl: "http://api.fga.example", CircuitBreaker: &config}, nil)
+	breakers.now = func() time.Time { return now }
+	return breakers.get("Check", "01GXSB9YR785C4FYS3C0RTG7B2"), func(d time.Duration) { now = now.Add(d) }
+}
+
+func recordOutcome(t *testing.T, c *circuit, outcome circuitOutcome) {
+	t.Helper()
+	generation, err := c.allow("Check", "")
+	if err != nil {
+		t.Fatalf("Expected the request to be allowed, got %v", err)
+	}
+	c.record(generation, outcome)
+}
+
+func TestCircuitBreakerConfigurationValidate(t *testing.T) {
+	invalid := map[string]CircuitBreakerConfiguration{
+		"unknown scope":     {Scope: "region"},
+		"ratio above 1":     {FailureRatio: 1.5},
+		"negative ratio":    {FailureRatio: -0.1},
+		"negative failures": {ConsecutiveFailures: -1},
+		"negative probes":   {HalfOpenProbes: -1},
+	}
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080", CircuitBreaker: &config})
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+		})
+	}
+
+	cfg, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080", CircuitBreaker: &CircuitBreakerConfiguration{
+		Scope:        CIRCUIT_BREAKER_SCOPE_STORE,
+		FailureRatio: 0.5,
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.CircuitBreaker == nil || cfg.CircuitBreaker.Scope != CIRCUIT_BREAKER_SCOPE_STORE {
+		t.Fatalf("Expected the circuit breaker configuration to be kept, got %+v", cfg.CircuitBreaker)
+	}
+}
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	c, advance := newTestCircuit(CircuitBreakerConfiguration{ConsecutiveFailures: 3, OpenDurationInMs: 1000})
+
+	recordOutcome(t, c, circuitFailure)
+	recordOutcome(t, c, circuitFailure)
+	recordOutcome(t, c, circuitSuccess)
+	recordOutcome(t, c, circuitFailure)
+	recordOutcome(t, c, circuitIgnored)
+	recordOutcome(t, c, circuitFailure)
+	if c.State() != CIRCUIT_BREAKER_STATE_CLOSED {
+		t.Fatalf("Expected a success to reset the consecutive failures, got %s", c.State())
+	}
+
+	recordOutcome(t, c, circuitFailure)
+	if c.State() != CIRCUIT_BREAKER_STATE_OPEN {
+		t.Fatalf("Expected the circuit to open, got %s", c.State())
+	}
+
+	advance(400 * time.Millisecond)
+	_, err := c.allow("Check", "01GXSB9YR785C4FYS3C0RTG7B2")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	var circuitOpenErr FgaCircuitOpenError
+	if !errors.As(err, &circuitOpenErr) {
+		t.Fatalf("Expected an FgaCircuitOpenError, got %T", err)
+	}
+	if circuitOpenErr.RetryAfter() != 600*time.Millisecond || circuitOpenErr.EndpointCategory() != "Check" ||
+		circuitOpenErr.StoreId() != "01GXSB9YR785C4FYS3C0RTG7B2" {
+		t.Fatalf("Unexpected error details: %v", circuitOpenErr)
+	}
+	if !c.rejects() {
+		t.Fatalf("Expected an open circuit to reject requests")
+	}
+}
+
+func TestCircuitBreakerFailureRatio(t *testing.T) {
+	c, advance := newTestCircuit(CircuitBreakerConfiguration{FailureRatio: 0.5, MinRequests: 4, WindowInMs: 1000})
+
+	recordOutcome(t, c, circuitFailure)
+	recordOutcome(t, c, circuitSuccess)
+	recordOutcome(t, c, circuitFailure)
+	if c.State() != CIRCUIT_BREAKER_STATE_CLOSED {
+		t.Fatalf("Expected the ratio not to apply before the minimum requests, got %s", c.State())
+	}
+
+	// the window restarts, so the failures above no longer count
+	advance(time.Second)
+	recordOutcome(t, c, circuitSuccess)
+	recordOutcome(t, c, circuitSuccess)
+	recordOutcome(t, c, circuitSuccess)
+	recordOutcome(t, c, circuitFailure)
+	if c.State() != CIRCUIT_BREAKER_STATE_CLOSED {
+		t.Fatalf("Expected a ratio of 0.25 to keep the circuit closed, got %s", c.State())
+	}
+	recordOutcome(t, c, circuitFailure)
+	recordOutcome(t, c, circuitFailure)
+	if c.State() != CIRCUIT_BREAKER_STATE_OPEN {
+		t.Fatalf("Expected a ratio of 0.5 to open the circuit, got %s", c.State())
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	c, advance := newTestCircuit(CircuitBreakerConfiguration{ConsecutiveFailures: 1, OpenDurationInMs: 1000, HalfOpenProbes: 2})
+
+	// a request allowed before the circuit opened does not count once it is open
+	staleGeneration, _ := c.allow("Check", "")
+	recordOutcome(t, c, circuitFailure)
+	c.record(staleGeneration, circuitSuccess)
+	if c.State() != CIRCUIT_BREAKER_STATE_OPEN {
+		t.Fatalf("Expected the circuit to stay open, got %s", c.State())
+	}
+
+	advance(time.Second)
+	first, err := c.allow("Check", "")
+	if err != nil {
+		t.Fatalf("Expected a probe to be allowed, got %v", err)
+	}
+	if c.State() != CIRCUIT_BREAKER_STATE_HALF_OPEN {
+		t.Fatalf("Expected the circuit to be half-open, got %s", c.State())
+	}
+	second, err := c.allow("Check", "")
+	if err != nil {
+		t.Fatalf("Expected a second probe to be allowed, got %v", err)
+	}
+	if _, err := c.allow("Check", ""); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected the requests beyond the probes to be rejected, got %v", err)
+	}
+
+	// a rate limited probe frees its slot
+	c.record(first, circuitIgnored)
+	third, err := c.allow("Check", "")
+	if err != nil {
+		t.Fatalf("Expected the freed probe to be allowed, got %v", err)
+	}
+	c.record(second, circuitSuccess)
+	if c.State() != CIRCUIT_BREAKER_STATE_HALF_OPEN {
+		t.Fatalf("Expected the circuit to wait for every probe, got %s", c.State())
+	}
+	c.record(third, circuitSuccess)
+	if c.State() != CIRCUIT_BREAKER_STATE_CLOSED {
+		t.Fatalf("Expected the circuit to close, got %s", c.State())
+	}
+
+	recordOutcome(t, c, circuitFailure)
+	advance(time.Second)
+	recordOutcome(t, c, circuitFailure)
+	if c.State() != CIRCUIT_BREAKER_STATE_OPEN {
+		t.Fatalf("Expected a failed probe to open the circuit again, got %s", c.State())
+	}
+}
+
+func TestCircuitBreakerScope(t *testing.T) {
+	scopes := map[CircuitBreakerScope]bool{
+		CIRCUIT_BREAKER_SCOPE_CLIENT:   true,
+		CIRCUIT_BREAKER_SCOPE_ENDPOINT: false,
+		CIRCUIT_BREAKER_SCOPE_STORE:    false,
+	}
+	for scope, shared := range scopes {
+		t.Run(string(scope), func(t *testing.T) {
+			breakers := newCircuitBreakers(&Configuration{CircuitBreaker: &CircuitBreakerConfiguration{Scope: scope}}, nil)
+			var first, second *circuit
+			switch scope {
+			case CIRCUIT_BREAKER_SCOPE_ENDPOINT:
+				first, second = breakers.get("Check", "store"), breakers.get("Write", "store")
+			default:
+				first, second = breakers.get("Check", "01GXSB9YR785C4FYS3C0RTG7B2"), breakers.get("Check", "01GXSA8YR785C4FYS3C0RTG7B1")
+			}
+			if (first == second) != shared {
+				t.Fatalf("Expected circuits shared=%v", shared)
+			}
+			if breakers.get("Check", "01GXSB9YR785C4FYS3C0RTG7B2") != first {
+				t.Fatalf("Expected the same request to get the same circuit")
+			}
+		})
+	}
+
+	if newCircuitBreakers(&Configuration{}, nil).get("Check", "") != nil {
+		t.Fatalf("Expected no circuit when the circuit breaker is disabled")
+	}
+}
+
+func TestCircuitBreakerSkipsRetries(t *testing.T) {
+	var attempts int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if healthy.Load() {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"allowed":true}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"code":"internal_error","message":"unavailable"}`))
+	}))
+	defer server.Close()
+
+	cfg, err := NewConfiguration(Configuration{
+		ApiUrl:         server.URL,
+		RetryParams:    &RetryParams{MaxRetry: 5, MinWaitInMs: 1},
+		HTTPClient:     &http.Client{},
+		CircuitBreaker: &CircuitBreakerConfiguration{ConsecutiveFailures: 2, OpenDurationInMs: 1000},
+	})
+	if err != nil {
+		t.Fatalf("failed to create configuration: %v", err)
+	}
+	apiClient := NewAPIClient(cfg)
+	now := time.Now()
+	apiClient.circuitBreakers.now = func() time.Time { return now }
+	check := func() error {
+		_, _, err := apiClient.OpenFgaApi.Check(context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2").
+			Body(CheckRequest{TupleKey: CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}).
+			Execute()
+		return err
+	}
+
+	err = check()
+	var internalErr FgaApiInternalError
+	if !errors.As(err, &internalErr) {
+		t.Fatalf("Expected the error of the last attempt, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("Expected the retries to stop once the circuit opened after 2 attempts, got %d", got)
+	}
+
+	if err := check(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("Expected a rejected request not to be sent, got %d attempts", got)
+	}
+	if state := apiClient.CircuitBreakerState("Check", ""); state != CIRCUIT_BREAKER_STATE_OPEN {
+		t.Fatalf("Expected the circuit to be open, got %s", state)
+	}
+
+	healthy.Store(true)
+	now = now.Add(time.Second)
+	if err := check(); err != nil {
+		t.Fatalf("Expected the probe to succeed, got %v", err)
+	}
+	if state := apiClient.CircuitBreakerState("Check", ""); state != CIRCUIT_BREAKER_STATE_CLOSED {
+		t.Fatalf("Expected the circuit to close, got %s", state)
+	}
+}
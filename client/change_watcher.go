@@ -0,0 +1,352 @@
+package client
+
+import (
+	_context "context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+const (
+	// DefaultChangeWatcherPollInterval is how long the watcher waits before polling again once it has caught up
+	DefaultChangeWatcherPollInterval = time.Second
+	// DefaultChangeWatcherMaxPollInterval is the upper bound of the backoff applied while no new changes arrive or a poll fails
+	DefaultChangeWatcherMaxPollInterval = 30 * time.Second
+)
+
+// CheckpointStore persists the continuation token of the last ReadChanges page that was fully processed by a ChangeWatcher,
+// so that a restarted watcher resumes where the previous one stopped.
+type CheckpointStore interface {
+	// Load returns the token saved under key, or an empty string if there is none
+	Load(ctx _context.Context, key string) (string, error)
+	// Save stores token under key, replacing any previous token
+	Save(ctx _context.Context, key string, token string) error
+}
+
+// InMemoryCheckpointStore is a CheckpointStore that keeps tokens in memory; they are lost when the process exits.
+type InMemoryCheckpointStore struct {
+	lock   sync.RWMutex
+	tokens map[string]string
+}
+
+func NewInMemoryCheckpointStore() *InMemoryCheckpointStore {
+	return &InMemoryCheckpointStore{tokens: make(map[string]string)}
+}
+
+func (s *InMemoryCheckpointStore) Load(_ _context.Context, key string) (string, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.tokens[key], nil
+}
+
+func (s *InMemoryCheckpointStore) Save(_ _context.Context, key string, token string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.tokens[key] = token
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore that keeps tokens in a JSON file. Writes go to a temporary file that is then renamed
+// over the original, so a crash never leaves a partially written checkpoint behind.
+type FileCheckpointStore struct {
+	lock sync.Mutex
+	path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) Load(_ _context.Context, key string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	return tokens[key], nil
+}
+
+func (s *FileCheckpointStore) Save(_ _context.Context, key string, token string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	tokens[key] = token
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path)
+}
+
+func (s *FileCheckpointStore) read() (map[string]string, error) {
+	tokens := make(map[string]string)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return tokens, nil
+	}
+
+	if err = json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// ChangeHandler processes a batch of tuple changes. Returning an error makes the watcher deliver the same batch again after a backoff.
+type ChangeHandler func(ctx _context.Context, changes []fgaSdk.TupleChange) error
+
+type ChangeWatcherOptions struct {
+	RequestOptions
+
+	// StoreId overrides the store id set in the client configuration
+	StoreId *string `json:"store_id,omitempty"`
+	// Type only watches changes to objects of this type
+	Type string `json:"type,omitempty"`
+	// StartTime only watches changes that happened after this time. Ignored when a checkpoint was found.
+	StartTime time.Time `json:"start_time,omitempty"`
+	PageSize  *int32    `json:"page_size,omitempty"`
+	// PollInterval is how long to wait before polling again once the watcher has caught up (default = 1s)
+	PollInterval time.Duration `json:"poll_interval,omitempty"`
+	// MaxPollInterval caps the backoff applied while no new changes arrive or a poll or handler fails (default = 30s)
+	MaxPollInterval time.Duration `json:"max_poll_interval,omitempty"`
+	// CheckpointStore persists the last processed continuation token (default = an in-memory store)
+	CheckpointStore CheckpointStore `json:"-"`
+	// CheckpointKey is the key the continuation token is saved under (default = the store id, followed by "|" and Type if set)
+	CheckpointKey string `json:"checkpoint_key,omitempty"`
+	// OnError is called with every poll, handler or checkpoint error the watcher recovers from
+	OnError func(err error) `json:"-"`
+}
+
+// ChangeWatcher long-polls ReadChanges for a store and delivers the changes to the registered handlers.
+//
+// Delivery is at-least-once: the continuation token of a page is only saved to the CheckpointStore after every handler
+// processed that page successfully, so a batch may be delivered again after a handler error or a restart and handlers
+// should be idempotent.
+type ChangeWatcher struct {
+	client   SdkClient
+	options  ChangeWatcherOptions
+	lock     sync.RWMutex
+	handlers []ChangeHandler
+}
+
+func NewChangeWatcher(client SdkClient, options ChangeWatcherOptions) *ChangeWatcher {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultChangeWatcherPollInterval
+	}
+	if options.MaxPollInterval <= 0 {
+		options.MaxPollInterval = DefaultChangeWatcherMaxPollInterval
+	}
+	if options.MaxPollInterval < options.PollInterval {
+		options.MaxPollInterval = options.PollInterval
+	}
+	if options.CheckpointStore == nil {
+		options.CheckpointStore = NewInMemoryCheckpointStore()
+	}
+
+	return &ChangeWatcher{
+		client:  client,
+		options: options,
+	}
+}
+
+// AddHandler registers a handler; handlers are called in the order they were added
+func (w *ChangeWatcher) AddHandler(handler ChangeHandler) *ChangeWatcher {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.handlers = append(w.handlers, handler)
+	return w
+}
+
+// Run polls for changes until ctx is done, then returns ctx.Err(). It returns early if the store cannot be resolved, the
+// checkpoint cannot be loaded or the server rejects the request as unauthenticated, invalid or not found.
+func (w *ChangeWatcher) Run(ctx _context.Context) error {
+	storeId, err := w.storeId()
+	if err != nil {
+		return err
+	}
+
+	checkpointKey := w.options.CheckpointKey
+	if checkpointKey == "" {
+		checkpointKey = storeId
+		if w.options.Type != "" {
+			checkpointKey += "|" + w.options.Type
+		}
+	}
+
+	token, err := w.options.CheckpointStore.Load(ctx, checkpointKey)
+	if err != nil {
+		return err
+	}
+
+	wait := time.Duration(0)
+	for {
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+
+		response, err := w.readChanges(ctx, storeId, token)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if isFatalChangeWatcherError(err) {
+				return err
+			}
+			w.reportError(err)
+			wait = w.backoff(wait)
+			continue
+		}
+
+		nextToken := response.GetContinuationToken()
+		if len(response.Changes) > 0 {
+			if err := w.deliver(ctx, response.Changes); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				w.reportError(err)
+				wait = w.backoff(wait)
+				continue
+			}
+		}
+
+		if nextToken != "" && nextToken != token {
+			if err := w.options.CheckpointStore.Save(ctx, checkpointKey, nextToken); err != nil {
+				// the batch was processed, so move on and let the next successful save catch up
+				w.reportError(err)
+			}
+			token = nextToken
+		}
+
+		if len(response.Changes) > 0 {
+			// there may be more pages waiting, poll again right away
+			wait = 0
+		} else {
+			wait = w.backoff(wait)
+		}
+	}
+}
+
+func (w *ChangeWatcher) storeId() (string, error) {
+	if w.options.StoreId != nil && *w.options.StoreId != "" {
+		return *w.options.StoreId, nil
+	}
+
+	storeId, err := w.client.GetStoreId()
+	if err != nil {
+		return "", err
+	}
+	if storeId == "" {
+		return "", FgaRequiredParamError{param: "StoreId"}
+	}
+	return storeId, nil
+}
+
+func (w *ChangeWatcher) readChanges(ctx _context.Context, storeId string, token string) (*ClientReadChangesResponse, error) {
+	body := ClientReadChangesRequest{Type: w.options.Type}
+	options := ClientReadChangesOptions{
+		RequestOptions: w.options.RequestOptions,
+		PageSize:       w.options.PageSize,
+		StoreId:        &storeId,
+	}
+	if token != "" {
+		options.ContinuationToken = &token
+	} else {
+		body.StartTime = w.options.StartTime
+	}
+
+	return w.client.ReadChanges(ctx).Body(body).Options(options).Execute()
+}
+
+func (w *ChangeWatcher) deliver(ctx _context.Context, changes []fgaSdk.TupleChange) error {
+	w.lock.RLock()
+	handlers := make([]ChangeHandler, len(w.handlers))
+	copy(handlers, w.handlers)
+	w.lock.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, changes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ChangeWatcher) backoff(wait time.Duration) time.Duration {
+	if wait < w.options.PollInterval {
+		return w.options.PollInterval
+	}
+	wait *= 2
+	if wait > w.options.MaxPollInterval {
+		wait = w.options.MaxPollInterval
+	}
+	return wait
+}
+
+func (w *ChangeWatcher) reportError(err error) {
+	if w.options.OnError != nil {
+		w.options.OnError(err)
+	}
+}
+
+func isFatalChangeWatcherError(err error) bool {
+	switch err.(type) {
+	case fgaSdk.FgaApiAuthenticationError, fgaSdk.FgaApiValidationError, fgaSdk.FgaApiNotFoundError, FgaInvalidError, FgaRequiredParamError:
+		return true
+	}
+	return false
+}
+
+func sleepContext(ctx _context.Context, wait time.Duration) error {
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
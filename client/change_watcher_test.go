@@ -0,0 +1,246 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+
+	openfga "github.com/openfga/go-sdk"
+	. "github.com/openfga/go-sdk/client"
+)
+
+func TestChangeWatcher(t *testing.T) {
+	const storeId = "01GXSB9YR785C4FYS3C0RTG7B2"
+
+	fgaClient, err := NewSdkClient(&ClientConfiguration{
+		ApiUrl:  "https://api.fga.example",
+		StoreId: storeId,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	changesUrl := fmt.Sprintf("https://api.fga.example/stores/%s/changes", storeId)
+
+	change := func(object string) openfga.TupleChange {
+		return openfga.TupleChange{
+			TupleKey:  openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: object},
+			Operation: openfga.TUPLEOPERATION_WRITE,
+			Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	// pages maps the continuation token sent by the watcher to the page returned by the server
+	pages := map[string]openfga.ReadChangesResponse{
+		"": {
+			Changes:           []openfga.TupleChange{change("document:1"), change("document:2")},
+			ContinuationToken: openfga.PtrString("t1"),
+		},
+		"t1": {
+			Changes:           []openfga.TupleChange{change("document:3")},
+			ContinuationToken: openfga.PtrString("t2"),
+		},
+		"t2": {
+			Changes:           []openfga.TupleChange{},
+			ContinuationToken: openfga.PtrString("t2"),
+		},
+	}
+
+	registerChanges := func(sentTokens *[]string, lock *sync.Mutex) {
+		httpmock.RegisterResponder(http.MethodGet, changesUrl,
+			func(req *http.Request) (*http.Response, error) {
+				token := req.URL.Query().Get("continuation_token")
+				lock.Lock()
+				*sentTokens = append(*sentTokens, token)
+				lock.Unlock()
+				return httpmock.NewJsonResponse(http.StatusOK, pages[token])
+			},
+		)
+	}
+
+	t.Run("Delivers changes and saves checkpoints", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		var lock sync.Mutex
+		var sentTokens []string
+		registerChanges(&sentTokens, &lock)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		checkpoints := NewInMemoryCheckpointStore()
+		var received []string
+		watcher := NewChangeWatcher(fgaClient, ChangeWatcherOptions{
+			PollInterval:    time.Millisecond,
+			CheckpointStore: checkpoints,
+		})
+		watcher.AddHandler(func(ctx context.Context, changes []openfga.TupleChange) error {
+			for _, change := range changes {
+				received = append(received, change.TupleKey.Object)
+			}
+			if len(received) == 3 {
+				cancel()
+			}
+			return nil
+		})
+
+		err := watcher.Run(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+
+		if len(received) != 3 || received[0] != "document:1" || received[2] != "document:3" {
+			t.Fatalf("Unexpected changes received: %v", received)
+		}
+
+		token, _ := checkpoints.Load(context.Background(), storeId)
+		if token != "t2" {
+			t.Fatalf("Expected checkpoint t2, got %q", token)
+		}
+	})
+
+	t.Run("Redelivers a batch when a handler fails", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		var lock sync.Mutex
+		var sentTokens []string
+		registerChanges(&sentTokens, &lock)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		checkpoints := NewInMemoryCheckpointStore()
+		var reported []error
+		attempts := 0
+		watcher := NewChangeWatcher(fgaClient, ChangeWatcherOptions{
+			PollInterval:    time.Millisecond,
+			CheckpointStore: checkpoints,
+			OnError: func(err error) {
+				reported = append(reported, err)
+			},
+		})
+		watcher.AddHandler(func(ctx context.Context, changes []openfga.TupleChange) error {
+			attempts++
+			if attempts == 1 {
+				token, _ := checkpoints.Load(ctx, storeId)
+				if token != "" {
+					t.Errorf("Expected no checkpoint before the first batch is processed, got %q", token)
+				}
+				return errors.New("index unavailable")
+			}
+			cancel()
+			return nil
+		})
+
+		_ = watcher.Run(ctx)
+
+		if attempts != 2 {
+			t.Fatalf("Expected the batch to be delivered twice, got %d", attempts)
+		}
+		if len(reported) != 1 {
+			t.Fatalf("Expected 1 reported error, got %v", reported)
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if len(sentTokens) < 2 || sentTokens[0] != "" || sentTokens[1] != "" {
+			t.Fatalf("Expected the first page to be requested twice, got %v", sentTokens)
+		}
+	})
+
+	t.Run("Resumes from a saved checkpoint", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		var lock sync.Mutex
+		var sentTokens []string
+		registerChanges(&sentTokens, &lock)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		checkpoints := NewInMemoryCheckpointStore()
+		_ = checkpoints.Save(ctx, storeId+"|document", "t1")
+
+		watcher := NewChangeWatcher(fgaClient, ChangeWatcherOptions{
+			Type:            "document",
+			PollInterval:    time.Millisecond,
+			CheckpointStore: checkpoints,
+		})
+		watcher.AddHandler(func(ctx context.Context, changes []openfga.TupleChange) error {
+			cancel()
+			return nil
+		})
+
+		_ = watcher.Run(ctx)
+
+		lock.Lock()
+		defer lock.Unlock()
+		if len(sentTokens) == 0 || sentTokens[0] != "t1" {
+			t.Fatalf("Expected the watcher to resume from t1, got %v", sentTokens)
+		}
+	})
+
+	t.Run("Stops on validation errors", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		httpmock.RegisterResponder(http.MethodGet, changesUrl,
+			httpmock.NewJsonResponderOrPanic(http.StatusBadRequest, map[string]string{"code": "invalid_continuation_token", "message": "invalid"}),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		watcher := NewChangeWatcher(fgaClient, ChangeWatcherOptions{PollInterval: time.Millisecond})
+		err := watcher.Run(ctx)
+
+		if _, ok := err.(openfga.FgaApiValidationError); !ok {
+			t.Fatalf("Expected a validation error, got %v", err)
+		}
+	})
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	store := NewFileCheckpointStore(path)
+
+	token, err := store.Load(ctx, "store")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if token != "" {
+		t.Fatalf("Expected no token, got %q", token)
+	}
+
+	if err := store.Save(ctx, "store", "t1"); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := store.Save(ctx, "other", "t9"); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := store.Save(ctx, "store", "t2"); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	reopened := NewFileCheckpointStore(path)
+	for key, expected := range map[string]string{"store": "t2", "other": "t9"} {
+		token, err := reopened.Load(ctx, key)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if token != expected {
+			t.Fatalf("Expected token %q for %q, got %q", expected, key, token)
+		}
+	}
+}
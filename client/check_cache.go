@@ -0,0 +1,284 @@
+package client
+
+import (
+	"container/list"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/internal/constants"
+	"github.com/openfga/go-sdk/telemetry"
+)
+
+// ClientCheckCacheConfiguration enables an opt-in, client-side cache of check results that sits in front of
+// Check, ClientBatchCheck and BatchCheck.
+//
+// Results are kept for at most TTL and the least recently used result is evicted once MaxEntries is reached.
+// Checks sent with CONSISTENCYPREFERENCE_HIGHER_CONSISTENCY always bypass the cache. Successful writes and deletes
+// issued through the same client evict any cached result whose user or object is part of a written or deleted tuple,
+// and a model written through the same client evicts the results of its store checked without an authorization model
+// id; changes made through other clients, or that affect a result only indirectly, are picked up once the TTL expires.
+type ClientCheckCacheConfiguration struct {
+	// MaxEntries is the maximum number of check results kept in memory (default = 10000)
+	MaxEntries int `json:"max_entries,omitempty"`
+	// TTL is how long a check result is served from the cache before it is requested again (default = 10s)
+	TTL time.Duration `json:"ttl,omitempty"`
+}
+
+// checkCacheKey holds everything that can change the result of a check
+type checkCacheKey struct {
+	StoreId              string                     `json:"store_id"`
+	AuthorizationModelId string                     `json:"authorization_model_id"`
+	User                 string                     `json:"user"`
+	Relation             string                     `json:"relation"`
+	Object               string                     `json:"object"`
+	Context              *map[string]interface{}    `json:"context,omitempty"`
+	ContextualTuples     []ClientContextualTupleKey `json:"contextual_tuples,omitempty"`
+}
+
+func (k checkCacheKey) String() (string, error) {
+	// encoding/json sorts map keys, so equal contexts always produce the same key
+	key, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+type checkCacheEntry struct {
+	key       string
+	refs      []string
+	response  fgaSdk.CheckResponse
+	expiresAt time.Time
+}
+
+type checkCache struct {
+	lock       sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	lru        *list.List
+	entries    map[string]*list.Element
+	// refs indexes the cache keys by the store and the objects/users they touch, so writes can evict them
+	refs map[string]map[string]struct{}
+	// generation is bumped on every invalidation so that results of checks that were in-flight during a write are not stored
+	generation uint64
+	now        func() time.Time
+}
+
+func newCheckCache(config *ClientCheckCacheConfiguration) *checkCache {
+	maxEntries := constants.DefaultCheckCacheMaxEntries
+	if config.MaxEntries > 0 {
+		maxEntries = config.MaxEntries
+	}
+
+	ttl := time.Duration(constants.DefaultCheckCacheTTLInMs) * time.Millisecond
+	if config.TTL > 0 {
+		ttl = config.TTL
+	}
+
+	return &checkCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		refs:       make(map[string]map[string]struct{}),
+		now:        time.Now,
+	}
+}
+
+// checkCacheRef returns the index key for an object or user in a store. Usersets (e.g. group:eng#member) are indexed by their object.
+func checkCacheRef(storeId string, objectOrUser string) string {
+	if index := strings.Index(objectOrUser, "#"); index >= 0 {
+		objectOrUser = objectOrUser[:index]
+	}
+	return storeId + "\x00" + objectOrUser
+}
+
+// checkCacheLatestModelRef returns the index key for the results of a store checked without an authorization model id,
+// which the server evaluated against the latest model of the store. It cannot collide with the key of an object or user,
+// which has no "#".
+func checkCacheLatestModelRef(storeId string) string {
+	return storeId + "\x00#latest"
+}
+
+func (c *checkCache) get(key string) (fgaSdk.CheckResponse, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return fgaSdk.CheckResponse{}, false
+	}
+
+	entry := element.Value.(*checkCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.removeElement(element)
+		return fgaSdk.CheckResponse{}, false
+	}
+
+	c.lru.MoveToFront(element)
+	return entry.response, true
+}
+
+func (c *checkCache) currentGeneration() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.generation
+}
+
+// set stores a check result, unless the cache was invalidated since generation was read
+func (c *checkCache) set(key checkCacheKey, keyString string, response fgaSdk.CheckResponse, generation uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	if element, exists := c.entries[keyString]; exists {
+		c.removeElement(element)
+	}
+
+	entry := &checkCacheEntry{
+		key:       keyString,
+		refs:      []string{checkCacheRef(key.StoreId, key.Object), checkCacheRef(key.StoreId, key.User)},
+		response:  response,
+		expiresAt: c.now().Add(c.ttl),
+	}
+	if key.AuthorizationModelId == "" {
+		entry.refs = append(entry.refs, checkCacheLatestModelRef(key.StoreId))
+	}
+	c.entries[keyString] = c.lru.PushFront(entry)
+	for _, ref := range entry.refs {
+		if c.refs[ref] == nil {
+			c.refs[ref] = make(map[string]struct{})
+		}
+		c.refs[ref][keyString] = struct{}{}
+	}
+
+	for c.lru.Len() > c.maxEntries {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+// invalidate evicts every result in the store that touches any of the given objects or users
+func (c *checkCache) invalidate(storeId string, objectsOrUsers ...string) {
+	refs := make([]string, len(objectsOrUsers))
+	for index, objectOrUser := range objectsOrUsers {
+		refs[index] = checkCacheRef(storeId, objectOrUser)
+	}
+	c.evict(refs...)
+}
+
+// invalidateLatestModel evicts every result in the store checked without an authorization model id
+func (c *checkCache) invalidateLatestModel(storeId string) {
+	c.evict(checkCacheLatestModelRef(storeId))
+}
+
+// evict removes every result indexed by any of the refs
+func (c *checkCache) evict(refs ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.generation++
+	for _, ref := range refs {
+		for key := range c.refs[ref] {
+			if element, exists := c.entries[key]; exists {
+				c.removeElement(element)
+			}
+		}
+	}
+}
+
+func (c *checkCache) removeElement(element *list.Element) {
+	entry := element.Value.(*checkCacheEntry)
+	c.lru.Remove(element)
+	delete(c.entries, entry.key)
+	for _, ref := range entry.refs {
+		delete(c.refs[ref], entry.key)
+		if len(c.refs[ref]) == 0 {
+			delete(c.refs, ref)
+		}
+	}
+}
+
+func (c *checkCache) len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.lru.Len()
+}
+
+func isCheckCacheBypassed(consistency *fgaSdk.ConsistencyPreference) bool {
+	return consistency != nil && *consistency == fgaSdk.CONSISTENCYPREFERENCE_HIGHER_CONSISTENCY
+}
+
+// invalidateCheckCache evicts the cached results touching the tuples of a write request
+func (client *OpenFgaClient) invalidateCheckCache(storeId string, body *ClientWriteRequest) {
+	if client.checkCache == nil || body == nil {
+		return
+	}
+
+	objectsOrUsers := make([]string, 0, 2*(len(body.Writes)+len(body.Deletes)))
+	for _, tuple := range body.Writes {
+		objectsOrUsers = append(objectsOrUsers, tuple.Object, tuple.User)
+	}
+	for _, tuple := range body.Deletes {
+		objectsOrUsers = append(objectsOrUsers, tuple.Object, tuple.User)
+	}
+	client.checkCache.invalidate(storeId, objectsOrUsers...)
+}
+
+// batchCheckFromCache splits the checks of a server-side batch check into those answered from the cache and those that need
+// to be sent to the server, returning the cache key of each remaining check by correlation id
+func (client *OpenFgaClient) batchCheckFromCache(storeId string, authorizationModelId string, checks []ClientBatchCheckItem) ([]ClientBatchCheckItem, map[string]fgaSdk.BatchCheckSingleResult, map[string]checkCacheKey) {
+	remaining := make([]ClientBatchCheckItem, 0, len(checks))
+	cached := make(map[string]fgaSdk.BatchCheckSingleResult)
+	keys := make(map[string]checkCacheKey)
+
+	for _, check := range checks {
+		key := checkCacheKey{
+			StoreId:              storeId,
+			AuthorizationModelId: authorizationModelId,
+			User:                 check.User,
+			Relation:             check.Relation,
+			Object:               check.Object,
+			Context:              check.Context,
+			ContextualTuples:     check.ContextualTuples,
+		}
+		keyString, err := key.String()
+		if err != nil {
+			remaining = append(remaining, check)
+			continue
+		}
+		if response, ok := client.checkCache.get(keyString); ok {
+			cached[check.CorrelationId] = fgaSdk.BatchCheckSingleResult{Allowed: fgaSdk.ToPtr(response.GetAllowed())}
+			continue
+		}
+		keys[check.CorrelationId] = key
+		remaining = append(remaining, check)
+	}
+
+	return remaining, cached, keys
+}
+
+func (client *OpenFgaClient) recordCheckCacheMetrics(method string, storeId string, authorizationModelId string, hits int, misses int) {
+	attrs := map[*telemetry.Attribute]string{
+		telemetry.FGAClientRequestMethod:  method,
+		telemetry.FGAClientRequestStoreID: storeId,
+	}
+	if authorizationModelId != "" {
+		attrs[telemetry.FGAClientRequestModelID] = authorizationModelId
+	}
+
+	factory := client.metricsFactory()
+	if hits > 0 {
+		_, _ = telemetry.CheckCacheHitMetric(telemetry.CheckCacheMetricParameters{Value: int64(hits), Attrs: attrs, TelemetryFactoryParameters: factory})
+	}
+	if misses > 0 {
+		_, _ = telemetry.CheckCacheMissMetric(telemetry.CheckCacheMetricParameters{Value: int64(misses), Attrs: attrs, TelemetryFactoryParameters: factory})
+	}
+}
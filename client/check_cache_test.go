@@ -0,0 +1,416 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+
+	openfga "github.com/openfga/go-sdk"
+	. "github.com/openfga/go-sdk/client"
+)
+
+func TestCheckCache(t *testing.T) {
+	const storeId = "01GXSB9YR785C4FYS3C0RTG7B2"
+	const modelId = "01GAHCE4YVKPQEKZQHT2R89MQV"
+
+	newClient := func(t *testing.T, cacheConfig *ClientCheckCacheConfiguration) *OpenFgaClient {
+		fgaClient, err := NewSdkClient(&ClientConfiguration{
+			ApiUrl:               "https://api.fga.example",
+			StoreId:              storeId,
+			AuthorizationModelId: modelId,
+			CheckCache:           cacheConfig,
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return fgaClient
+	}
+
+	checkUrl := fmt.Sprintf("https://api.fga.example/stores/%s/check", storeId)
+	writeUrl := fmt.Sprintf("https://api.fga.example/stores/%s/write", storeId)
+	batchCheckUrl := fmt.Sprintf("https://api.fga.example/stores/%s/batch-check", storeId)
+
+	registerCheck := func(allowed bool) {
+		httpmock.RegisterResponder(http.MethodPost, checkUrl,
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewJsonResponse(http.StatusOK, openfga.CheckResponse{Allowed: openfga.PtrBool(allowed)})
+			},
+		)
+	}
+
+	requestBody := ClientCheckRequest{
+		User:     "user:anne",
+		Relation: "viewer",
+		Object:   "document:roadmap",
+	}
+
+	t.Run("Check results are served from the cache", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+
+		for i := 0; i < 3; i++ {
+			got, err := fgaClient.Check(context.Background()).Body(requestBody).Execute()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if !got.GetAllowed() {
+				t.Fatalf("Check() allowed = %v, want true", got.GetAllowed())
+			}
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 1 {
+			t.Fatalf("Expected 1 call to the server, got %d", count)
+		}
+	})
+
+	t.Run("Checks without a cache always hit the server", func(t *testing.T) {
+		fgaClient := newClient(t, nil)
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+
+		for i := 0; i < 2; i++ {
+			if _, err := fgaClient.Check(context.Background()).Body(requestBody).Execute(); err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 2 {
+			t.Fatalf("Expected 2 calls to the server, got %d", count)
+		}
+	})
+
+	t.Run("Different contexts and contextual tuples are cached separately", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+
+		withContext := requestBody
+		withContext.Context = &map[string]interface{}{"ip": "10.0.0.1"}
+		withContextualTuples := requestBody
+		withContextualTuples.ContextualTuples = []ClientContextualTupleKey{{
+			User:     "user:anne",
+			Relation: "editor",
+			Object:   "document:roadmap",
+		}}
+
+		for _, body := range []ClientCheckRequest{requestBody, withContext, withContextualTuples, withContext} {
+			if _, err := fgaClient.Check(context.Background()).Body(body).Execute(); err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 3 {
+			t.Fatalf("Expected 3 calls to the server, got %d", count)
+		}
+	})
+
+	t.Run("Higher consistency bypasses the cache", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+
+		options := ClientCheckOptions{
+			Consistency: openfga.CONSISTENCYPREFERENCE_HIGHER_CONSISTENCY.Ptr(),
+		}
+		for i := 0; i < 2; i++ {
+			if _, err := fgaClient.Check(context.Background()).Body(requestBody).Options(options).Execute(); err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 2 {
+			t.Fatalf("Expected 2 calls to the server, got %d", count)
+		}
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder(http.MethodPost, checkUrl,
+			httpmock.NewStringResponder(http.StatusBadRequest, `{"code":"validation_error","message":"invalid"}`),
+		)
+
+		for i := 0; i < 2; i++ {
+			if _, err := fgaClient.Check(context.Background()).Body(requestBody).Execute(); err == nil {
+				t.Fatalf("Expected an error but got none")
+			}
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 2 {
+			t.Fatalf("Expected 2 calls to the server, got %d", count)
+		}
+	})
+
+	t.Run("Entries expire after the TTL", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{TTL: 20 * time.Millisecond})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+
+		if _, err := fgaClient.Check(context.Background()).Body(requestBody).Execute(); err != nil {
+			t.Fatalf("%v", err)
+		}
+		time.Sleep(40 * time.Millisecond)
+		if _, err := fgaClient.Check(context.Background()).Body(requestBody).Execute(); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 2 {
+			t.Fatalf("Expected 2 calls to the server, got %d", count)
+		}
+	})
+
+	t.Run("Least recently used entries are evicted", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{MaxEntries: 1})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+
+		other := requestBody
+		other.Object = "document:budget"
+		for _, body := range []ClientCheckRequest{requestBody, other, requestBody} {
+			if _, err := fgaClient.Check(context.Background()).Body(body).Execute(); err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 3 {
+			t.Fatalf("Expected 3 calls to the server, got %d", count)
+		}
+	})
+
+	t.Run("Writes invalidate affected entries", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(false)
+		httpmock.RegisterResponder(http.MethodPost, writeUrl, httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{}))
+
+		unrelated := ClientCheckRequest{
+			User:     "user:bob",
+			Relation: "viewer",
+			Object:   "document:budget",
+		}
+		for _, body := range []ClientCheckRequest{requestBody, unrelated} {
+			if _, err := fgaClient.Check(context.Background()).Body(body).Execute(); err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+
+		_, err := fgaClient.WriteTuples(context.Background()).Body(ClientWriteTuplesBody{{
+			User:     "user:anne",
+			Relation: "viewer",
+			Object:   "document:roadmap",
+		}}).Execute()
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		httpmock.Reset()
+		registerCheck(true)
+
+		got, err := fgaClient.Check(context.Background()).Body(requestBody).Execute()
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if !got.GetAllowed() {
+			t.Fatalf("Expected the written tuple to invalidate the cached result")
+		}
+
+		got, err = fgaClient.Check(context.Background()).Body(unrelated).Execute()
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if got.GetAllowed() {
+			t.Fatalf("Expected the unrelated result to be served from the cache")
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 1 {
+			t.Fatalf("Expected 1 call to the server after the write, got %d", count)
+		}
+	})
+
+	t.Run("Failed writes keep the cached entries", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+		httpmock.RegisterResponder(http.MethodPost, writeUrl,
+			httpmock.NewJsonResponderOrPanic(http.StatusBadRequest, map[string]interface{}{"code": "validation_error", "message": "invalid"}),
+		)
+
+		if _, err := fgaClient.Check(context.Background()).Body(requestBody).Execute(); err != nil {
+			t.Fatalf("%v", err)
+		}
+		_, err := fgaClient.WriteTuples(context.Background()).Body(ClientWriteTuplesBody{{
+			User:     "user:anne",
+			Relation: "viewer",
+			Object:   "document:roadmap",
+		}}).Execute()
+		if err == nil {
+			t.Fatalf("Expected the write to fail")
+		}
+		if _, err := fgaClient.Check(context.Background()).Body(requestBody).Execute(); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 1 {
+			t.Fatalf("Expected the result to be served from the cache after the failed write, got %d calls", count)
+		}
+	})
+
+	t.Run("Writing a model invalidates the entries checked without a model id", func(t *testing.T) {
+		fgaClient, err := NewSdkClient(&ClientConfiguration{
+			ApiUrl:     "https://api.fga.example",
+			StoreId:    storeId,
+			CheckCache: &ClientCheckCacheConfiguration{},
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+		httpmock.RegisterResponder(http.MethodPost, fmt.Sprintf("https://api.fga.example/stores/%s/authorization-models", storeId),
+			httpmock.NewJsonResponderOrPanic(http.StatusCreated, openfga.WriteAuthorizationModelResponse{AuthorizationModelId: modelId}),
+		)
+
+		withModel := ClientCheckOptions{AuthorizationModelId: openfga.PtrString(modelId)}
+		check := func() {
+			if _, err := fgaClient.Check(context.Background()).Body(requestBody).Execute(); err != nil {
+				t.Fatalf("%v", err)
+			}
+			if _, err := fgaClient.Check(context.Background()).Body(requestBody).Options(withModel).Execute(); err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+
+		check()
+		_, err = fgaClient.WriteAuthorizationModel(context.Background()).Body(openfga.WriteAuthorizationModelRequest{
+			SchemaVersion:   "1.1",
+			TypeDefinitions: []openfga.TypeDefinition{{Type: "user"}},
+		}).Execute()
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		check()
+
+		// only the check without a model id is sent again
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 3 {
+			t.Fatalf("Expected 3 calls to the server, got %d", count)
+		}
+	})
+
+	t.Run("Deletes of a userset invalidate entries for its object", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+		httpmock.RegisterResponder(http.MethodPost, writeUrl, httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{}))
+
+		groupCheck := ClientCheckRequest{
+			User:     "user:anne",
+			Relation: "member",
+			Object:   "group:eng",
+		}
+		if _, err := fgaClient.Check(context.Background()).Body(groupCheck).Execute(); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		_, err := fgaClient.DeleteTuples(context.Background()).Body(ClientDeleteTuplesBody{{
+			User:     "group:eng#member",
+			Relation: "viewer",
+			Object:   "document:roadmap",
+		}}).Execute()
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if _, err := fgaClient.Check(context.Background()).Body(groupCheck).Execute(); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if count := httpmock.GetCallCountInfo()["POST "+checkUrl]; count != 2 {
+			t.Fatalf("Expected 2 calls to the server, got %d", count)
+		}
+	})
+
+	t.Run("BatchCheck only sends checks missing from the cache", func(t *testing.T) {
+		fgaClient := newClient(t, &ClientCheckCacheConfiguration{})
+
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerCheck(true)
+
+		var sentChecks []int
+		httpmock.RegisterResponder(http.MethodPost, batchCheckUrl,
+			func(req *http.Request) (*http.Response, error) {
+				var body openfga.BatchCheckRequest
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+				}
+				sentChecks = append(sentChecks, len(body.Checks))
+				result := map[string]openfga.BatchCheckSingleResult{}
+				for _, check := range body.Checks {
+					result[check.CorrelationId] = openfga.BatchCheckSingleResult{Allowed: openfga.PtrBool(false)}
+				}
+				return httpmock.NewJsonResponse(http.StatusOK, openfga.BatchCheckResponse{Result: &result})
+			},
+		)
+
+		if _, err := fgaClient.Check(context.Background()).Body(requestBody).Execute(); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		body := ClientBatchCheckRequest{
+			Checks: []ClientBatchCheckItem{
+				{User: "user:anne", Relation: "viewer", Object: "document:roadmap", CorrelationId: "cached"},
+				{User: "user:bob", Relation: "viewer", Object: "document:roadmap", CorrelationId: "missing"},
+			},
+		}
+
+		for i := 0; i < 2; i++ {
+			got, err := fgaClient.BatchCheck(context.Background()).Body(body).Execute()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			result := got.GetResult()
+			if len(result) != 2 {
+				t.Fatalf("Expected 2 results, got %d", len(result))
+			}
+			cached, missing := result["cached"], result["missing"]
+			if !cached.GetAllowed() {
+				t.Fatalf("Expected the cached check to be allowed")
+			}
+			if missing.GetAllowed() {
+				t.Fatalf("Expected the server check to not be allowed")
+			}
+		}
+
+		if len(sentChecks) != 1 || sentChecks[0] != 1 {
+			t.Fatalf("Expected a single batch check request with 1 check, got %v", sentChecks)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+package client
+
+import (
+	_context "context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/internal/constants"
+)
+
+// writeChunk is a transaction of a chunked write, referencing its tuples by their position in the request
+type writeChunk struct {
+	deletes []int
+	writes  []int
+	// undo is the write reverting the chunk, computed before the chunk is applied when compensating
+	undo ClientWriteRequest
+}
+
+// chunkWrite splits deletes then writes into chunks of at most maxPerChunk tuples
+func chunkWrite(deleteCount int, writeCount int, maxPerChunk int) []writeChunk {
+	var chunks []writeChunk
+	current := writeChunk{}
+	for index := 0; index < deleteCount+writeCount; index++ {
+		if index < deleteCount {
+			current.deletes = append(current.deletes, index)
+		} else {
+			current.writes = append(current.writes, index-deleteCount)
+		}
+		if len(current.deletes)+len(current.writes) == maxPerChunk {
+			chunks = append(chunks, current)
+			current = writeChunk{}
+		}
+	}
+	if len(current.deletes)+len(current.writes) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// writeChunkedTransactions sends a write as a sequence of transactions, stopping at the first failed one and, when
+// CompensateOnFailure is set, reverting the transactions already applied
+func (client *OpenFgaClient) writeChunkedTransactions(ctx _context.Context, body *ClientWriteRequest, options ClientWriteOptions, authorizationModelId *string) (*ClientWriteResponse, error) {
+	request := ClientWriteRequest{}
+	if body != nil {
+		request = *body
+	}
+	maxPerChunk := int32(constants.ClientMaxTuplesPerWrite)
+	if options.Transaction.MaxPerChunk < 0 || options.Transaction.MaxPerChunk > maxPerChunk {
+		return nil, FgaInvalidError{param: "MaxPerChunk", description: fmt.Sprintf("must be between 1 and %d", maxPerChunk)}
+	}
+	if options.Transaction.MaxPerChunk > 0 {
+		maxPerChunk = options.Transaction.MaxPerChunk
+	}
+
+	response := ClientWriteResponse{
+		Writes:  make([]ClientWriteRequestWriteResponse, len(request.Writes)),
+		Deletes: make([]ClientWriteRequestDeleteResponse, len(request.Deletes)),
+	}
+	for index, tuple := range request.Writes {
+		response.Writes[index] = ClientWriteRequestWriteResponse{TupleKey: tuple, Status: FAILURE, Error: ErrWriteChunkSkipped,
+			FailureCategory: CLIENT_WRITE_FAILURE_NOT_APPLIED}
+	}
+	for index, tuple := range request.Deletes {
+		response.Deletes[index] = ClientWriteRequestDeleteResponse{TupleKey: tuple, Status: FAILURE, Error: ErrWriteChunkSkipped,
+			FailureCategory: CLIENT_WRITE_FAILURE_NOT_APPLIED}
+	}
+	setStatus := func(chunk writeChunk, status ClientWriteStatus, chunkResponse *ClientWriteResponse, err error) {
+		for position, index := range chunk.deletes {
+			response.Deletes[index].Status, response.Deletes[index].Error = status, err
+			response.Deletes[index].FailureCategory = ClassifyWriteError(err)
+			if chunkResponse != nil && position < len(chunkResponse.Deletes) {
+				response.Deletes[index].HttpResponse = chunkResponse.Deletes[position].HttpResponse
+			}
+		}
+		for position, index := range chunk.writes {
+			response.Writes[index].Status, response.Writes[index].Error = status, err
+			response.Writes[index].FailureCategory = ClassifyWriteError(err)
+			if chunkResponse != nil && position < len(chunkResponse.Writes) {
+				response.Writes[index].HttpResponse = chunkResponse.Writes[position].HttpResponse
+			}
+		}
+	}
+
+	chunkOptions := ClientWriteOptions{
+		RequestOptions:       options.RequestOptions,
+		AuthorizationModelId: authorizationModelId,
+		StoreId:              options.StoreId,
+		Conflict:             options.Conflict,
+	}
+	var applied []writeChunk
+	chunks := chunkWrite(len(request.Deletes), len(request.Writes), int(maxPerChunk))
+	for position, chunk := range chunks {
+		chunkBody := ClientWriteRequest{}
+		for _, index := range chunk.deletes {
+			chunkBody.Deletes = append(chunkBody.Deletes, request.Deletes[index])
+		}
+		for _, index := range chunk.writes {
+			chunkBody.Writes = append(chunkBody.Writes, request.Writes[index])
+		}
+
+		var chunkResponse *ClientWriteResponse
+		var err error
+		// the last chunk is never reverted: it is either the last one applied, or failed without being applied
+		if options.Transaction.CompensateOnFailure && position < len(chunks)-1 {
+			chunk.undo, err = client.undoWrite(ctx, chunkBody, options)
+		}
+		if err == nil {
+			chunkResponse, err = client.writeExecute(&SdkClientWriteRequest{
+				ctx:     ctx,
+				Client:  client,
+				body:    &chunkBody,
+				options: &chunkOptions,
+			})
+		}
+		if err != nil {
+			setStatus(chunk, FAILURE, chunkResponse, err)
+			if options.Transaction.CompensateOnFailure {
+				for index := len(applied) - 1; index >= 0; index-- {
+					if revertErr := client.revertChunk(ctx, applied[index], chunkOptions); revertErr != nil {
+						return &response, errors.Join(err, revertErr)
+					}
+					setStatus(applied[index], FAILURE, nil, ErrWriteChunkReverted)
+				}
+			}
+			return &response, err
+		}
+		setStatus(chunk, SUCCESS, chunkResponse, nil)
+		applied = append(applied, chunk)
+	}
+
+	return &response, nil
+}
+
+// undoWrite returns the write reverting a write: the tuples it deletes are written again with their current condition,
+// and the tuples it writes are deleted unless they already exist
+func (client *OpenFgaClient) undoWrite(ctx _context.Context, body ClientWriteRequest, options ClientWriteOptions) (ClientWriteRequest, error) {
+	keys := append([]ClientTupleKeyWithoutCondition{}, body.Deletes...)
+	// a duplicate write fails the transaction unless duplicates are ignored, so the written tuples only need to be read then
+	checkWrites := options.Conflict.OnDuplicateWrites == CLIENT_WRITE_REQUEST_ON_DUPLICATE_WRITES_IGNORE
+	if checkWrites {
+		for _, tuple := range body.Writes {
+			keys = append(keys, ClientTupleKeyWithoutCondition{User: tuple.User, Relation: tuple.Relation, Object: tuple.Object})
+		}
+	}
+
+	maxParallelReqs := DEFAULT_MAX_METHOD_PARALLEL_REQS
+	if options.Transaction.MaxParallelRequests > 0 {
+		maxParallelReqs = options.Transaction.MaxParallelRequests
+	}
+	existing := make([]*ClientTupleKey, len(keys))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(int(maxParallelReqs))
+	for index, key := range keys {
+		group.Go(func() error {
+			tuple, err := client.readTuple(groupCtx, key, options)
+			existing[index] = tuple
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return ClientWriteRequest{}, fmt.Errorf("reading the tuples to revert: %w", err)
+	}
+
+	undo := ClientWriteRequest{}
+	for index := range body.Deletes {
+		if existing[index] != nil {
+			undo.Writes = append(undo.Writes, *existing[index])
+		}
+	}
+	for index, tuple := range body.Writes {
+		if checkWrites && existing[len(body.Deletes)+index] != nil {
+			continue
+		}
+		undo.Deletes = append(undo.Deletes, ClientTupleKeyWithoutCondition{User: tuple.User, Relation: tuple.Relation, Object: tuple.Object})
+	}
+	return undo, nil
+}
+
+// readTuple returns the stored tuple with the key, or nil when there is none
+func (client *OpenFgaClient) readTuple(ctx _context.Context, key ClientTupleKeyWithoutCondition, options ClientWriteOptions) (*ClientTupleKey, error) {
+	response, err := client.Read(ctx).Body(ClientReadRequest{
+		User:     &key.User,
+		Relation: &key.Relation,
+		Object:   &key.Object,
+	}).Options(ClientReadOptions{
+		RequestOptions: options.RequestOptions,
+		StoreId:        options.StoreId,
+		Consistency:    fgaSdk.ToPtr(fgaSdk.CONSISTENCYPREFERENCE_HIGHER_CONSISTENCY),
+	}).Execute()
+	if err != nil {
+		return nil, err
+	}
+	for _, tuple := range response.Tuples {
+		if tuple.Key.User == key.User && tuple.Key.Relation == key.Relation && tuple.Key.Object == key.Object {
+			return &tuple.Key, nil
+		}
+	}
+	return nil, nil
+}
+
+// revertChunk applies the undo write of a chunk, ignoring tuples already in the state it reverts to
+func (client *OpenFgaClient) revertChunk(ctx _context.Context, chunk writeChunk, chunkOptions ClientWriteOptions) error {
+	if len(chunk.undo.Writes)+len(chunk.undo.Deletes) == 0 {
+		return nil
+	}
+	chunkOptions.Conflict = ClientWriteConflictOptions{
+		OnDuplicateWrites: CLIENT_WRITE_REQUEST_ON_DUPLICATE_WRITES_IGNORE,
+		OnMissingDeletes:  CLIENT_WRITE_REQUEST_ON_MISSING_DELETES_IGNORE,
+	}
+	_, err := client.writeExecute(&SdkClientWriteRequest{
+		ctx:     ctx,
+		Client:  client,
+		body:    &chunk.undo,
+		options: &chunkOptions,
+	})
+	if err != nil {
+		return fmt.Errorf("reverting an applied chunk: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	. "github.com/openfga/go-sdk/client"
+)
+
+func TestChunkedTransactionWrite(t *testing.T) {
+	fgaClient := newSyncTestClient(t, nil)
+
+	writes := make([]ClientTupleKey, 150)
+	for index := range writes {
+		writes[index] = ClientTupleKey{User: fmt.Sprintf("user:%d", index), Relation: "viewer", Object: "document:1"}
+	}
+
+	// a single transaction exceeds the server limit
+	if _, err := fgaClient.Write(context.Background()).Body(ClientWriteRequest{Writes: writes}).Execute(); err == nil {
+		t.Fatalf("Expected the write to exceed the limit of a transaction")
+	}
+
+	response, err := fgaClient.Write(context.Background()).Body(ClientWriteRequest{Writes: writes}).Options(ClientWriteOptions{
+		Transaction: &TransactionOptions{Chunked: true},
+	}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	for index, write := range response.Writes {
+		if write.Status != SUCCESS || write.TupleKey != writes[index] {
+			t.Fatalf("Unexpected response %+v for write %d", write, index)
+		}
+	}
+	if tuples := readAllTuples(t, fgaClient); len(tuples) != 150 {
+		t.Fatalf("Expected 150 tuples, got %d", len(tuples))
+	}
+
+	_, err = fgaClient.Write(context.Background()).Body(ClientWriteRequest{Writes: writes}).Options(ClientWriteOptions{
+		Transaction: &TransactionOptions{Chunked: true, MaxPerChunk: 101},
+	}).Execute()
+	if _, ok := err.(FgaInvalidError); !ok {
+		t.Fatalf("Expected a chunk exceeding the limit of a transaction to be rejected, got %v", err)
+	}
+}
+
+func TestChunkedTransactionWriteFailure(t *testing.T) {
+	inOffice := &openfga.RelationshipCondition{Name: "in_office", Context: &map[string]interface{}{"floor": 2}}
+	existing := []ClientTupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "document:1", Condition: inOffice},
+		{User: "user:beth", Relation: "viewer", Object: "document:1"},
+	}
+	body := ClientWriteRequest{
+		Deletes: []ClientTupleKeyWithoutCondition{{User: "user:anne", Relation: "viewer", Object: "document:1"}},
+		Writes: []ClientTupleKey{
+			{User: "user:anne", Relation: "viewer", Object: "folder:1"},
+			{User: "user:beth", Relation: "viewer", Object: "document:1"},
+			{User: "user:carl", Relation: "viewer", Object: "document:1"},
+			{User: "user:dave", Relation: "owner", Object: "document:1"},
+			{User: "user:erin", Relation: "viewer", Object: "document:1"},
+			{User: "user:fred", Relation: "viewer", Object: "document:1"},
+		},
+	}
+
+	t.Run("without compensation", func(t *testing.T) {
+		fgaClient := newSyncTestClient(t, existing)
+
+		response, err := fgaClient.Write(context.Background()).Body(body).Options(ClientWriteOptions{
+			Transaction: &TransactionOptions{Chunked: true, MaxPerChunk: 2},
+			Conflict:    ClientWriteConflictOptions{OnDuplicateWrites: CLIENT_WRITE_REQUEST_ON_DUPLICATE_WRITES_IGNORE},
+		}).Execute()
+		if _, ok := err.(openfga.FgaApiValidationError); !ok {
+			t.Fatalf("Expected a validation error, got %v", err)
+		}
+
+		statuses := []ClientWriteStatus{response.Deletes[0].Status}
+		for _, write := range response.Writes {
+			statuses = append(statuses, write.Status)
+		}
+		expected := []ClientWriteStatus{SUCCESS, SUCCESS, SUCCESS, SUCCESS, FAILURE, FAILURE, FAILURE}
+		if !reflect.DeepEqual(statuses, expected) {
+			t.Fatalf("Expected statuses %v, got %v", expected, statuses)
+		}
+		if _, ok := response.Writes[3].Error.(openfga.FgaApiValidationError); !ok {
+			t.Fatalf("Expected the failed chunk to report the validation error, got %v", response.Writes[3].Error)
+		}
+		if !errors.Is(response.Writes[5].Error, ErrWriteChunkSkipped) {
+			t.Fatalf("Expected the last chunk to be skipped, got %v", response.Writes[5].Error)
+		}
+
+		expectedTuples := []ClientTupleKey{existing[1], body.Writes[2], body.Writes[0]}
+		if tuples := readAllTuples(t, fgaClient); !reflect.DeepEqual(tuples, expectedTuples) {
+			t.Fatalf("Expected %v, got %v", expectedTuples, tuples)
+		}
+	})
+
+	t.Run("with compensation", func(t *testing.T) {
+		fgaClient := newSyncTestClient(t, existing)
+
+		response, err := fgaClient.Write(context.Background()).Body(body).Options(ClientWriteOptions{
+			Transaction: &TransactionOptions{Chunked: true, MaxPerChunk: 2, CompensateOnFailure: true},
+			Conflict:    ClientWriteConflictOptions{OnDuplicateWrites: CLIENT_WRITE_REQUEST_ON_DUPLICATE_WRITES_IGNORE},
+		}).Execute()
+		if _, ok := err.(openfga.FgaApiValidationError); !ok {
+			t.Fatalf("Expected a validation error, got %v", err)
+		}
+		if !errors.Is(response.Deletes[0].Error, ErrWriteChunkReverted) || !errors.Is(response.Writes[1].Error, ErrWriteChunkReverted) {
+			t.Fatalf("Expected the first chunk to be reverted, got %+v", response)
+		}
+
+		// the deleted tuple is restored with its condition and the tuple which already existed is kept
+		tuples := readAllTuples(t, fgaClient)
+		if len(tuples) != 2 || tuples[0] != existing[1] || tuples[1].User != "user:anne" || tuples[1].Condition == nil ||
+			tuples[1].Condition.GetContext()["floor"] != float64(2) {
+			t.Fatalf("Expected the tuples to be restored, got %v", tuples)
+		}
+	})
+}
@@ -4,8 +4,10 @@ import (
 	_context "context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
 	_nethttp "net/http"
+	"strconv"
 	"time"
 
 	"github.com/sourcegraph/conc/pool"
@@ -44,6 +46,27 @@ type ClientConfiguration struct {
 	HTTPClient           *_nethttp.Client
 	RetryParams          *fgaSdk.RetryParams
 	Telemetry            *telemetry.Configuration `json:"telemetry,omitempty"`
+	// CheckCache - optional client-side cache of check results, disabled when nil
+	CheckCache *ClientCheckCacheConfiguration `json:"check_cache,omitempty"`
+	// CircuitBreaker - optional circuit breaker failing requests fast while the API is failing, disabled when nil
+	CircuitBreaker *fgaSdk.CircuitBreakerConfiguration `json:"circuit_breaker,omitempty"`
+	// RateLimiter - optional limits of the rate and concurrency of the requests of the client, shared by all its
+	// methods, disabled when nil
+	RateLimiter *fgaSdk.RateLimiterConfiguration `json:"rate_limiter,omitempty"`
+	// Hedging - optional hedging policies of the read methods, sending a second request when the first one is slow,
+	// disabled when nil
+	Hedging *fgaSdk.HedgingConfiguration `json:"hedging,omitempty"`
+	// Retry - optional retry policies replacing the default one of RetryParams, with a retry time budget and a retry
+	// budget shared by all the methods of the client
+	Retry *fgaSdk.RetryConfiguration `json:"retry,omitempty"`
+	// Timeouts - optional timeouts of each attempt of a request and deadlines of the requests, with overrides per
+	// method, disabled when nil
+	Timeouts *fgaSdk.TimeoutConfiguration `json:"timeouts,omitempty"`
+	// Logger - optional logger receiving the structured events of the client, with bearer tokens and client secrets
+	// redacted. When nil, the events are written to stderr in debug mode, and discarded otherwise.
+	Logger *slog.Logger `json:"-"`
+	// LogRedactUsers - redacts the users of the requests from the events of the Logger
+	LogRedactUsers bool `json:"log_redact_users,omitempty"`
 }
 
 func newClientConfiguration(cfg *fgaSdk.Configuration) ClientConfiguration {
@@ -58,11 +81,19 @@ func newClientConfiguration(cfg *fgaSdk.Configuration) ClientConfiguration {
 		HTTPClient:     cfg.HTTPClient,
 		RetryParams:    cfg.RetryParams,
 		Telemetry:      cfg.Telemetry,
+		CircuitBreaker: cfg.CircuitBreaker,
+		RateLimiter:    cfg.RateLimiter,
+		Hedging:        cfg.Hedging,
+		Retry:          cfg.Retry,
+		Timeouts:       cfg.Timeouts,
+		Logger:         cfg.Logger,
+		LogRedactUsers: cfg.LogRedactUsers,
 	}
 }
 
 type OpenFgaClient struct {
-	config ClientConfiguration
+	config     ClientConfiguration
+	checkCache *checkCache
 	SdkClient
 	fgaSdk.APIClient
 }
@@ -79,6 +110,13 @@ func NewSdkClient(cfg *ClientConfiguration) (*OpenFgaClient, error) {
 		HTTPClient:     cfg.HTTPClient,
 		RetryParams:    cfg.RetryParams,
 		Telemetry:      cfg.Telemetry,
+		CircuitBreaker: cfg.CircuitBreaker,
+		RateLimiter:    cfg.RateLimiter,
+		Hedging:        cfg.Hedging,
+		Retry:          cfg.Retry,
+		Timeouts:       cfg.Timeouts,
+		Logger:         cfg.Logger,
+		LogRedactUsers: cfg.LogRedactUsers,
 	})
 
 	if err != nil {
@@ -88,6 +126,7 @@ func NewSdkClient(cfg *ClientConfiguration) (*OpenFgaClient, error) {
 	clientConfig := newClientConfiguration(apiConfiguration)
 	clientConfig.AuthorizationModelId = cfg.AuthorizationModelId
 	clientConfig.StoreId = cfg.StoreId
+	clientConfig.CheckCache = cfg.CheckCache
 
 	// store id is already validate as part of configuration validation
 
@@ -101,9 +140,15 @@ func NewSdkClient(cfg *ClientConfiguration) (*OpenFgaClient, error) {
 
 	apiClient := fgaSdk.NewAPIClient(apiConfiguration)
 
+	var cache *checkCache
+	if cfg.CheckCache != nil {
+		cache = newCheckCache(cfg.CheckCache)
+	}
+
 	return &OpenFgaClient{
-		config:    clientConfig,
-		APIClient: *apiClient,
+		config:     clientConfig,
+		checkCache: cache,
+		APIClient:  *apiClient,
 	}, nil
 }
 
@@ -561,6 +606,84 @@ func (client *OpenFgaClient) getStoreId(storeId *string) (*string, error) {
 	return &store, nil
 }
 
+// startSpan starts the span of a method of the client, when tracing is enabled
+func (client *OpenFgaClient) startSpan(ctx _context.Context, method string) (_context.Context, *telemetry.Span) {
+	return client.Telemetry().Traces.StartClientMethod(ctx, method, map[*telemetry.Attribute]string{})
+}
+
+// startStoreSpan starts the span of a method of the client on the store of storeIdOverride, or else of the client
+func (client *OpenFgaClient) startStoreSpan(ctx _context.Context, method string, storeIdOverride *string) (_context.Context, *telemetry.Span) {
+	ctx, span := client.startSpan(ctx, method)
+	if storeId, err := client.getStoreId(storeIdOverride); err == nil && *storeId != "" {
+		span.SetAttributes(map[*telemetry.Attribute]string{telemetry.FGAClientRequestStoreID: *storeId})
+	}
+
+	return ctx, span
+}
+
+// metricsFactory returns the parameters reporting the metrics to the telemetry of the client
+func (client *OpenFgaClient) metricsFactory() telemetry.TelemetryFactoryParameters {
+	return telemetry.TelemetryFactoryParameters{Configuration: client.config.Telemetry, Telemetry: client.Telemetry()}
+}
+
+// recordBatchCheckSize records the number of checks a batch check method sends in a request, or in parallel requests
+func (client *OpenFgaClient) recordBatchCheckSize(method string, storeId string, size int) {
+	_, _ = telemetry.BatchCheckSizeMetric(telemetry.BatchCheckSizeMetricParameters{
+		Value: float64(size),
+		Attrs: map[*telemetry.Attribute]string{
+			telemetry.FGAClientRequestMethod:  method,
+			telemetry.FGAClientRequestStoreID: storeId,
+		},
+		TelemetryFactoryParameters: client.metricsFactory(),
+	})
+}
+
+// recordWriteTuples records the number of tuples a write wrote and deleted
+func (client *OpenFgaClient) recordWriteTuples(storeIdOverride *string, response *ClientWriteResponse) {
+	if response == nil {
+		return
+	}
+
+	attrs := map[*telemetry.Attribute]string{}
+	if storeId, err := client.getStoreId(storeIdOverride); err == nil && *storeId != "" {
+		attrs[telemetry.FGAClientRequestStoreID] = *storeId
+	}
+
+	record := func(operation string, statuses []ClientWriteStatus) {
+		if len(statuses) == 0 {
+			return
+		}
+
+		succeeded := 0
+		for _, status := range statuses {
+			if status == SUCCESS {
+				succeeded++
+			}
+		}
+
+		operationAttrs := map[*telemetry.Attribute]string{telemetry.FGAClientWriteOperation: operation}
+		for attr, value := range attrs {
+			operationAttrs[attr] = value
+		}
+		_, _ = telemetry.WriteTuplesMetric(telemetry.WriteTuplesMetricParameters{
+			Value:                      float64(succeeded),
+			Attrs:                      operationAttrs,
+			TelemetryFactoryParameters: client.metricsFactory(),
+		})
+	}
+
+	writes := make([]ClientWriteStatus, len(response.Writes))
+	for index, write := range response.Writes {
+		writes[index] = write.Status
+	}
+	deletes := make([]ClientWriteStatus, len(response.Deletes))
+	for index, deleted := range response.Deletes {
+		deletes[index] = deleted.Status
+	}
+	record("write", writes)
+	record("delete", deletes)
+}
+
 /* Stores */
 
 // / ListStores
@@ -605,8 +728,11 @@ func (request *SdkClientListStoresRequest) GetOptions() *ClientListStoresOptions
 	return request.options
 }
 
-func (client *OpenFgaClient) ListStoresExecute(request SdkClientListStoresRequestInterface) (*ClientListStoresResponse, error) {
-	req := client.OpenFgaApi.ListStores(request.GetContext())
+func (client *OpenFgaClient) ListStoresExecute(request SdkClientListStoresRequestInterface) (_ *ClientListStoresResponse, err error) {
+	ctx, span := client.startSpan(request.GetContext(), "ListStores")
+	defer func() { span.End(err) }()
+
+	req := client.OpenFgaApi.ListStores(ctx)
 	options := request.GetOptions()
 	if options != nil {
 		req = req.Options(options.RequestOptions)
@@ -690,7 +816,10 @@ func (request *SdkClientCreateStoreRequest) GetBody() *ClientCreateStoreRequest
 	return request.body
 }
 
-func (client *OpenFgaClient) CreateStoreExecute(request SdkClientCreateStoreRequestInterface) (*ClientCreateStoreResponse, error) {
+func (client *OpenFgaClient) CreateStoreExecute(request SdkClientCreateStoreRequestInterface) (_ *ClientCreateStoreResponse, err error) {
+	ctx, span := client.startSpan(request.GetContext(), "CreateStore")
+	defer func() { span.End(err) }()
+
 	requestOptions := RequestOptions{}
 	if request.GetOptions() != nil {
 		requestOptions = request.GetOptions().RequestOptions
@@ -701,7 +830,7 @@ func (client *OpenFgaClient) CreateStoreExecute(request SdkClientCreateStoreRequ
 	}
 
 	data, _, err := client.OpenFgaApi.
-		CreateStore(request.GetContext()).
+		CreateStore(ctx).
 		Body(requestBody).
 		Options(requestOptions).
 		Execute()
@@ -767,7 +896,10 @@ func (request *SdkClientGetStoreRequest) GetOptions() *ClientGetStoreOptions {
 	return request.options
 }
 
-func (client *OpenFgaClient) GetStoreExecute(request SdkClientGetStoreRequestInterface) (*ClientGetStoreResponse, error) {
+func (client *OpenFgaClient) GetStoreExecute(request SdkClientGetStoreRequestInterface) (_ *ClientGetStoreResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "GetStore", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	storeId, err := client.getStoreId(request.GetStoreIdOverride())
 	if err != nil {
 		return nil, err
@@ -779,7 +911,7 @@ func (client *OpenFgaClient) GetStoreExecute(request SdkClientGetStoreRequestInt
 	}
 
 	data, _, err := client.OpenFgaApi.
-		GetStore(request.GetContext(), *storeId).
+		GetStore(ctx, *storeId).
 		Options(requestOptions).
 		Execute()
 	if err != nil {
@@ -844,7 +976,10 @@ func (request *SdkClientDeleteStoreRequest) GetOptions() *ClientDeleteStoreOptio
 	return request.options
 }
 
-func (client *OpenFgaClient) DeleteStoreExecute(request SdkClientDeleteStoreRequestInterface) (*ClientDeleteStoreResponse, error) {
+func (client *OpenFgaClient) DeleteStoreExecute(request SdkClientDeleteStoreRequestInterface) (_ *ClientDeleteStoreResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "DeleteStore", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	storeId, err := client.getStoreId(request.GetStoreIdOverride())
 	if err != nil {
 		return nil, err
@@ -856,7 +991,7 @@ func (client *OpenFgaClient) DeleteStoreExecute(request SdkClientDeleteStoreRequ
 	}
 
 	_, err = client.OpenFgaApi.
-		DeleteStore(request.GetContext(), *storeId).
+		DeleteStore(ctx, *storeId).
 		Options(requestOptions).
 		Execute()
 	if err != nil {
@@ -925,7 +1060,10 @@ func (request *SdkClientReadAuthorizationModelsRequest) GetOptions() *ClientRead
 	return request.options
 }
 
-func (client *OpenFgaClient) ReadAuthorizationModelsExecute(request SdkClientReadAuthorizationModelsRequestInterface) (*ClientReadAuthorizationModelsResponse, error) {
+func (client *OpenFgaClient) ReadAuthorizationModelsExecute(request SdkClientReadAuthorizationModelsRequestInterface) (_ *ClientReadAuthorizationModelsResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ReadAuthorizationModels", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	pagingOpts := ClientPaginationOptions{}
 	if request.GetOptions() != nil {
 		pagingOpts.PageSize = request.GetOptions().PageSize
@@ -943,7 +1081,7 @@ func (client *OpenFgaClient) ReadAuthorizationModelsExecute(request SdkClientRea
 	}
 
 	req := client.OpenFgaApi.
-		ReadAuthorizationModels(request.GetContext(), *storeId).
+		ReadAuthorizationModels(ctx, *storeId).
 		Options(requestOptions)
 
 	pageSize := getPageSizeFromRequest(&pagingOpts)
@@ -1031,7 +1169,10 @@ func (request *SdkClientWriteAuthorizationModelRequest) GetContext() _context.Co
 	return request.ctx
 }
 
-func (client *OpenFgaClient) WriteAuthorizationModelExecute(request SdkClientWriteAuthorizationModelRequestInterface) (*ClientWriteAuthorizationModelResponse, error) {
+func (client *OpenFgaClient) WriteAuthorizationModelExecute(request SdkClientWriteAuthorizationModelRequestInterface) (_ *ClientWriteAuthorizationModelResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "WriteAuthorizationModel", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	storeId, err := client.getStoreId(request.GetStoreIdOverride())
 	if err != nil {
 		return nil, err
@@ -1043,13 +1184,17 @@ func (client *OpenFgaClient) WriteAuthorizationModelExecute(request SdkClientWri
 	}
 
 	data, _, err := client.OpenFgaApi.
-		WriteAuthorizationModel(request.GetContext(), *storeId).
+		WriteAuthorizationModel(ctx, *storeId).
 		Body(*request.GetBody()).
 		Options(requestOptions).
 		Execute()
 	if err != nil {
 		return nil, err
 	}
+	// the results checked without a model id were evaluated against the model this one replaces as the latest
+	if client.checkCache != nil {
+		client.checkCache.invalidateLatestModel(*storeId)
+	}
 	return &data, nil
 }
 
@@ -1133,7 +1278,10 @@ func (request *SdkClientReadAuthorizationModelRequest) GetContext() _context.Con
 	return request.ctx
 }
 
-func (client *OpenFgaClient) ReadAuthorizationModelExecute(request SdkClientReadAuthorizationModelRequestInterface) (*ClientReadAuthorizationModelResponse, error) {
+func (client *OpenFgaClient) ReadAuthorizationModelExecute(request SdkClientReadAuthorizationModelRequestInterface) (_ *ClientReadAuthorizationModelResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ReadAuthorizationModel", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	authorizationModelId, err := client.getAuthorizationModelId(request.GetAuthorizationModelIdOverride())
 	if err != nil {
 		return nil, err
@@ -1152,7 +1300,7 @@ func (client *OpenFgaClient) ReadAuthorizationModelExecute(request SdkClientRead
 	}
 
 	data, _, err := client.OpenFgaApi.
-		ReadAuthorizationModel(request.GetContext(), *storeId, *authorizationModelId).
+		ReadAuthorizationModel(ctx, *storeId, *authorizationModelId).
 		Options(requestOptions).
 		Execute()
 	if err != nil {
@@ -1222,7 +1370,10 @@ func (request *SdkClientReadLatestAuthorizationModelRequest) GetOptions() *Clien
 	return request.options
 }
 
-func (client *OpenFgaClient) ReadLatestAuthorizationModelExecute(request SdkClientReadLatestAuthorizationModelRequestInterface) (*ClientReadAuthorizationModelResponse, error) {
+func (client *OpenFgaClient) ReadLatestAuthorizationModelExecute(request SdkClientReadLatestAuthorizationModelRequestInterface) (_ *ClientReadAuthorizationModelResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ReadLatestAuthorizationModel", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	opts := ClientReadAuthorizationModelsOptions{
 		PageSize: fgaSdk.PtrInt32(1),
 	}
@@ -1230,7 +1381,7 @@ func (client *OpenFgaClient) ReadLatestAuthorizationModelExecute(request SdkClie
 		opts.StoreId = request.GetOptions().StoreId
 		opts.RequestOptions = request.GetOptions().RequestOptions
 	}
-	req := client.ReadAuthorizationModels(request.GetContext()).Options(opts)
+	req := client.ReadAuthorizationModels(ctx).Options(opts)
 
 	response, err := req.Execute()
 	if err != nil {
@@ -1326,7 +1477,10 @@ func (request *SdkClientReadChangesRequest) GetOptions() *ClientReadChangesOptio
 	return request.options
 }
 
-func (client *OpenFgaClient) ReadChangesExecute(request SdkClientReadChangesRequestInterface) (*ClientReadChangesResponse, error) {
+func (client *OpenFgaClient) ReadChangesExecute(request SdkClientReadChangesRequestInterface) (_ *ClientReadChangesResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ReadChanges", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	pagingOpts := ClientPaginationOptions{}
 	requestOptions := RequestOptions{}
 	if request.GetOptions() != nil {
@@ -1341,7 +1495,7 @@ func (client *OpenFgaClient) ReadChangesExecute(request SdkClientReadChangesRequ
 	}
 
 	req := client.OpenFgaApi.
-		ReadChanges(request.GetContext(), *storeId).
+		ReadChanges(ctx, *storeId).
 		Options(requestOptions)
 	pageSize := getPageSizeFromRequest(&pagingOpts)
 	if pageSize != nil {
@@ -1443,7 +1597,10 @@ func (request *SdkClientReadRequest) GetOptions() *ClientReadOptions {
 	return request.options
 }
 
-func (client *OpenFgaClient) ReadExecute(request SdkClientReadRequestInterface) (*ClientReadResponse, error) {
+func (client *OpenFgaClient) ReadExecute(request SdkClientReadRequestInterface) (_ *ClientReadResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "Read", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	pagingOpts := ClientPaginationOptions{}
 	requestOptions := RequestOptions{}
 	var consistency *fgaSdk.ConsistencyPreference
@@ -1472,7 +1629,7 @@ func (client *OpenFgaClient) ReadExecute(request SdkClientReadRequestInterface)
 	}
 
 	data, _, err := client.OpenFgaApi.
-		Read(request.GetContext(), *storeId).
+		Read(ctx, *storeId).
 		Body(body).
 		Options(requestOptions).
 		Execute()
@@ -1512,9 +1669,21 @@ type TransactionOptions struct {
 	// If set to true will disable running in transaction mode (transaction mode means everything is sent in a single transaction to the server)
 	Disable bool `json:"disable,omitempty"`
 	// When transaction mode is disabled, the requests are chunked and sent separately and each chunk is a transaction (default = 1)
+	// When chunked transaction mode is enabled, each chunk is a transaction of at most this many writes and deletes (default = 100)
 	MaxPerChunk int32 `json:"max_per_chunk,omitempty"`
 	// Number of requests to issue in parallel
 	MaxParallelRequests int32 `json:"max_parallel_requests,omitempty"`
+	// If set to true (and Disable is not), the writes and deletes are split into transactions sent one after another, deletes first.
+	// The chunks after a failed one are not sent.
+	Chunked bool `json:"chunked,omitempty"`
+	// When chunked transaction mode is enabled, a failed chunk causes the chunks already applied to be reverted, in reverse order:
+	// the tuples they wrote are deleted and the tuples they deleted are written again with their condition.
+	// Reverting needs the state of the tuples before each chunk, so every chunk but the last one is preceded by one Read with
+	// HIGHER_CONSISTENCY per tuple it deletes, and per tuple it writes when duplicate writes are ignored, sent MaxParallelRequests at a time
+	CompensateOnFailure bool `json:"compensate_on_failure,omitempty"`
+	// When transaction mode is disabled, the number of times the chunks which failed with a rate limit or a transient error are sent again,
+	// one after another, waiting with exponential backoff based on the configured retry parameters (default = 0)
+	TransientFailureRetries int32 `json:"transient_failure_retries,omitempty"`
 }
 
 // ClientWriteRequestOnDuplicateWrites indicates what to do when a write conflicts with an existing tuple
@@ -1588,6 +1757,8 @@ type ClientWriteRequestWriteResponse struct {
 	Status       ClientWriteStatus  `json:"status,omitempty"`
 	HttpResponse *_nethttp.Response `json:"http_response,omitempty"`
 	Error        error              `json:"error,omitempty"`
+	// FailureCategory classifies the error of a failed write
+	FailureCategory ClientWriteFailureCategory `json:"failure_category,omitempty"`
 }
 
 func (o ClientWriteRequestWriteResponse) MarshalJSON() ([]byte, error) {
@@ -1597,6 +1768,9 @@ func (o ClientWriteRequestWriteResponse) MarshalJSON() ([]byte, error) {
 	if o.Error != nil {
 		toSerialize["error"] = o.Error
 	}
+	if o.FailureCategory != "" {
+		toSerialize["failure_category"] = o.FailureCategory
+	}
 	return json.Marshal(toSerialize)
 }
 
@@ -1605,6 +1779,8 @@ type ClientWriteRequestDeleteResponse struct {
 	Status       ClientWriteStatus              `json:"status,omitempty"`
 	HttpResponse *_nethttp.Response             `json:"http_response,omitempty"`
 	Error        error                          `json:"error,omitempty"`
+	// FailureCategory classifies the error of a failed delete
+	FailureCategory ClientWriteFailureCategory `json:"failure_category,omitempty"`
 }
 
 func (o ClientWriteRequestDeleteResponse) MarshalJSON() ([]byte, error) {
@@ -1614,6 +1790,9 @@ func (o ClientWriteRequestDeleteResponse) MarshalJSON() ([]byte, error) {
 	if o.Error != nil {
 		toSerialize["error"] = o.Error
 	}
+	if o.FailureCategory != "" {
+		toSerialize["failure_category"] = o.FailureCategory
+	}
 	return json.Marshal(toSerialize)
 }
 
@@ -1681,6 +1860,17 @@ func (request *SdkClientWriteRequest) GetBody() *ClientWriteRequest {
 }
 
 func (client *OpenFgaClient) WriteExecute(request SdkClientWriteRequestInterface) (*ClientWriteResponse, error) {
+	response, err := client.writeExecute(request)
+	client.recordWriteTuples(request.GetStoreIdOverride(), response)
+
+	return response, err
+}
+
+// writeExecute sends a write, which WriteExecute sends once, and the transaction modes once per chunk
+func (client *OpenFgaClient) writeExecute(request SdkClientWriteRequestInterface) (_ *ClientWriteResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "Write", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	options := request.GetOptions()
 	transactionOptionsSet := options != nil && options.Transaction != nil
 	response := ClientWriteResponse{
@@ -1702,6 +1892,11 @@ func (client *OpenFgaClient) WriteExecute(request SdkClientWriteRequestInterface
 		return nil, err
 	}
 
+	// In chunked transaction mode, the client will send the request in transactions of at most MaxPerChunk tuples
+	if transactionOptionsSet && !options.Transaction.Disable && options.Transaction.Chunked {
+		return client.writeChunkedTransactions(ctx, request.GetBody(), *options, authorizationModelId)
+	}
+
 	// Unless explicitly disabled, transaction mode is enabled
 	// In transaction mode, the client will send the request to the server as is
 	if !transactionOptionsSet || !options.Transaction.Disable {
@@ -1730,24 +1925,29 @@ func (client *OpenFgaClient) WriteExecute(request SdkClientWriteRequestInterface
 		}
 
 		_, httpResponse, err := client.OpenFgaApi.
-			Write(request.GetContext(), *storeId).
+			Write(ctx, *storeId).
 			Body(writeRequest).
 			Options(requestOptions).
 			Execute()
+		if err == nil {
+			client.invalidateCheckCache(*storeId, request.GetBody())
+		}
 
 		clientWriteStatus := SUCCESS
 		if err != nil {
 			clientWriteStatus = FAILURE
 		}
+		failureCategory := ClassifyWriteError(err)
 
 		if request.GetBody() != nil && request.GetBody().Writes != nil {
 			writeRequestTupleKeys := request.GetBody().Writes
 			for index := 0; index < len(writeRequestTupleKeys); index++ {
 				response.Writes = append(response.Writes, ClientWriteRequestWriteResponse{
-					TupleKey:     writeRequestTupleKeys[index],
-					HttpResponse: httpResponse,
-					Status:       clientWriteStatus,
-					Error:        err,
+					TupleKey:        writeRequestTupleKeys[index],
+					HttpResponse:    httpResponse,
+					Status:          clientWriteStatus,
+					Error:           err,
+					FailureCategory: failureCategory,
 				})
 			}
 		}
@@ -1756,10 +1956,11 @@ func (client *OpenFgaClient) WriteExecute(request SdkClientWriteRequestInterface
 			deleteRequestTupleKeys := request.GetBody().Deletes
 			for index := 0; index < len(deleteRequestTupleKeys); index++ {
 				response.Deletes = append(response.Deletes, ClientWriteRequestDeleteResponse{
-					TupleKey:     deleteRequestTupleKeys[index],
-					HttpResponse: httpResponse,
-					Status:       clientWriteStatus,
-					Error:        err,
+					TupleKey:        deleteRequestTupleKeys[index],
+					HttpResponse:    httpResponse,
+					Status:          clientWriteStatus,
+					Error:           err,
+					FailureCategory: failureCategory,
 				})
 			}
 		}
@@ -1791,15 +1992,15 @@ func (client *OpenFgaClient) WriteExecute(request SdkClientWriteRequestInterface
 		}
 	}
 
-	writeGroup, ctx := errgroup.WithContext(request.GetContext())
+	writeGroup, writeCtx := errgroup.WithContext(ctx)
 
 	writeGroup.SetLimit(int(maxParallelReqs))
 	writeResponses := make([]ClientWriteResponse, len(writeChunks))
 	for index, writeBody := range writeChunks {
 		index, writeBody := index, writeBody
 		writeGroup.Go(func() error {
-			singleResponse, err := client.WriteExecute(&SdkClientWriteRequest{
-				ctx:    ctx,
+			singleResponse, err := client.writeExecute(&SdkClientWriteRequest{
+				ctx:    writeCtx,
 				Client: client,
 				body: &ClientWriteRequest{
 					Writes: writeBody,
@@ -1828,6 +2029,23 @@ func (client *OpenFgaClient) WriteExecute(request SdkClientWriteRequestInterface
 		return &response, err
 	}
 
+	chunkOptions := ClientWriteOptions{
+		RequestOptions:       options.RequestOptions,
+		AuthorizationModelId: authorizationModelId,
+		StoreId:              request.GetStoreIdOverride(),
+		Conflict:             options.Conflict,
+	}
+	if options.Transaction.TransientFailureRetries > 0 {
+		writeBodies := make([]ClientWriteRequest, len(writeChunks))
+		for index, writeBody := range writeChunks {
+			writeBodies[index] = ClientWriteRequest{Writes: writeBody}
+		}
+		err = client.requeueTransientFailures(ctx, writeBodies, writeResponses, chunkOptions, int(options.Transaction.TransientFailureRetries))
+		if err != nil {
+			return &response, err
+		}
+	}
+
 	var deleteChunkSize = int(maxPerChunk)
 	var deleteChunks [][]ClientTupleKeyWithoutCondition
 	if request.GetBody() != nil {
@@ -1838,14 +2056,14 @@ func (client *OpenFgaClient) WriteExecute(request SdkClientWriteRequestInterface
 		}
 	}
 
-	deleteGroup, ctx := errgroup.WithContext(request.GetContext())
+	deleteGroup, deleteCtx := errgroup.WithContext(ctx)
 	deleteGroup.SetLimit(int(maxParallelReqs))
 	deleteResponses := make([]ClientWriteResponse, len(deleteChunks))
 	for index, deleteBody := range deleteChunks {
 		index, deleteBody := index, deleteBody
 		deleteGroup.Go(func() error {
-			singleResponse, err := client.WriteExecute(&SdkClientWriteRequest{
-				ctx:    ctx,
+			singleResponse, err := client.writeExecute(&SdkClientWriteRequest{
+				ctx:    deleteCtx,
 				Client: client,
 				body: &ClientWriteRequest{
 					Deletes: deleteBody,
@@ -1874,6 +2092,17 @@ func (client *OpenFgaClient) WriteExecute(request SdkClientWriteRequestInterface
 		return &response, err
 	}
 
+	if options.Transaction.TransientFailureRetries > 0 {
+		deleteBodies := make([]ClientWriteRequest, len(deleteChunks))
+		for index, deleteBody := range deleteChunks {
+			deleteBodies[index] = ClientWriteRequest{Deletes: deleteBody}
+		}
+		err = client.requeueTransientFailures(ctx, deleteBodies, deleteResponses, chunkOptions, int(options.Transaction.TransientFailureRetries))
+		if err != nil {
+			return &response, err
+		}
+	}
+
 	for _, writeResponse := range writeResponses {
 		response.Writes = append(response.Writes, writeResponse.Writes...)
 	}
@@ -1939,8 +2168,11 @@ func (request *SdkClientWriteTuplesRequest) GetOptions() *ClientWriteOptions {
 	return request.options
 }
 
-func (client *OpenFgaClient) WriteTuplesExecute(request SdkClientWriteTuplesRequestInterface) (*ClientWriteResponse, error) {
-	baseReq := client.Write(request.GetContext()).Body(ClientWriteRequest{
+func (client *OpenFgaClient) WriteTuplesExecute(request SdkClientWriteTuplesRequestInterface) (_ *ClientWriteResponse, err error) {
+	ctx, span := client.startSpan(request.GetContext(), "WriteTuples")
+	defer func() { span.End(err) }()
+
+	baseReq := client.Write(ctx).Body(ClientWriteRequest{
 		Writes: *request.GetBody(),
 	})
 	if request.GetOptions() != nil {
@@ -2003,8 +2235,11 @@ func (request *SdkClientDeleteTuplesRequest) GetOptions() *ClientWriteOptions {
 	return request.options
 }
 
-func (client *OpenFgaClient) DeleteTuplesExecute(request SdkClientDeleteTuplesRequestInterface) (*ClientWriteResponse, error) {
-	baseReq := client.Write(request.GetContext()).Body(ClientWriteRequest{
+func (client *OpenFgaClient) DeleteTuplesExecute(request SdkClientDeleteTuplesRequestInterface) (_ *ClientWriteResponse, err error) {
+	ctx, span := client.startSpan(request.GetContext(), "DeleteTuples")
+	defer func() { span.End(err) }()
+
+	baseReq := client.Write(ctx).Body(ClientWriteRequest{
 		Deletes: *request.GetBody(),
 	})
 	if request.GetOptions() != nil {
@@ -2105,7 +2340,10 @@ func (request *SdkClientCheckRequest) GetOptions() *ClientCheckOptions {
 	return request.options
 }
 
-func (client *OpenFgaClient) CheckExecute(request SdkClientCheckRequestInterface) (*ClientCheckResponse, error) {
+func (client *OpenFgaClient) CheckExecute(request SdkClientCheckRequestInterface) (_ *ClientCheckResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "Check", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	if request.GetBody() == nil {
 		return nil, FgaRequiredParamError{param: "body"}
 	}
@@ -2141,11 +2379,41 @@ func (client *OpenFgaClient) CheckExecute(request SdkClientCheckRequestInterface
 		requestBody.Consistency = request.GetOptions().Consistency
 	}
 
+	useCache := client.checkCache != nil && !isCheckCacheBypassed(requestBody.Consistency)
+	var cacheKey checkCacheKey
+	var cacheKeyString string
+	var cacheGeneration uint64
+	if useCache {
+		cacheKey = checkCacheKey{
+			StoreId:              *storeId,
+			AuthorizationModelId: *authorizationModelId,
+			User:                 requestBody.TupleKey.User,
+			Relation:             requestBody.TupleKey.Relation,
+			Object:               requestBody.TupleKey.Object,
+			Context:              requestBody.Context,
+			ContextualTuples:     contextualTuples,
+		}
+		// a context that cannot be serialized would be rejected by the server anyway, so skip the cache for it
+		cacheKeyString, err = cacheKey.String()
+		useCache = err == nil
+	}
+	if useCache {
+		if cached, ok := client.checkCache.get(cacheKeyString); ok {
+			client.recordCheckCacheMetrics("Check", *storeId, cacheKey.AuthorizationModelId, 1, 0)
+			return &ClientCheckResponse{CheckResponse: cached}, nil
+		}
+		client.recordCheckCacheMetrics("Check", *storeId, cacheKey.AuthorizationModelId, 0, 1)
+		cacheGeneration = client.checkCache.currentGeneration()
+	}
+
 	data, httpResponse, err := client.OpenFgaApi.
-		Check(request.GetContext(), *storeId).
+		Check(ctx, *storeId).
 		Body(requestBody).
 		Options(requestOptions).
 		Execute()
+	if useCache && err == nil {
+		client.checkCache.set(cacheKey, cacheKeyString, data, cacheGeneration)
+	}
 	return &ClientCheckResponse{CheckResponse: data, HttpResponse: httpResponse}, err
 }
 
@@ -2237,8 +2505,11 @@ func (request *SdkClientBatchCheckClientRequest) GetOptions() *ClientBatchCheckC
 	return request.options
 }
 
-func (client *OpenFgaClient) ClientBatchCheckExecute(request SdkClientBatchCheckClientRequestInterface) (*ClientBatchCheckClientResponse, error) {
-	group, ctx := errgroup.WithContext(request.GetContext())
+func (client *OpenFgaClient) ClientBatchCheckExecute(request SdkClientBatchCheckClientRequestInterface) (_ *ClientBatchCheckClientResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ClientBatchCheck", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
+	group, ctx := errgroup.WithContext(ctx)
 	requestOptions := RequestOptions{}
 	maxParallelReqs := int(DEFAULT_MAX_METHOD_PARALLEL_REQS)
 	if request.GetOptions() != nil {
@@ -2250,6 +2521,7 @@ func (client *OpenFgaClient) ClientBatchCheckExecute(request SdkClientBatchCheck
 
 	group.SetLimit(maxParallelReqs)
 	var numOfChecks = len(*request.GetBody())
+	span.SetAttributes(map[*telemetry.Attribute]string{telemetry.FGAClientRequestBatchCheckSize: strconv.Itoa(numOfChecks)})
 	response := make(ClientBatchCheckClientResponse, numOfChecks)
 	authorizationModelId, err := client.getAuthorizationModelId(request.GetAuthorizationModelIdOverride())
 	if err != nil {
@@ -2272,6 +2544,7 @@ func (client *OpenFgaClient) ClientBatchCheckExecute(request SdkClientBatchCheck
 		checkOptions.Consistency = request.GetOptions().Consistency
 	}
 
+	client.recordBatchCheckSize("ClientBatchCheck", *storeId, numOfChecks)
 	for index, checkBody := range *request.GetBody() {
 		index, checkBody := index, checkBody
 		group.Go(func() error {
@@ -2362,8 +2635,10 @@ func (client *OpenFgaClient) BatchCheck(ctx _context.Context) SdkClientBatchChec
  * @param request SdkClientBatchCheckRequestInterface - the request interface
  * @return *fgaSdk.BatchCheckResponse
  */
-func (client *OpenFgaClient) BatchCheckExecute(request SdkClientBatchCheckRequestInterface) (*fgaSdk.BatchCheckResponse, error) {
-	ctx := request.GetContext()
+func (client *OpenFgaClient) BatchCheckExecute(request SdkClientBatchCheckRequestInterface) (_ *fgaSdk.BatchCheckResponse, err error) {
+	ctx, span := client.startSpan(request.GetContext(), "BatchCheck")
+	defer func() { span.End(err) }()
+
 	body := request.GetBody()
 	options := request.GetOptions()
 
@@ -2385,7 +2660,7 @@ func (client *OpenFgaClient) BatchCheckExecute(request SdkClientBatchCheckReques
 		maxBatchSize = *options.MaxBatchSize
 	}
 
-	_, err := client.getStoreId(options.StoreId)
+	storeId, err := client.getStoreId(options.StoreId)
 	if err != nil {
 		return nil, err
 	}
@@ -2395,12 +2670,29 @@ func (client *OpenFgaClient) BatchCheckExecute(request SdkClientBatchCheckReques
 		return nil, err
 	}
 
-	chunks := chunkClientBatchCheckItems(body.Checks, int(maxBatchSize))
+	span.SetAttributes(map[*telemetry.Attribute]string{
+		telemetry.FGAClientRequestStoreID:        *storeId,
+		telemetry.FGAClientRequestBatchCheckSize: strconv.Itoa(len(body.Checks)),
+	})
+
+	checks := body.Checks
+	combinedResult := make(map[string]fgaSdk.BatchCheckSingleResult)
+	var cacheKeys map[string]checkCacheKey
+	var cacheGeneration uint64
+	useCache := client.checkCache != nil && !isCheckCacheBypassed(options.Consistency)
+	if useCache {
+		cacheGeneration = client.checkCache.currentGeneration()
+		checks, combinedResult, cacheKeys = client.batchCheckFromCache(*storeId, *authorizationModelId, checks)
+		client.recordCheckCacheMetrics("BatchCheck", *storeId, *authorizationModelId, len(combinedResult), len(checks))
+	}
+
+	chunks := chunkClientBatchCheckItems(checks, int(maxBatchSize))
 
 	p := pool.NewWithResults[*fgaSdk.BatchCheckResponse]().WithContext(ctx).WithMaxGoroutines(int(maxParallelRequests))
 
 	for _, chunk := range chunks {
 		chunkCopy := chunk
+		client.recordBatchCheckSize("BatchCheck", *storeId, len(chunkCopy))
 
 		p.Go(func(ctx _context.Context) (*fgaSdk.BatchCheckResponse, error) {
 			batchCheckRequest := createBatchCheckRequest(chunkCopy, authorizationModelId, options.Consistency)
@@ -2413,11 +2705,14 @@ func (client *OpenFgaClient) BatchCheckExecute(request SdkClientBatchCheckReques
 		return nil, err
 	}
 
-	combinedResult := make(map[string]fgaSdk.BatchCheckSingleResult)
-
 	for _, response := range responses {
 		for correlationID, result := range response.GetResult() {
 			combinedResult[correlationID] = result
+			if key, ok := cacheKeys[correlationID]; ok && result.Error == nil && result.Allowed != nil {
+				if keyString, err := key.String(); err == nil {
+					client.checkCache.set(key, keyString, fgaSdk.CheckResponse{Allowed: result.Allowed}, cacheGeneration)
+				}
+			}
 		}
 	}
 
@@ -2614,7 +2909,10 @@ func (request *SdkClientExpandRequest) GetOptions() *ClientExpandOptions {
 	return request.options
 }
 
-func (client *OpenFgaClient) ExpandExecute(request SdkClientExpandRequestInterface) (*ClientExpandResponse, error) {
+func (client *OpenFgaClient) ExpandExecute(request SdkClientExpandRequestInterface) (_ *ClientExpandResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "Expand", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	authorizationModelId, err := client.getAuthorizationModelId(request.GetAuthorizationModelIdOverride())
 	if err != nil {
 		return nil, err
@@ -2647,7 +2945,7 @@ func (client *OpenFgaClient) ExpandExecute(request SdkClientExpandRequestInterfa
 	}
 
 	data, _, err := client.OpenFgaApi.
-		Expand(request.GetContext(), *storeId).
+		Expand(ctx, *storeId).
 		Body(body).
 		Options(requestOptions).
 		Execute()
@@ -2743,7 +3041,10 @@ func (request *SdkClientListObjectsRequest) GetOptions() *ClientListObjectsOptio
 	return request.options
 }
 
-func (client *OpenFgaClient) ListObjectsExecute(request SdkClientListObjectsRequestInterface) (*ClientListObjectsResponse, error) {
+func (client *OpenFgaClient) ListObjectsExecute(request SdkClientListObjectsRequestInterface) (_ *ClientListObjectsResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ListObjects", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	var contextualTuples []ClientContextualTupleKey
 	if request.GetBody().ContextualTuples != nil {
 		for index := 0; index < len(request.GetBody().ContextualTuples); index++ {
@@ -2772,7 +3073,7 @@ func (client *OpenFgaClient) ListObjectsExecute(request SdkClientListObjectsRequ
 		body.Consistency = request.GetOptions().Consistency
 	}
 	data, _, err := client.OpenFgaApi.
-		ListObjects(request.GetContext(), *storeId).
+		ListObjects(ctx, *storeId).
 		Body(body).
 		Options(requestOptions).
 		Execute()
@@ -2878,7 +3179,10 @@ func (request *SdkClientListRelationsRequest) GetOptions() *ClientListRelationsO
 	return request.options
 }
 
-func (client *OpenFgaClient) ListRelationsExecute(request SdkClientListRelationsRequestInterface) (*ClientListRelationsResponse, error) {
+func (client *OpenFgaClient) ListRelationsExecute(request SdkClientListRelationsRequestInterface) (_ *ClientListRelationsResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ListRelations", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	if len(request.GetBody().Relations) <= 0 {
 		return nil, fmt.Errorf("ListRelations - expected len(Relations) > 0")
 	}
@@ -2913,7 +3217,7 @@ func (client *OpenFgaClient) ListRelationsExecute(request SdkClientListRelations
 	}
 
 	batchResponse, err := client.ClientBatchCheckExecute(&SdkClientBatchCheckClientRequest{
-		ctx:     request.GetContext(),
+		ctx:     ctx,
 		Client:  client,
 		body:    &batchRequestBody,
 		options: options,
@@ -3020,7 +3324,10 @@ func (request *SdkClientListUsersRequest) GetOptions() *ClientListUsersOptions {
 	return request.options
 }
 
-func (client *OpenFgaClient) ListUsersExecute(request SdkClientListUsersRequestInterface) (*ClientListUsersResponse, error) {
+func (client *OpenFgaClient) ListUsersExecute(request SdkClientListUsersRequestInterface) (_ *ClientListUsersResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ListUsers", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	var contextualTuples []ClientContextualTupleKey
 	if request.GetBody().ContextualTuples != nil {
 		for index := 0; index < len(request.GetBody().ContextualTuples); index++ {
@@ -3051,7 +3358,7 @@ func (client *OpenFgaClient) ListUsersExecute(request SdkClientListUsersRequestI
 	}
 
 	data, _, err := client.OpenFgaApi.
-		ListUsers(request.GetContext(), *storeId).
+		ListUsers(ctx, *storeId).
 		Body(body).
 		Options(requestOptions).
 		Execute()
@@ -3126,7 +3433,10 @@ func (request *SdkClientReadAssertionsRequest) GetOptions() *ClientReadAssertion
 	return request.options
 }
 
-func (client *OpenFgaClient) ReadAssertionsExecute(request SdkClientReadAssertionsRequestInterface) (*ClientReadAssertionsResponse, error) {
+func (client *OpenFgaClient) ReadAssertionsExecute(request SdkClientReadAssertionsRequestInterface) (_ *ClientReadAssertionsResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "ReadAssertions", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	authorizationModelId, err := client.getAuthorizationModelId(request.GetAuthorizationModelIdOverride())
 	if err != nil {
 		return nil, err
@@ -3145,7 +3455,7 @@ func (client *OpenFgaClient) ReadAssertionsExecute(request SdkClientReadAssertio
 	}
 
 	data, _, err := client.OpenFgaApi.
-		ReadAssertions(request.GetContext(), *storeId, *authorizationModelId).
+		ReadAssertions(ctx, *storeId, *authorizationModelId).
 		Options(requestOptions).
 		Execute()
 	if err != nil {
@@ -3261,7 +3571,10 @@ func (request *SdkClientWriteAssertionsRequest) GetOptions() *ClientWriteAsserti
 	return request.options
 }
 
-func (client *OpenFgaClient) WriteAssertionsExecute(request SdkClientWriteAssertionsRequestInterface) (*ClientWriteAssertionsResponse, error) {
+func (client *OpenFgaClient) WriteAssertionsExecute(request SdkClientWriteAssertionsRequestInterface) (_ *ClientWriteAssertionsResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "WriteAssertions", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	writeAssertionsRequest := fgaSdk.WriteAssertionsRequest{}
 	authorizationModelId, err := client.getAuthorizationModelId(request.GetAuthorizationModelIdOverride())
 	if err != nil {
@@ -3285,7 +3598,7 @@ func (client *OpenFgaClient) WriteAssertionsExecute(request SdkClientWriteAssert
 	}
 
 	_, err = client.OpenFgaApi.
-		WriteAssertions(request.GetContext(), *storeId, *authorizationModelId).
+		WriteAssertions(ctx, *storeId, *authorizationModelId).
 		Body(writeAssertionsRequest).
 		Options(requestOptions).
 		Execute()
@@ -3396,7 +3709,10 @@ func (request *SdkClientStreamedListObjectsRequest) GetOptions() *ClientStreamed
 	return request.options
 }
 
-func (client *OpenFgaClient) StreamedListObjectsExecute(request SdkClientStreamedListObjectsRequestInterface) (*ClientStreamedListObjectsResponse, error) {
+func (client *OpenFgaClient) StreamedListObjectsExecute(request SdkClientStreamedListObjectsRequestInterface) (_ *ClientStreamedListObjectsResponse, err error) {
+	ctx, span := client.startStoreSpan(request.GetContext(), "StreamedListObjects", request.GetStoreIdOverride())
+	defer func() { span.End(err) }()
+
 	if request.GetBody() == nil {
 		return nil, FgaRequiredParamError{param: "body"}
 	}
@@ -3434,7 +3750,7 @@ func (client *OpenFgaClient) StreamedListObjectsExecute(request SdkClientStreame
 
 	channel, err := fgaSdk.ExecuteStreamedListObjectsWithBufferSize(
 		&client.APIClient,
-		request.GetContext(),
+		ctx,
 		*storeId,
 		body,
 		requestOptions,
@@ -5,14 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/jarcoal/httpmock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	openfga "github.com/openfga/go-sdk"
 	. "github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/fgatest"
+	"github.com/openfga/go-sdk/telemetry"
 )
 
 type TestDefinition struct {
@@ -4342,3 +4351,244 @@ func getStoreId(t *testing.T, fgaClient *OpenFgaClient) string {
 	}
 	return storeId
 }
+
+func TestOpenFgaClientRateLimiter(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	fgaClient, err := NewSdkClient(&ClientConfiguration{
+		ApiUrl:      "https://api.fga.example",
+		StoreId:     "01GXSB9YR785C4FYS3C0RTG7B2",
+		RateLimiter: &openfga.RateLimiterConfiguration{MaxInFlightRequests: 3},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var lock sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		fmt.Sprintf("%s/stores/%s/batch-check", fgaClient.GetConfig().ApiUrl, "01GXSB9YR785C4FYS3C0RTG7B2"),
+		func(req *http.Request) (*http.Response, error) {
+			lock.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			lock.Unlock()
+			time.Sleep(2 * time.Millisecond)
+			lock.Lock()
+			inFlight--
+			lock.Unlock()
+			return httpmock.NewJsonResponse(http.StatusOK, openfga.BatchCheckResponse{Result: &map[string]openfga.BatchCheckSingleResult{}})
+		},
+	)
+
+	var checks []ClientBatchCheckItem
+	for i := 0; i < 20; i++ {
+		checks = append(checks, ClientBatchCheckItem{
+			User:          fmt.Sprintf("user:%d", i),
+			Relation:      "viewer",
+			Object:        "document:roadmap",
+			CorrelationId: fmt.Sprintf("%d", i),
+		})
+	}
+
+	// two calls fanning out with their own parallelism share the limit of the client
+	var wg sync.WaitGroup
+	for call := 0; call < 2; call++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := fgaClient.BatchCheck(context.Background()).Body(ClientBatchCheckRequest{Checks: checks}).Options(BatchCheckOptions{
+				MaxBatchSize:        openfga.ToPtr(int32(1)),
+				MaxParallelRequests: openfga.ToPtr(int32(10)),
+			}).Execute()
+			if err != nil {
+				t.Errorf("%v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 3 {
+		t.Fatalf("Expected at most 3 requests in flight, got %d", maxInFlight)
+	}
+	if limit := fgaClient.RateLimiterInFlightLimit(); limit != 3 {
+		t.Fatalf("Expected an in-flight limit of 3, got %d", limit)
+	}
+}
+
+func TestOpenFgaClientHedging(t *testing.T) {
+	server := fgatest.NewTestServer(t)
+	fgaClient, err := NewSdkClient(&ClientConfiguration{
+		ApiUrl:      server.URL,
+		RetryParams: &openfga.RetryParams{MaxRetry: 0, MinWaitInMs: 1},
+		Hedging:     &openfga.HedgingConfiguration{Read: &openfga.HedgingPolicy{DelayInMs: 20}},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	store, err := fgaClient.CreateStore(context.Background()).Body(ClientCreateStoreRequest{Name: "test"}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := fgaClient.SetStoreId(store.Id); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	server.Inject(fgatest.Fault{Path: "/read", Times: 1, Delay: 5 * time.Second})
+	started := time.Now()
+	if _, err := fgaClient.Read(context.Background()).Body(ClientReadRequest{}).Execute(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Expected the hedged request to answer before the slow one, took %v", elapsed)
+	}
+
+	reads := 0
+	for _, request := range server.Requests() {
+		if strings.HasSuffix(request.Path, "/read") {
+			reads++
+		}
+	}
+	if reads != 2 {
+		t.Fatalf("Expected the read to be sent twice, got %d requests", reads)
+	}
+}
+
+func TestOpenFgaClientTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	const storeId = "01GXSB9YR785C4FYS3C0RTG7B2"
+	fgaClient, err := NewSdkClient(&ClientConfiguration{
+		ApiUrl:               "https://api.fga.example",
+		StoreId:              storeId,
+		AuthorizationModelId: "01GAHCE4YVKPQEKZQHT2R89MQV",
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(http.MethodPost, fmt.Sprintf("https://api.fga.example/stores/%s/check", storeId),
+		httpmock.NewStringResponder(http.StatusOK, `{"allowed":true}`))
+
+	_, err = fgaClient.ClientBatchCheck(context.Background()).Body(ClientBatchCheckClientBody{
+		{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+		{User: "user:anne", Relation: "editor", Object: "document:roadmap"},
+	}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	spans := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = append(spans[span.Name()], span)
+	}
+	if len(spans["ClientBatchCheck"]) != 1 || len(spans["Check"]) != 2 || len(spans["POST Check"]) != 2 {
+		t.Fatalf("Expected a span for ClientBatchCheck, and a span for each check and its request, got %v", spans)
+	}
+
+	batchCheck := spans["ClientBatchCheck"][0]
+	attrs := make(map[string]string)
+	for _, attr := range batchCheck.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.AsString()
+	}
+	if attrs[telemetry.ATTR_FGA_CLIENT_REQUEST_STORE_ID] != storeId || attrs[telemetry.ATTR_FGA_CLIENT_REQUEST_BATCH_CHECK_SIZE] != "2" {
+		t.Fatalf("Expected the store and the number of checks on the span of ClientBatchCheck, got %v", attrs)
+	}
+
+	checks := make(map[string]bool)
+	for index, check := range spans["Check"] {
+		if check.Parent().SpanID() != batchCheck.SpanContext().SpanID() {
+			t.Errorf("Expected check %d to be a child of ClientBatchCheck", index)
+		}
+		checks[check.SpanContext().SpanID().String()] = true
+	}
+	for index, request := range spans["POST Check"] {
+		if !checks[request.Parent().SpanID().String()] {
+			t.Errorf("Expected request %d to be a child of a check", index)
+		}
+	}
+}
+
+func TestOpenFgaClientMetrics(t *testing.T) {
+	const storeId = "01GXSB9YR785C4FYS3C0RTG7B2"
+	telemetryConfiguration := telemetry.DefaultTelemetryConfiguration()
+	reader := sdkmetric.NewManualReader()
+	telemetryConfiguration.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	fgaClient, err := NewSdkClient(&ClientConfiguration{
+		ApiUrl:               "https://api.fga.example",
+		StoreId:              storeId,
+		AuthorizationModelId: "01GAHCE4YVKPQEKZQHT2R89MQV",
+		Telemetry:            telemetryConfiguration,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(http.MethodPost, fmt.Sprintf("https://api.fga.example/stores/%s/check", storeId),
+		httpmock.NewStringResponder(http.StatusOK, `{"allowed":true}`))
+	httpmock.RegisterResponder(http.MethodPost, fmt.Sprintf("https://api.fga.example/stores/%s/write", storeId),
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{}))
+
+	_, err = fgaClient.ClientBatchCheck(context.Background()).Body(ClientBatchCheckClientBody{
+		{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+		{User: "user:anne", Relation: "editor", Object: "document:roadmap"},
+		{User: "user:anne", Relation: "owner", Object: "document:roadmap"},
+	}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// the transaction chunks are sent by the internal writes, which must not be recorded again
+	_, err = fgaClient.Write(context.Background()).Body(ClientWriteRequest{
+		Writes: []ClientTupleKey{
+			{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+			{User: "user:beth", Relation: "viewer", Object: "document:roadmap"},
+		},
+		Deletes: []ClientTupleKeyWithoutCondition{
+			{User: "user:carl", Relation: "viewer", Object: "document:roadmap"},
+		},
+	}).Options(ClientWriteOptions{Transaction: &TransactionOptions{Disable: true, MaxPerChunk: 1}}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var resourceMetrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &resourceMetrics); err != nil {
+		t.Fatalf("%v", err)
+	}
+	histograms := make(map[string]metricdata.Histogram[float64])
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			if histogram, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				histograms[m.Name] = histogram
+			}
+		}
+	}
+
+	batchCheckSize := histograms[telemetry.METRIC_HISTOGRAM_BATCH_CHECK_SIZE]
+	if len(batchCheckSize.DataPoints) != 1 || batchCheckSize.DataPoints[0].Count != 1 || batchCheckSize.DataPoints[0].Sum != 3 {
+		t.Fatalf("Expected the fan-out of ClientBatchCheck to 3 checks, got %v", batchCheckSize.DataPoints)
+	}
+
+	tuples := make(map[string]float64)
+	for _, point := range histograms[telemetry.METRIC_HISTOGRAM_WRITE_TUPLES].DataPoints {
+		if point.Count != 1 {
+			t.Errorf("Expected a single write to be recorded, got %d", point.Count)
+		}
+		operation, _ := point.Attributes.Value(attribute.Key(telemetry.ATTR_FGA_CLIENT_WRITE_OPERATION))
+		tuples[operation.AsString()] = point.Sum
+	}
+	if len(tuples) != 2 || tuples["write"] != 2 || tuples["delete"] != 1 {
+		t.Fatalf("Expected 2 tuples written and 1 deleted, got %v", tuples)
+	}
+}
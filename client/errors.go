@@ -1,5 +1,7 @@
 package client
 
+import "errors"
+
 // FgaRequiredParamError Provides access to the body, error and model on returned errors.
 type FgaRequiredParamError struct {
 	error string
@@ -38,3 +40,11 @@ func (e FgaInvalidError) Error() string {
 func (e FgaInvalidError) Param() string {
 	return e.param
 }
+
+// ErrWriteChunkSkipped is the error reported for the tuples of a chunked transactional write whose chunk was not sent
+// because an earlier chunk failed
+var ErrWriteChunkSkipped = errors.New("the chunk was not written because an earlier chunk failed")
+
+// ErrWriteChunkReverted is the error reported for the tuples of a chunked transactional write whose chunk was applied,
+// then reverted because a later chunk failed
+var ErrWriteChunkReverted = errors.New("the chunk was reverted because a later chunk failed")
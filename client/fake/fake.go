@@ -0,0 +1,135 @@
+// Package fake provides an in-memory implementation of client.SdkClient for unit tests.
+//
+// The fake is a regular client.OpenFgaClient whose requests are served in-process by an in-memory OpenFGA server, so
+// request validation, serialization, error mapping, pagination and streaming behave as they do against a real server,
+// without the need for a network or a running OpenFGA instance:
+//
+//	fgaClient, err := fake.NewClient(&fake.ClientOptions{
+//		AuthorizationModel: &model,
+//		Tuples: []client.ClientTupleKey{
+//			{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+//		},
+//	})
+//	response, err := fgaClient.Check(ctx).Body(client.ClientCheckRequest{
+//		User: "user:anne", Relation: "viewer", Object: "document:roadmap",
+//	}).Execute()
+//
+// Relationship queries are answered by the evaluator package, by interpreting the rewrites of the stored model.
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/evaluator"
+	"github.com/openfga/go-sdk/internal/memstore"
+)
+
+// ApiUrl is the url the fake client is configured with. Requests to it never leave the process.
+const ApiUrl = "http://fake.openfga.local"
+
+const (
+	// DefaultStoreName is the name of the store created by NewClient when ClientOptions.StoreName is empty
+	DefaultStoreName = "fake"
+	// tuplesPerWrite is the number of tuples seeded per write, the maximum the server accepts
+	tuplesPerWrite = 100
+)
+
+// ClientOptions configures the state of a fake Client
+type ClientOptions struct {
+	// StoreName is the name of the store created for the client. Defaults to DefaultStoreName.
+	StoreName string
+	// AuthorizationModel is written to the store and its id set on the client, when set
+	AuthorizationModel *client.ClientWriteAuthorizationModelRequest
+	// Tuples are written to the store once the model is written. Requires AuthorizationModel.
+	Tuples []client.ClientTupleKey
+	// ConditionEvaluator evaluates the conditions of conditional tuples. When nil, queries reaching a conditional tuple
+	// fail with a validation error.
+	ConditionEvaluator evaluator.ConditionEvaluator
+	// Now returns the time used for store, model and change timestamps. Defaults to time.Now.
+	Now func() time.Time
+	// Configuration is used for the client, after its ApiUrl, HTTPClient, StoreId and AuthorizationModelId are set
+	Configuration *client.ClientConfiguration
+}
+
+// Client is a client.OpenFgaClient backed by an in-memory server. It implements client.SdkClient.
+type Client struct {
+	*client.OpenFgaClient
+}
+
+var _ client.SdkClient = (*Client)(nil)
+
+// NewClient creates a store, and writes the model and the tuples of the options to it, returning a Client configured
+// with the ids of the store and the model. options may be nil.
+func NewClient(options *ClientOptions) (*Client, error) {
+	if options == nil {
+		options = &ClientOptions{}
+	}
+	if len(options.Tuples) > 0 && options.AuthorizationModel == nil {
+		return nil, fmt.Errorf("fake: Tuples require an AuthorizationModel")
+	}
+
+	server := memstore.NewServer(&memstore.Options{ConditionEvaluator: options.ConditionEvaluator, Now: options.Now})
+
+	storeName := options.StoreName
+	if storeName == "" {
+		storeName = DefaultStoreName
+	}
+	store, err := server.CreateStore(fgaSdk.CreateStoreRequest{Name: storeName})
+	if err != nil {
+		return nil, fmt.Errorf("fake: creating store: %w", err)
+	}
+
+	var authorizationModelId string
+	if options.AuthorizationModel != nil {
+		model, err := server.WriteAuthorizationModel(store.Id, *options.AuthorizationModel)
+		if err != nil {
+			return nil, fmt.Errorf("fake: writing authorization model: %w", err)
+		}
+		authorizationModelId = model.AuthorizationModelId
+	}
+	for start := 0; start < len(options.Tuples); start += tuplesPerWrite {
+		end := min(start+tuplesPerWrite, len(options.Tuples))
+		err := server.Write(store.Id, fgaSdk.WriteRequest{
+			Writes:               &fgaSdk.WriteRequestWrites{TupleKeys: options.Tuples[start:end]},
+			AuthorizationModelId: &authorizationModelId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fake: writing tuples: %w", err)
+		}
+	}
+
+	configuration := client.ClientConfiguration{}
+	if options.Configuration != nil {
+		configuration = *options.Configuration
+	}
+	configuration.ApiScheme = ""
+	configuration.ApiHost = ""
+	configuration.ApiUrl = ApiUrl
+	configuration.HTTPClient = &http.Client{Transport: &transport{handler: memstore.NewHandler(server)}}
+	configuration.StoreId = store.Id
+	configuration.AuthorizationModelId = authorizationModelId
+
+	fgaClient, err := client.NewSdkClient(&configuration)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{OpenFgaClient: fgaClient}, nil
+}
+
+// transport serves requests in-process with the handler of the in-memory server
+type transport struct {
+	handler http.Handler
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, request)
+	response := recorder.Result()
+	response.Request = request
+	return response, nil
+}
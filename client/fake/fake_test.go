@@ -0,0 +1,344 @@
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/client/fake"
+	"github.com/openfga/go-sdk/evaluator"
+	internalutils "github.com/openfga/go-sdk/internal/utils"
+	"github.com/openfga/go-sdk/language"
+)
+
+const testModelDSL = `model
+  schema 1.1
+
+type user
+
+type group
+  relations
+    define member: [user, group#member]
+
+type document
+  relations
+    define owner: [user]
+    define editor: [user, user with allowed] or owner
+    define viewer: [user, user:*, group#member] or editor
+
+condition allowed(allowed: bool) {
+  allowed
+}
+`
+
+func newTestClient(t *testing.T, tuples []client.ClientTupleKey) *fake.Client {
+	t.Helper()
+
+	model, err := language.TransformDSLToModel(testModelDSL)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	fgaClient, err := fake.NewClient(&fake.ClientOptions{
+		AuthorizationModel: model,
+		Tuples:             tuples,
+		ConditionEvaluator: evaluator.ConditionEvaluatorFunc(func(_ context.Context, _ fgaSdk.Condition, parameters map[string]interface{}) (bool, error) {
+			allowed, _ := parameters["allowed"].(bool)
+			return allowed, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return fgaClient
+}
+
+func TestFakeClientStores(t *testing.T) {
+	fgaClient := newTestClient(t, nil)
+	ctx := context.Background()
+
+	storeId, err := fgaClient.GetStoreId()
+	if err != nil || !internalutils.IsWellFormedUlidString(storeId) {
+		t.Fatalf("Expected a ULID store id, got %q (%v)", storeId, err)
+	}
+
+	for _, name := range []string{"second", "third"} {
+		if _, err := fgaClient.CreateStore(ctx).Body(client.ClientCreateStoreRequest{Name: name}).Execute(); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	firstPage, err := fgaClient.ListStores(ctx).Options(client.ClientListStoresOptions{PageSize: fgaSdk.ToPtr(int32(2))}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(firstPage.Stores) != 2 || firstPage.Stores[0].Name != fake.DefaultStoreName || firstPage.ContinuationToken == "" {
+		t.Fatalf("Unexpected first page %+v", firstPage)
+	}
+	secondPage, err := fgaClient.ListStores(ctx).Options(client.ClientListStoresOptions{
+		PageSize:          fgaSdk.ToPtr(int32(2)),
+		ContinuationToken: &firstPage.ContinuationToken,
+	}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(secondPage.Stores) != 1 || secondPage.Stores[0].Name != "third" || secondPage.ContinuationToken != "" {
+		t.Fatalf("Unexpected second page %+v", secondPage)
+	}
+
+	store, err := fgaClient.GetStore(ctx).Execute()
+	if err != nil || store.Name != fake.DefaultStoreName {
+		t.Fatalf("GetStore() = %+v, %v", store, err)
+	}
+	if _, err := fgaClient.DeleteStore(ctx).Execute(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = fgaClient.GetStore(ctx).Execute()
+	var notFound fgaSdk.FgaApiNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected FgaApiNotFoundError, got %v", err)
+	}
+}
+
+func TestFakeClientAuthorizationModels(t *testing.T) {
+	fgaClient := newTestClient(t, nil)
+	ctx := context.Background()
+
+	modelId, err := fgaClient.GetAuthorizationModelId()
+	if err != nil || !internalutils.IsWellFormedUlidString(modelId) {
+		t.Fatalf("Expected a ULID model id, got %q (%v)", modelId, err)
+	}
+
+	model, _ := language.TransformDSLToModel(testModelDSL)
+	written, err := fgaClient.WriteAuthorizationModel(ctx).Body(*model).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	latest, err := fgaClient.ReadLatestAuthorizationModel(ctx).Execute()
+	if err != nil || latest.AuthorizationModel.Id != written.AuthorizationModelId {
+		t.Fatalf("Expected the latest model to be %s, got %+v (%v)", written.AuthorizationModelId, latest, err)
+	}
+	models, err := fgaClient.ReadAuthorizationModels(ctx).Execute()
+	if err != nil || len(models.AuthorizationModels) != 2 || models.AuthorizationModels[1].Id != modelId {
+		t.Fatalf("Unexpected models %+v (%v)", models, err)
+	}
+	read, err := fgaClient.ReadAuthorizationModel(ctx).Options(client.ClientReadAuthorizationModelOptions{
+		AuthorizationModelId: &modelId,
+	}).Execute()
+	if err != nil || read.AuthorizationModel.Id != modelId {
+		t.Fatalf("ReadAuthorizationModel() = %+v, %v", read, err)
+	}
+
+	invalid := fgaSdk.WriteAuthorizationModelRequest{SchemaVersion: "1.1", TypeDefinitions: []fgaSdk.TypeDefinition{{
+		Type:      "document",
+		Relations: &map[string]fgaSdk.Userset{"viewer": {ComputedUserset: &fgaSdk.ObjectRelation{Relation: fgaSdk.ToPtr("editor")}}},
+	}}}
+	_, err = fgaClient.WriteAuthorizationModel(ctx).Body(invalid).Execute()
+	var validationErr fgaSdk.FgaApiValidationError
+	if !errors.As(err, &validationErr) || validationErr.ResponseCode() != fgaSdk.ERRORCODE_INVALID_AUTHORIZATION_MODEL {
+		t.Fatalf("Expected invalid_authorization_model, got %v", err)
+	}
+}
+
+func TestFakeClientWriteConflicts(t *testing.T) {
+	anne := client.ClientTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}
+	fgaClient := newTestClient(t, []client.ClientTupleKey{anne})
+	ctx := context.Background()
+	beth := client.ClientTupleKey{User: "user:beth", Relation: "viewer", Object: "document:roadmap"}
+
+	_, err := fgaClient.WriteTuples(ctx).Body([]client.ClientTupleKey{anne, beth}).Execute()
+	var validationErr fgaSdk.FgaApiValidationError
+	if !errors.As(err, &validationErr) || validationErr.ResponseCode() != fgaSdk.ERRORCODE_WRITE_FAILED_DUE_TO_INVALID_INPUT {
+		t.Fatalf("Expected a duplicate write to fail, got %v", err)
+	}
+	if tuples := readTuples(t, fgaClient); len(tuples) != 1 {
+		t.Fatalf("Expected a failed write not to be applied, got %v", tuples)
+	}
+
+	_, err = fgaClient.Write(ctx).Body(client.ClientWriteRequest{
+		Writes:  []client.ClientTupleKey{anne, beth},
+		Deletes: []client.ClientTupleKeyWithoutCondition{{User: "user:carl", Relation: "viewer", Object: "document:roadmap"}},
+	}).Options(client.ClientWriteOptions{Conflict: client.ClientWriteConflictOptions{
+		OnDuplicateWrites: client.CLIENT_WRITE_REQUEST_ON_DUPLICATE_WRITES_IGNORE,
+		OnMissingDeletes:  client.CLIENT_WRITE_REQUEST_ON_MISSING_DELETES_IGNORE,
+	}}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if tuples := readTuples(t, fgaClient); !reflect.DeepEqual(tuples, []string{"user:anne", "user:beth"}) {
+		t.Fatalf("Unexpected tuples %v", tuples)
+	}
+
+	_, err = fgaClient.DeleteTuples(ctx).Body([]client.ClientTupleKeyWithoutCondition{{User: "user:carl", Relation: "viewer", Object: "document:roadmap"}}).Execute()
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected deleting a missing tuple to fail, got %v", err)
+	}
+
+	_, err = fgaClient.WriteTuples(ctx).Body([]client.ClientTupleKey{{User: "user:anne", Relation: "owner", Object: "group:eng"}}).Execute()
+	if !errors.As(err, &validationErr) || validationErr.ResponseCode() != fgaSdk.ERRORCODE_VALIDATION_ERROR {
+		t.Fatalf("Expected a tuple not allowed by the model to fail, got %v", err)
+	}
+}
+
+func readTuples(t *testing.T, fgaClient *fake.Client) []string {
+	t.Helper()
+
+	response, err := fgaClient.Read(context.Background()).Body(client.ClientReadRequest{Object: fgaSdk.ToPtr("document:")}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	users := []string{}
+	for _, tuple := range response.Tuples {
+		users = append(users, tuple.Key.User)
+	}
+	sort.Strings(users)
+	return users
+}
+
+func TestFakeClientReadChanges(t *testing.T) {
+	fgaClient := newTestClient(t, []client.ClientTupleKey{
+		{User: "user:anne", Relation: "member", Object: "group:eng"},
+		{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+	})
+	ctx := context.Background()
+
+	changes, err := fgaClient.ReadChanges(ctx).Body(client.ClientReadChangesRequest{Type: "document"}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(changes.Changes) != 1 || changes.Changes[0].Operation != fgaSdk.TUPLEOPERATION_WRITE {
+		t.Fatalf("Unexpected changes %+v", changes.Changes)
+	}
+
+	_, err = fgaClient.DeleteTuples(ctx).Body([]client.ClientTupleKeyWithoutCondition{{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	changes, err = fgaClient.ReadChanges(ctx).Body(client.ClientReadChangesRequest{Type: "document"}).Options(client.ClientReadChangesOptions{
+		ContinuationToken: changes.ContinuationToken,
+	}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(changes.Changes) != 1 || changes.Changes[0].Operation != fgaSdk.TUPLEOPERATION_DELETE {
+		t.Fatalf("Expected only the new delete, got %+v", changes.Changes)
+	}
+
+	_, err = fgaClient.ReadChanges(ctx).Body(client.ClientReadChangesRequest{Type: "group"}).Options(client.ClientReadChangesOptions{
+		ContinuationToken: changes.ContinuationToken,
+	}).Execute()
+	var validationErr fgaSdk.FgaApiValidationError
+	if !errors.As(err, &validationErr) || validationErr.ResponseCode() != fgaSdk.ERRORCODE_QUERY_STRING_TYPE_CONTINUATION_TOKEN_MISMATCH {
+		t.Fatalf("Expected a type mismatch, got %v", err)
+	}
+}
+
+func TestFakeClientQueries(t *testing.T) {
+	fgaClient := newTestClient(t, []client.ClientTupleKey{
+		{User: "user:anne", Relation: "member", Object: "group:eng"},
+		{User: "group:eng#member", Relation: "viewer", Object: "document:roadmap"},
+		{User: "user:beth", Relation: "owner", Object: "document:plan"},
+		{User: "user:carl", Relation: "editor", Object: "document:plan", Condition: &fgaSdk.RelationshipCondition{Name: "allowed"}},
+	})
+	ctx := context.Background()
+
+	check, err := fgaClient.Check(ctx).Body(client.ClientCheckRequest{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}).Execute()
+	if err != nil || !check.GetAllowed() {
+		t.Fatalf("Expected anne to view the roadmap, got %+v (%v)", check, err)
+	}
+	check, err = fgaClient.Check(ctx).Body(client.ClientCheckRequest{
+		User: "user:carl", Relation: "viewer", Object: "document:plan",
+		Context: &map[string]interface{}{"allowed": true},
+	}).Execute()
+	if err != nil || !check.GetAllowed() {
+		t.Fatalf("Expected carl to view the plan with the condition met, got %+v (%v)", check, err)
+	}
+
+	batch, err := fgaClient.BatchCheck(ctx).Body(client.ClientBatchCheckRequest{Checks: []client.ClientBatchCheckItem{
+		{User: "user:beth", Relation: "viewer", Object: "document:plan", CorrelationId: "1"},
+		{User: "user:beth", Relation: "viewer", Object: "document:roadmap", CorrelationId: "2"},
+		{User: "user:beth", Relation: "member", Object: "document:plan", CorrelationId: "3"},
+	}}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	results := batch.GetResult()
+	if !*results["1"].Allowed || *results["2"].Allowed || results["3"].Error == nil {
+		t.Fatalf("Unexpected batch check results %+v", results)
+	}
+
+	objects, err := fgaClient.ListObjects(ctx).Body(client.ClientListObjectsRequest{User: "user:beth", Relation: "viewer", Type: "document"}).Execute()
+	if err != nil || !reflect.DeepEqual(objects.Objects, []string{"document:plan"}) {
+		t.Fatalf("ListObjects() = %+v, %v", objects, err)
+	}
+
+	streamed, err := fgaClient.StreamedListObjects(ctx).Body(client.ClientStreamedListObjectsRequest{User: "user:anne", Relation: "viewer", Type: "document"}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer streamed.Close()
+	var streamedObjects []string
+	for object := range streamed.Objects {
+		streamedObjects = append(streamedObjects, object.Object)
+	}
+	if err := <-streamed.Errors; err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !reflect.DeepEqual(streamedObjects, []string{"document:roadmap"}) {
+		t.Fatalf("StreamedListObjects() = %v", streamedObjects)
+	}
+
+	users, err := fgaClient.ListUsers(ctx).Body(client.ClientListUsersRequest{
+		Object:      fgaSdk.FgaObject{Type: "document", Id: "roadmap"},
+		Relation:    "viewer",
+		UserFilters: []fgaSdk.UserTypeFilter{{Type: "user"}},
+	}).Execute()
+	if err != nil || len(users.Users) != 1 || users.Users[0].Object.Id != "anne" {
+		t.Fatalf("ListUsers() = %+v, %v", users, err)
+	}
+
+	expand, err := fgaClient.Expand(ctx).Body(client.ClientExpandRequest{Relation: "owner", Object: "document:plan"}).Execute()
+	if err != nil || !reflect.DeepEqual(expand.Tree.Root.Leaf.Users.Users, []string{"user:beth"}) {
+		t.Fatalf("Expand() = %+v, %v", expand, err)
+	}
+}
+
+func TestFakeClientAssertions(t *testing.T) {
+	fgaClient := newTestClient(t, nil)
+	ctx := context.Background()
+
+	assertions, err := fgaClient.ReadAssertions(ctx).Execute()
+	if err != nil || len(assertions.GetAssertions()) != 0 {
+		t.Fatalf("Expected no assertions, got %+v (%v)", assertions, err)
+	}
+
+	_, err = fgaClient.WriteAssertions(ctx).Body(client.ClientWriteAssertionsRequest{
+		{User: "user:anne", Relation: "viewer", Object: "document:roadmap", Expectation: true},
+	}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	assertions, err = fgaClient.ReadAssertions(ctx).Execute()
+	if err != nil || len(assertions.GetAssertions()) != 1 || !assertions.GetAssertions()[0].Expectation {
+		t.Fatalf("Unexpected assertions %+v (%v)", assertions, err)
+	}
+}
+
+func TestNewClientRequiresModelForTuples(t *testing.T) {
+	_, err := fake.NewClient(&fake.ClientOptions{Tuples: []client.ClientTupleKey{{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}})
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+
+	fgaClient, err := fake.NewClient(nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if modelId, _ := fgaClient.GetAuthorizationModelId(); modelId != "" {
+		t.Fatalf("Expected no model id, got %s", modelId)
+	}
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	_context "context"
+	"iter"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// ClientPaginationCursor records how far an auto-paginating iterator got, so that a caller can resume after a crash by passing
+// ContinuationToken back as the ContinuationToken option.
+//
+// The cursor only moves once every item of a page was yielded: resuming from it may return the items of a partially
+// consumed page again, but never skips an item.
+type ClientPaginationCursor struct {
+	// ContinuationToken is the token of the first page that was not fully consumed
+	ContinuationToken string `json:"continuation_token"`
+	// Done is set once the last page was fully consumed
+	Done bool `json:"done"`
+}
+
+type ClientIteratorOptions struct {
+	// MaxItems stops the iteration after this many items (default = no limit)
+	MaxItems int `json:"max_items,omitempty"`
+	// Cursor, if set, is updated as pages are consumed
+	Cursor *ClientPaginationCursor `json:"-"`
+}
+
+type ClientReadAllOptions struct {
+	ClientReadOptions
+	ClientIteratorOptions
+}
+
+type ClientReadChangesAllOptions struct {
+	ClientReadChangesOptions
+	ClientIteratorOptions
+}
+
+type ClientListStoresAllOptions struct {
+	ClientListStoresOptions
+	ClientIteratorOptions
+}
+
+type ClientReadAuthorizationModelsAllOptions struct {
+	ClientReadAuthorizationModelsOptions
+	ClientIteratorOptions
+}
+
+/*
+ * ReadAll Reads all the relationship tuples matching the body, following continuation tokens.
+ * @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc.
+ * @return iter.Seq2[fgaSdk.Tuple, error] - an iteration stops after the first error it yields
+ */
+func (client *OpenFgaClient) ReadAll(ctx _context.Context, body ClientReadRequest, options ClientReadAllOptions) iter.Seq2[fgaSdk.Tuple, error] {
+	return paginate(ctx, options.ContinuationToken, options.ClientIteratorOptions, func(continuationToken *string) ([]fgaSdk.Tuple, string, error) {
+		pageOptions := options.ClientReadOptions
+		pageOptions.ContinuationToken = continuationToken
+		response, err := client.Read(ctx).Body(body).Options(pageOptions).Execute()
+		if err != nil {
+			return nil, "", err
+		}
+		return response.GetTuples(), response.GetContinuationToken(), nil
+	})
+}
+
+/*
+ * ReadChangesAll Reads all the historical relationship tuple writes and deletes up to now, following continuation tokens.
+ * Once the iteration completes, the cursor holds the token to pass to ReadChanges to only get newer changes.
+ * @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc.
+ * @return iter.Seq2[fgaSdk.TupleChange, error] - an iteration stops after the first error it yields
+ */
+func (client *OpenFgaClient) ReadChangesAll(ctx _context.Context, body ClientReadChangesRequest, options ClientReadChangesAllOptions) iter.Seq2[fgaSdk.TupleChange, error] {
+	return paginate(ctx, options.ContinuationToken, options.ClientIteratorOptions, func(continuationToken *string) ([]fgaSdk.TupleChange, string, error) {
+		pageOptions := options.ClientReadChangesOptions
+		pageOptions.ContinuationToken = continuationToken
+		response, err := client.ReadChanges(ctx).Body(body).Options(pageOptions).Execute()
+		if err != nil {
+			return nil, "", err
+		}
+		return response.GetChanges(), response.GetContinuationToken(), nil
+	})
+}
+
+/*
+ * ListStoresAll Lists all the stores, following continuation tokens.
+ * @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc.
+ * @return iter.Seq2[fgaSdk.Store, error] - an iteration stops after the first error it yields
+ */
+func (client *OpenFgaClient) ListStoresAll(ctx _context.Context, options ClientListStoresAllOptions) iter.Seq2[fgaSdk.Store, error] {
+	return paginate(ctx, options.ContinuationToken, options.ClientIteratorOptions, func(continuationToken *string) ([]fgaSdk.Store, string, error) {
+		pageOptions := options.ClientListStoresOptions
+		pageOptions.ContinuationToken = continuationToken
+		response, err := client.ListStores(ctx).Options(pageOptions).Execute()
+		if err != nil {
+			return nil, "", err
+		}
+		return response.GetStores(), response.GetContinuationToken(), nil
+	})
+}
+
+/*
+ * ReadAuthorizationModelsAll Reads all the authorization models of a store, following continuation tokens.
+ * @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc.
+ * @return iter.Seq2[fgaSdk.AuthorizationModel, error] - an iteration stops after the first error it yields
+ */
+func (client *OpenFgaClient) ReadAuthorizationModelsAll(ctx _context.Context, options ClientReadAuthorizationModelsAllOptions) iter.Seq2[fgaSdk.AuthorizationModel, error] {
+	return paginate(ctx, options.ContinuationToken, options.ClientIteratorOptions, func(continuationToken *string) ([]fgaSdk.AuthorizationModel, string, error) {
+		pageOptions := options.ClientReadAuthorizationModelsOptions
+		pageOptions.ContinuationToken = continuationToken
+		response, err := client.ReadAuthorizationModels(ctx).Options(pageOptions).Execute()
+		if err != nil {
+			return nil, "", err
+		}
+		return response.GetAuthorizationModels(), response.GetContinuationToken(), nil
+	})
+}
+
+// paginate yields the items of every page returned by fetch, starting at startToken, until a page comes back without a new
+// continuation token (ReadChanges returns the token it was given once there are no newer changes)
+func paginate[T any](ctx _context.Context, startToken *string, options ClientIteratorOptions, fetch func(continuationToken *string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		var continuationToken *string
+		if startToken != nil && *startToken != "" {
+			token := *startToken
+			continuationToken = &token
+		}
+		// an iteration stopping within its first page resumes from where it started
+		if options.Cursor != nil {
+			options.Cursor.ContinuationToken = ""
+			if continuationToken != nil {
+				options.Cursor.ContinuationToken = *continuationToken
+			}
+			options.Cursor.Done = false
+		}
+
+		count := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, nextToken, err := fetch(continuationToken)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for index, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				count++
+				if options.MaxItems > 0 && count >= options.MaxItems {
+					if index == len(items)-1 {
+						updateCursor(options.Cursor, nextToken, continuationToken)
+					}
+					return
+				}
+			}
+
+			if updateCursor(options.Cursor, nextToken, continuationToken) {
+				return
+			}
+			continuationToken = &nextToken
+		}
+	}
+}
+
+// updateCursor records that the page fetched with currentToken was fully consumed and reports whether it was the last one
+func updateCursor(cursor *ClientPaginationCursor, nextToken string, currentToken *string) bool {
+	done := nextToken == "" || (currentToken != nil && *currentToken == nextToken)
+	if cursor != nil {
+		cursor.ContinuationToken = nextToken
+		cursor.Done = done
+	}
+	return done
+}
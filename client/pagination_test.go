@@ -0,0 +1,258 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+
+	openfga "github.com/openfga/go-sdk"
+	. "github.com/openfga/go-sdk/client"
+)
+
+func TestPaginationIterators(t *testing.T) {
+	const storeId = "01GXSB9YR785C4FYS3C0RTG7B2"
+
+	fgaClient, err := NewSdkClient(&ClientConfiguration{
+		ApiUrl:  "https://api.fga.example",
+		StoreId: storeId,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tuple := func(object string) openfga.Tuple {
+		return openfga.Tuple{Key: openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: object}}
+	}
+
+	readPages := map[string]openfga.ReadResponse{
+		"":   {Tuples: []openfga.Tuple{tuple("document:1"), tuple("document:2")}, ContinuationToken: "t1"},
+		"t1": {Tuples: []openfga.Tuple{tuple("document:3"), tuple("document:4")}, ContinuationToken: "t2"},
+		"t2": {Tuples: []openfga.Tuple{tuple("document:5")}, ContinuationToken: ""},
+	}
+
+	registerRead := func(sentTokens *[]string) {
+		httpmock.RegisterResponder(http.MethodPost, fmt.Sprintf("%s/stores/%s/read", fgaClient.GetConfig().ApiUrl, storeId),
+			func(req *http.Request) (*http.Response, error) {
+				var body openfga.ReadRequest
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+				}
+				token := body.GetContinuationToken()
+				*sentTokens = append(*sentTokens, token)
+				return httpmock.NewJsonResponse(http.StatusOK, readPages[token])
+			},
+		)
+	}
+
+	t.Run("ReadAll follows continuation tokens", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		var sentTokens []string
+		registerRead(&sentTokens)
+
+		cursor := ClientPaginationCursor{}
+		var objects []string
+		for tuple, err := range fgaClient.ReadAll(context.Background(), ClientReadRequest{}, ClientReadAllOptions{
+			ClientIteratorOptions: ClientIteratorOptions{Cursor: &cursor},
+		}) {
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			objects = append(objects, tuple.Key.Object)
+		}
+
+		if len(objects) != 5 {
+			t.Fatalf("Expected 5 tuples, got %v", objects)
+		}
+		if len(sentTokens) != 3 {
+			t.Fatalf("Expected 3 requests, got %v", sentTokens)
+		}
+		if !cursor.Done {
+			t.Fatalf("Expected the cursor to be done")
+		}
+	})
+
+	t.Run("ReadAll stops at MaxItems and can be resumed", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		var sentTokens []string
+		registerRead(&sentTokens)
+
+		cursor := ClientPaginationCursor{}
+		count := 0
+		for _, err := range fgaClient.ReadAll(context.Background(), ClientReadRequest{}, ClientReadAllOptions{
+			ClientIteratorOptions: ClientIteratorOptions{MaxItems: 3, Cursor: &cursor},
+		}) {
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			count++
+		}
+
+		if count != 3 {
+			t.Fatalf("Expected 3 tuples, got %d", count)
+		}
+		// the second page was only partially consumed
+		if cursor.ContinuationToken != "t1" || cursor.Done {
+			t.Fatalf("Expected the cursor to point at t1, got %+v", cursor)
+		}
+
+		var objects []string
+		for tuple, err := range fgaClient.ReadAll(context.Background(), ClientReadRequest{}, ClientReadAllOptions{
+			ClientReadOptions: ClientReadOptions{ContinuationToken: openfga.ToPtr(cursor.ContinuationToken)},
+		}) {
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			objects = append(objects, tuple.Key.Object)
+		}
+		if len(objects) != 3 || objects[0] != "document:3" {
+			t.Fatalf("Expected to resume at document:3, got %v", objects)
+		}
+
+		// a resumed iteration stopping within its first page keeps its starting point
+		resumed := ClientPaginationCursor{}
+		for _, err := range fgaClient.ReadAll(context.Background(), ClientReadRequest{}, ClientReadAllOptions{
+			ClientReadOptions:     ClientReadOptions{ContinuationToken: openfga.ToPtr("t1")},
+			ClientIteratorOptions: ClientIteratorOptions{MaxItems: 1, Cursor: &resumed},
+		}) {
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+		}
+		if resumed.ContinuationToken != "t1" || resumed.Done {
+			t.Fatalf("Expected the cursor to still point at t1, got %+v", resumed)
+		}
+	})
+
+	t.Run("ReadAll stops when the consumer breaks", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		var sentTokens []string
+		registerRead(&sentTokens)
+
+		for range fgaClient.ReadAll(context.Background(), ClientReadRequest{}, ClientReadAllOptions{}) {
+			break
+		}
+
+		if len(sentTokens) != 1 {
+			t.Fatalf("Expected 1 request, got %v", sentTokens)
+		}
+	})
+
+	t.Run("ReadAll yields context errors", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		var sentTokens []string
+		registerRead(&sentTokens)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var lastErr error
+		for _, err := range fgaClient.ReadAll(ctx, ClientReadRequest{}, ClientReadAllOptions{}) {
+			if err != nil {
+				lastErr = err
+				break
+			}
+			cancel()
+		}
+
+		if lastErr != context.Canceled {
+			t.Fatalf("Expected context.Canceled, got %v", lastErr)
+		}
+		if len(sentTokens) != 1 {
+			t.Fatalf("Expected 1 request, got %v", sentTokens)
+		}
+	})
+
+	t.Run("ReadChangesAll stops once the token stops advancing", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		change := openfga.TupleChange{
+			TupleKey:  openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"},
+			Operation: openfga.TUPLEOPERATION_WRITE,
+		}
+		pages := map[string]openfga.ReadChangesResponse{
+			"":   {Changes: []openfga.TupleChange{change, change}, ContinuationToken: openfga.PtrString("t1")},
+			"t1": {Changes: []openfga.TupleChange{}, ContinuationToken: openfga.PtrString("t1")},
+		}
+		httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("%s/stores/%s/changes", fgaClient.GetConfig().ApiUrl, storeId),
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewJsonResponse(http.StatusOK, pages[req.URL.Query().Get("continuation_token")])
+			},
+		)
+
+		cursor := ClientPaginationCursor{}
+		count := 0
+		for _, err := range fgaClient.ReadChangesAll(context.Background(), ClientReadChangesRequest{}, ClientReadChangesAllOptions{
+			ClientIteratorOptions: ClientIteratorOptions{Cursor: &cursor},
+		}) {
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			count++
+		}
+
+		if count != 2 {
+			t.Fatalf("Expected 2 changes, got %d", count)
+		}
+		if cursor.ContinuationToken != "t1" || !cursor.Done {
+			t.Fatalf("Expected the cursor to be done at t1, got %+v", cursor)
+		}
+	})
+
+	t.Run("ListStoresAll and ReadAuthorizationModelsAll follow continuation tokens", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+
+		storePages := map[string]openfga.ListStoresResponse{
+			"":   {Stores: []openfga.Store{{Id: "1"}}, ContinuationToken: "t1"},
+			"t1": {Stores: []openfga.Store{{Id: "2"}}, ContinuationToken: ""},
+		}
+		httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("%s/stores", fgaClient.GetConfig().ApiUrl),
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewJsonResponse(http.StatusOK, storePages[req.URL.Query().Get("continuation_token")])
+			},
+		)
+		modelPages := map[string]openfga.ReadAuthorizationModelsResponse{
+			"":   {AuthorizationModels: []openfga.AuthorizationModel{{Id: "1"}}, ContinuationToken: openfga.PtrString("t1")},
+			"t1": {AuthorizationModels: []openfga.AuthorizationModel{{Id: "2"}}},
+		}
+		httpmock.RegisterResponder(http.MethodGet, fmt.Sprintf("%s/stores/%s/authorization-models", fgaClient.GetConfig().ApiUrl, storeId),
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewJsonResponse(http.StatusOK, modelPages[req.URL.Query().Get("continuation_token")])
+			},
+		)
+
+		var storeIds []string
+		for store, err := range fgaClient.ListStoresAll(context.Background(), ClientListStoresAllOptions{}) {
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			storeIds = append(storeIds, store.Id)
+		}
+		if len(storeIds) != 2 {
+			t.Fatalf("Expected 2 stores, got %v", storeIds)
+		}
+
+		var modelIds []string
+		for model, err := range fgaClient.ReadAuthorizationModelsAll(context.Background(), ClientReadAuthorizationModelsAllOptions{}) {
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			modelIds = append(modelIds, model.Id)
+		}
+		if len(modelIds) != 2 {
+			t.Fatalf("Expected 2 models, got %v", modelIds)
+		}
+	})
+}
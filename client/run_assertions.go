@@ -0,0 +1,229 @@
+package client
+
+import (
+	_context "context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+type ClientRunAssertionsOptions struct {
+	RequestOptions
+
+	AuthorizationModelId *string `json:"authorization_model_id,omitempty"`
+	StoreId              *string `json:"store_id,omitempty"`
+	// Assertions are evaluated instead of the assertions stored on the authorization model, when set
+	Assertions          *[]ClientAssertion            `json:"assertions,omitempty"`
+	MaxParallelRequests *int32                        `json:"max_parallel_requests,omitempty"`
+	MaxBatchSize        *int32                        `json:"max_batch_size,omitempty"`
+	Consistency         *fgaSdk.ConsistencyPreference `json:"consistency,omitempty"`
+	// JUnitXML, if set, receives the results as a JUnit XML report
+	JUnitXML io.Writer `json:"-"`
+}
+
+// ClientAssertionResult is the outcome of an assertion. Allowed is nil when the check failed with Error.
+type ClientAssertionResult struct {
+	Assertion ClientAssertion `json:"assertion"`
+	Allowed   *bool           `json:"allowed,omitempty"`
+	Error     error           `json:"-"`
+}
+
+// Passed reports whether the check returned the expectation of the assertion
+func (r ClientAssertionResult) Passed() bool {
+	return r.Error == nil && r.Allowed != nil && *r.Allowed == r.Assertion.Expectation
+}
+
+func (r ClientAssertionResult) String() string {
+	query := fmt.Sprintf("%s %s %s", r.Assertion.User, r.Assertion.Relation, r.Assertion.Object)
+	switch {
+	case r.Error != nil:
+		return fmt.Sprintf("%s: %s", query, r.Error)
+	case r.Allowed == nil:
+		return fmt.Sprintf("%s: expected %t, got no result", query, r.Assertion.Expectation)
+	default:
+		return fmt.Sprintf("%s: expected %t, got %t", query, r.Assertion.Expectation, *r.Allowed)
+	}
+}
+
+type ClientRunAssertionsResponse struct {
+	AuthorizationModelId string                  `json:"authorization_model_id,omitempty"`
+	Results              []ClientAssertionResult `json:"results"`
+}
+
+// Passed reports whether every assertion passed
+func (r *ClientRunAssertionsResponse) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the results of the assertions which did not pass
+func (r *ClientRunAssertionsResponse) Failed() []ClientAssertionResult {
+	var failed []ClientAssertionResult
+	for _, result := range r.Results {
+		if !result.Passed() {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+/*
+ * RunAssertions evaluates the assertions of an authorization model against the store with server-side BatchCheck
+ * requests, and reports expected vs. actual results per assertion. The assertions are read with ReadAssertions unless
+ * they are set in the options.
+ * @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc.
+ * @param options ClientRunAssertionsOptions - the model, the store and optionally the assertions to evaluate
+ * @return *ClientRunAssertionsResponse
+ */
+func (client *OpenFgaClient) RunAssertions(ctx _context.Context, options ClientRunAssertionsOptions) (*ClientRunAssertionsResponse, error) {
+	authorizationModelId, err := client.getAuthorizationModelId(options.AuthorizationModelId)
+	if err != nil {
+		return nil, err
+	}
+
+	var assertions []ClientAssertion
+	if options.Assertions != nil {
+		assertions = *options.Assertions
+	} else {
+		stored, err := client.ReadAssertions(ctx).Options(ClientReadAssertionsOptions{
+			RequestOptions:       options.RequestOptions,
+			AuthorizationModelId: authorizationModelId,
+			StoreId:              options.StoreId,
+		}).Execute()
+		if err != nil {
+			return nil, err
+		}
+		for _, assertion := range stored.GetAssertions() {
+			assertions = append(assertions, toClientAssertion(assertion))
+		}
+	}
+
+	response := &ClientRunAssertionsResponse{AuthorizationModelId: *authorizationModelId, Results: make([]ClientAssertionResult, len(assertions))}
+	checks := make([]ClientBatchCheckItem, len(assertions))
+	for index, assertion := range assertions {
+		response.Results[index].Assertion = assertion
+		checks[index] = ClientBatchCheckItem{
+			User:             assertion.User,
+			Relation:         assertion.Relation,
+			Object:           assertion.Object,
+			CorrelationId:    strconv.Itoa(index),
+			ContextualTuples: assertion.ContextualTuples,
+			Context:          assertion.Context,
+		}
+	}
+
+	if len(checks) > 0 {
+		batchCheck, err := client.BatchCheck(ctx).Body(ClientBatchCheckRequest{Checks: checks}).Options(BatchCheckOptions{
+			RequestOptions:       options.RequestOptions,
+			AuthorizationModelId: authorizationModelId,
+			StoreId:              options.StoreId,
+			MaxParallelRequests:  options.MaxParallelRequests,
+			MaxBatchSize:         options.MaxBatchSize,
+			Consistency:          options.Consistency,
+		}).Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		results := batchCheck.GetResult()
+		for index := range response.Results {
+			result, ok := results[strconv.Itoa(index)]
+			switch {
+			case !ok:
+				response.Results[index].Error = errors.New("no result returned for the assertion")
+			case result.Error != nil:
+				response.Results[index].Error = errors.New(result.Error.GetMessage())
+			default:
+				response.Results[index].Allowed = result.Allowed
+			}
+		}
+	}
+
+	if options.JUnitXML != nil {
+		if err := response.WriteJUnitXML(options.JUnitXML); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+func toClientAssertion(assertion fgaSdk.Assertion) ClientAssertion {
+	clientAssertion := ClientAssertion{
+		User:        assertion.TupleKey.User,
+		Relation:    assertion.TupleKey.Relation,
+		Object:      assertion.TupleKey.Object,
+		Expectation: assertion.Expectation,
+		Context:     assertion.Context,
+	}
+	if assertion.ContextualTuples != nil {
+		clientAssertion.ContextualTuples = *assertion.ContextualTuples
+	}
+	return clientAssertion
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnitXML writes the results as a JUnit XML report with a test case per assertion. Assertions whose check
+// returned the wrong result are reported as failures, and those whose check failed as errors.
+func (r *ClientRunAssertionsResponse) WriteJUnitXML(w io.Writer) error {
+	suite := junitTestSuite{Name: "assertions", Tests: len(r.Results)}
+	if r.AuthorizationModelId != "" {
+		suite.Name += " " + r.AuthorizationModelId
+	}
+	for _, result := range r.Results {
+		testCase := junitTestCase{
+			ClassName: suite.Name,
+			Name: fmt.Sprintf("%s %s %s is %t", result.Assertion.User, result.Assertion.Relation, result.Assertion.Object,
+				result.Assertion.Expectation),
+		}
+		switch {
+		case result.Error != nil:
+			suite.Errors++
+			testCase.Error = &junitMessage{Message: result.Error.Error()}
+		case !result.Passed():
+			suite.Failures++
+			testCase.Failure = &junitMessage{Message: result.String()}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	report := junitTestSuites{Tests: suite.Tests, Failures: suite.Failures, Errors: suite.Errors, Suites: []junitTestSuite{suite}}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,116 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/client/fake"
+	"github.com/openfga/go-sdk/language"
+)
+
+func newAssertionsTestClient(t *testing.T) *fake.Client {
+	t.Helper()
+
+	model, err := language.TransformDSLToModel(`model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	fgaClient, err := fake.NewClient(&fake.ClientOptions{
+		AuthorizationModel: model,
+		Tuples:             []ClientTupleKey{{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return fgaClient
+}
+
+func TestRunAssertions(t *testing.T) {
+	fgaClient := newAssertionsTestClient(t)
+	ctx := context.Background()
+
+	_, err := fgaClient.WriteAssertions(ctx).Body(ClientWriteAssertionsRequest{
+		{User: "user:anne", Relation: "viewer", Object: "document:roadmap", Expectation: true},
+		{User: "user:beth", Relation: "viewer", Object: "document:roadmap", Expectation: true},
+		{User: "user:beth", Relation: "viewer", Object: "document:roadmap", Expectation: true, ContextualTuples: []ClientContextualTupleKey{
+			{User: "user:beth", Relation: "viewer", Object: "document:roadmap"},
+		}},
+		{User: "user:anne", Relation: "owner", Object: "document:roadmap", Expectation: false},
+	}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var report bytes.Buffer
+	response, err := fgaClient.RunAssertions(ctx, ClientRunAssertionsOptions{JUnitXML: &report})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(response.Results) != 4 || response.Passed() {
+		t.Fatalf("Unexpected results %+v", response.Results)
+	}
+
+	results := response.Results
+	if !results[0].Passed() || !results[2].Passed() {
+		t.Fatalf("Expected the first and third assertions to pass, got %+v", results)
+	}
+	if results[1].Passed() || results[1].String() != "user:beth viewer document:roadmap: expected true, got false" {
+		t.Fatalf("Unexpected result %s", results[1])
+	}
+	if results[3].Error == nil || results[3].Allowed != nil {
+		t.Fatalf("Expected the check of an unknown relation to fail, got %+v", results[3])
+	}
+	if failed := response.Failed(); len(failed) != 2 {
+		t.Fatalf("Expected 2 failed assertions, got %d", len(failed))
+	}
+
+	xml := report.String()
+	for _, expected := range []string{
+		`<testsuites tests="4" failures="1" errors="1">`,
+		`<testcase classname="assertions ` + response.AuthorizationModelId + `" name="user:anne viewer document:roadmap is true"></testcase>`,
+		`<failure message="user:beth viewer document:roadmap: expected true, got false"></failure>`,
+		`<error message=`,
+	} {
+		if !strings.Contains(xml, expected) {
+			t.Fatalf("Expected the report to contain %q, got:\n%s", expected, xml)
+		}
+	}
+}
+
+func TestRunAssertionsWithAssertions(t *testing.T) {
+	fgaClient := newAssertionsTestClient(t)
+
+	response, err := fgaClient.RunAssertions(context.Background(), ClientRunAssertionsOptions{
+		Assertions: &[]ClientAssertion{{User: "user:anne", Relation: "viewer", Object: "document:roadmap", Expectation: true}},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !response.Passed() || len(response.Results) != 1 {
+		t.Fatalf("Unexpected results %+v", response.Results)
+	}
+
+	response, err = fgaClient.RunAssertions(context.Background(), ClientRunAssertionsOptions{})
+	if err != nil || len(response.Results) != 0 || !response.Passed() {
+		t.Fatalf("Expected no assertions to pass, got %+v, %v", response, err)
+	}
+
+	if err := fgaClient.SetAuthorizationModelId(""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = fgaClient.RunAssertions(context.Background(), ClientRunAssertionsOptions{})
+	if _, ok := err.(FgaRequiredParamError); !ok {
+		t.Fatalf("Expected FgaRequiredParamError without a model id, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	_context "context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/internal/constants"
+)
+
+type ClientSyncTuplesOptions struct {
+	RequestOptions
+
+	AuthorizationModelId *string                       `json:"authorization_model_id,omitempty"`
+	StoreId              *string                       `json:"store_id,omitempty"`
+	Consistency          *fgaSdk.ConsistencyPreference `json:"consistency,omitempty"`
+	// DryRun computes the plan without writing or deleting any tuple
+	DryRun bool `json:"dry_run,omitempty"`
+	// MaxPerChunk is the number of writes and deletes applied per transaction (default and maximum = 100)
+	MaxPerChunk int32 `json:"max_per_chunk,omitempty"`
+}
+
+type ClientSyncTuplesResponse struct {
+	// Deletes are the tuples in the scope which are not desired, or are desired with another condition
+	Deletes []ClientTupleKeyWithoutCondition `json:"deletes"`
+	// Writes are the desired tuples missing from the scope, or present with another condition
+	Writes []ClientTupleKey `json:"writes"`
+	// Unchanged is the number of desired tuples already present with the same condition
+	Unchanged int `json:"unchanged"`
+	// AppliedTransactions is the number of Write requests that succeeded, always zero in dry-run mode
+	AppliedTransactions int `json:"applied_transactions"`
+}
+
+/*
+ * SyncTuples makes the tuples matching the scope equal to the desired tuples. It reads the current tuples of the scope,
+ * deletes those which are not desired and writes the desired ones which are missing. A tuple whose condition changed
+ * is deleted and written again. Deletes are applied before writes, in transactions of at most MaxPerChunk tuples, so
+ * a failure leaves the scope partially synced: running SyncTuples again completes it.
+ * @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc.
+ * @param desired iter.Seq[ClientTupleKey] - the tuples the scope should hold, all of which must match the scope
+ * @param scope ClientReadRequest - the filter of the tuples to sync, as passed to Read
+ * @return *ClientSyncTuplesResponse - the plan, returned along with the error when applying it failed
+ */
+func (client *OpenFgaClient) SyncTuples(ctx _context.Context, desired iter.Seq[ClientTupleKey], scope ClientReadRequest, options ClientSyncTuplesOptions) (*ClientSyncTuplesResponse, error) {
+	maxPerChunk := int32(constants.ClientMaxTuplesPerWrite)
+	if options.MaxPerChunk < 0 || options.MaxPerChunk > maxPerChunk {
+		return nil, FgaInvalidError{param: "MaxPerChunk", description: fmt.Sprintf("must be between 1 and %d", maxPerChunk)}
+	}
+	if options.MaxPerChunk > 0 {
+		maxPerChunk = options.MaxPerChunk
+	}
+
+	desiredTuples := map[string]ClientTupleKey{}
+	var desiredOrder []string
+	for tuple := range desired {
+		if !matchesReadRequest(tuple, scope) {
+			return nil, FgaInvalidError{param: "desired", description: fmt.Sprintf("tuple %s is outside of the scope", syncTupleKey(tuple))}
+		}
+		key := syncTupleKey(tuple)
+		if existing, ok := desiredTuples[key]; ok {
+			if !sameCondition(existing.Condition, tuple.Condition) {
+				return nil, FgaInvalidError{param: "desired", description: fmt.Sprintf("tuple %s is desired with different conditions", key)}
+			}
+			continue
+		}
+		desiredTuples[key] = tuple
+		desiredOrder = append(desiredOrder, key)
+	}
+
+	response := &ClientSyncTuplesResponse{Deletes: []ClientTupleKeyWithoutCondition{}, Writes: []ClientTupleKey{}}
+	current := map[string]bool{}
+	for tuple, err := range client.ReadAll(ctx, scope, ClientReadAllOptions{ClientReadOptions: ClientReadOptions{
+		RequestOptions: options.RequestOptions,
+		StoreId:        options.StoreId,
+		Consistency:    options.Consistency,
+	}}) {
+		if err != nil {
+			return nil, err
+		}
+		key := syncTupleKey(tuple.Key)
+		if desiredTuple, ok := desiredTuples[key]; ok && sameCondition(desiredTuple.Condition, tuple.Key.Condition) {
+			current[key] = true
+			response.Unchanged++
+			continue
+		}
+		response.Deletes = append(response.Deletes, ClientTupleKeyWithoutCondition{User: tuple.Key.User, Relation: tuple.Key.Relation, Object: tuple.Key.Object})
+	}
+	for _, key := range desiredOrder {
+		if !current[key] {
+			response.Writes = append(response.Writes, desiredTuples[key])
+		}
+	}
+
+	if options.DryRun {
+		return response, nil
+	}
+
+	writeOptions := ClientWriteOptions{
+		RequestOptions:       options.RequestOptions,
+		AuthorizationModelId: options.AuthorizationModelId,
+		StoreId:              options.StoreId,
+	}
+	for start := 0; start < len(response.Deletes); start += int(maxPerChunk) {
+		end := min(start+int(maxPerChunk), len(response.Deletes))
+		_, err := client.Write(ctx).Body(ClientWriteRequest{Deletes: response.Deletes[start:end]}).Options(writeOptions).Execute()
+		if err != nil {
+			return response, err
+		}
+		response.AppliedTransactions++
+	}
+	for start := 0; start < len(response.Writes); start += int(maxPerChunk) {
+		end := min(start+int(maxPerChunk), len(response.Writes))
+		_, err := client.Write(ctx).Body(ClientWriteRequest{Writes: response.Writes[start:end]}).Options(writeOptions).Execute()
+		if err != nil {
+			return response, err
+		}
+		response.AppliedTransactions++
+	}
+
+	return response, nil
+}
+
+func syncTupleKey(tuple ClientTupleKey) string {
+	return tuple.Object + "#" + tuple.Relation + "@" + tuple.User
+}
+
+// matchesReadRequest reports whether Read would return the tuple for the request
+func matchesReadRequest(tuple ClientTupleKey, request ClientReadRequest) bool {
+	if request.User != nil && *request.User != "" && tuple.User != *request.User {
+		return false
+	}
+	if request.Relation != nil && *request.Relation != "" && tuple.Relation != *request.Relation {
+		return false
+	}
+	if request.Object != nil && *request.Object != "" {
+		if strings.HasSuffix(*request.Object, ":") {
+			return strings.HasPrefix(tuple.Object, *request.Object)
+		}
+		return tuple.Object == *request.Object
+	}
+	return true
+}
+
+// sameCondition compares conditions by name and by the JSON encoding of their context, so that numbers read back
+// from the server as float64 match the integers they were written as
+func sameCondition(a *fgaSdk.RelationshipCondition, b *fgaSdk.RelationshipCondition) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if a.Name != b.Name {
+		return false
+	}
+	if len(a.GetContext()) == 0 || len(b.GetContext()) == 0 {
+		return len(a.GetContext()) == len(b.GetContext())
+	}
+	aContext, aErr := json.Marshal(a.GetContext())
+	bContext, bErr := json.Marshal(b.GetContext())
+	return aErr == nil && bErr == nil && string(aContext) == string(bContext)
+}
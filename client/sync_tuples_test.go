@@ -0,0 +1,140 @@
+package client_test
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	. "github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/client/fake"
+	"github.com/openfga/go-sdk/language"
+)
+
+func newSyncTestClient(t *testing.T, tuples []ClientTupleKey) *fake.Client {
+	t.Helper()
+
+	model, err := language.TransformDSLToModel(`model
+  schema 1.1
+
+type user
+
+type folder
+  relations
+    define viewer: [user]
+
+type document
+  relations
+    define viewer: [user, user with in_office]
+
+condition in_office(floor: int) {
+  floor > 1
+}
+`)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	fgaClient, err := fake.NewClient(&fake.ClientOptions{AuthorizationModel: model, Tuples: tuples})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return fgaClient
+}
+
+func readAllTuples(t *testing.T, fgaClient *fake.Client) []ClientTupleKey {
+	t.Helper()
+
+	var tuples []ClientTupleKey
+	for _, object := range []string{"document:", "folder:"} {
+		for tuple, err := range fgaClient.ReadAll(context.Background(), ClientReadRequest{Object: openfga.ToPtr(object)}, ClientReadAllOptions{}) {
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			tuples = append(tuples, tuple.Key)
+		}
+	}
+	return tuples
+}
+
+func TestSyncTuples(t *testing.T) {
+	inOffice := func(floor int) *openfga.RelationshipCondition {
+		return &openfga.RelationshipCondition{Name: "in_office", Context: &map[string]interface{}{"floor": floor}}
+	}
+	fgaClient := newSyncTestClient(t, []ClientTupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		{User: "user:beth", Relation: "viewer", Object: "document:1", Condition: inOffice(2)},
+		{User: "user:carl", Relation: "viewer", Object: "document:1", Condition: inOffice(3)},
+		{User: "user:dave", Relation: "viewer", Object: "document:1"},
+		{User: "user:anne", Relation: "viewer", Object: "folder:1"},
+	})
+	ctx := context.Background()
+
+	desired := []ClientTupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		{User: "user:beth", Relation: "viewer", Object: "document:1", Condition: inOffice(2)},
+		{User: "user:carl", Relation: "viewer", Object: "document:1", Condition: inOffice(4)},
+		{User: "user:erin", Relation: "viewer", Object: "document:2"},
+	}
+	scope := ClientReadRequest{Object: openfga.ToPtr("document:")}
+
+	plan, err := fgaClient.SyncTuples(ctx, slices.Values(desired), scope, ClientSyncTuplesOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	expectedDeletes := []ClientTupleKeyWithoutCondition{
+		{User: "user:carl", Relation: "viewer", Object: "document:1"},
+		{User: "user:dave", Relation: "viewer", Object: "document:1"},
+	}
+	if !reflect.DeepEqual(plan.Deletes, expectedDeletes) || !reflect.DeepEqual(plan.Writes, desired[2:]) ||
+		plan.Unchanged != 2 || plan.AppliedTransactions != 0 {
+		t.Fatalf("Unexpected plan %+v", plan)
+	}
+	if tuples := readAllTuples(t, fgaClient); len(tuples) != 5 {
+		t.Fatalf("Expected a dry run not to change the tuples, got %v", tuples)
+	}
+
+	response, err := fgaClient.SyncTuples(ctx, slices.Values(desired), scope, ClientSyncTuplesOptions{MaxPerChunk: 1})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if response.AppliedTransactions != 4 {
+		t.Fatalf("Expected 4 transactions of one tuple, got %d", response.AppliedTransactions)
+	}
+	tuples := readAllTuples(t, fgaClient)
+	if len(tuples) != 5 || tuples[len(tuples)-1].Object != "folder:1" {
+		t.Fatalf("Expected the desired tuples and the tuple outside of the scope, got %v", tuples)
+	}
+
+	response, err = fgaClient.SyncTuples(ctx, slices.Values(desired), scope, ClientSyncTuplesOptions{})
+	if err != nil || len(response.Writes) != 0 || len(response.Deletes) != 0 || response.Unchanged != 4 {
+		t.Fatalf("Expected the scope to be in sync, got %+v, %v", response, err)
+	}
+}
+
+func TestSyncTuplesInvalidInput(t *testing.T) {
+	fgaClient := newSyncTestClient(t, nil)
+	ctx := context.Background()
+	scope := ClientReadRequest{Object: openfga.ToPtr("document:")}
+
+	tests := []struct {
+		name    string
+		desired []ClientTupleKey
+		options ClientSyncTuplesOptions
+	}{
+		{name: "outside of scope", desired: []ClientTupleKey{{User: "user:anne", Relation: "viewer", Object: "folder:1"}}},
+		{name: "conflicting conditions", desired: []ClientTupleKey{
+			{User: "user:anne", Relation: "viewer", Object: "document:1"},
+			{User: "user:anne", Relation: "viewer", Object: "document:1", Condition: &openfga.RelationshipCondition{Name: "in_office"}},
+		}},
+		{name: "chunk too large", options: ClientSyncTuplesOptions{MaxPerChunk: 101}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := fgaClient.SyncTuples(ctx, slices.Values(test.desired), scope, test.options)
+			if _, ok := err.(FgaInvalidError); !ok {
+				t.Fatalf("Expected FgaInvalidError, got %v", err)
+			}
+		})
+	}
+}
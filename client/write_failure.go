@@ -0,0 +1,193 @@
+package client
+
+import (
+	_context "context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/internal/utils/retryutils"
+)
+
+// ClientWriteFailureCategory classifies why a tuple of a write failed
+type ClientWriteFailureCategory string
+
+// List of ClientWriteFailureCategory
+const (
+	// CLIENT_WRITE_FAILURE_DUPLICATE is a write of a tuple which already exists, or a tuple present twice in a request
+	CLIENT_WRITE_FAILURE_DUPLICATE ClientWriteFailureCategory = "duplicate"
+	// CLIENT_WRITE_FAILURE_MISSING is a delete of a tuple which does not exist
+	CLIENT_WRITE_FAILURE_MISSING ClientWriteFailureCategory = "missing"
+	// CLIENT_WRITE_FAILURE_INVALID_TUPLE is a tuple whose type, relation, user or object is not valid for the model
+	CLIENT_WRITE_FAILURE_INVALID_TUPLE ClientWriteFailureCategory = "invalid_tuple"
+	// CLIENT_WRITE_FAILURE_CONDITION is a tuple whose condition is not defined or not allowed by the model
+	CLIENT_WRITE_FAILURE_CONDITION ClientWriteFailureCategory = "condition"
+	// CLIENT_WRITE_FAILURE_RATE_LIMITED is a request rejected by the rate limit of the server once the retries are exhausted
+	CLIENT_WRITE_FAILURE_RATE_LIMITED ClientWriteFailureCategory = "rate_limited"
+	// CLIENT_WRITE_FAILURE_TRANSIENT is a server or network error which may not happen again, or a request rejected by
+	// an open circuit breaker
+	CLIENT_WRITE_FAILURE_TRANSIENT ClientWriteFailureCategory = "transient"
+	// CLIENT_WRITE_FAILURE_NOT_APPLIED is a tuple of a chunked transactional write which was skipped or reverted
+	CLIENT_WRITE_FAILURE_NOT_APPLIED ClientWriteFailureCategory = "not_applied"
+	// CLIENT_WRITE_FAILURE_OTHER is any other failure
+	CLIENT_WRITE_FAILURE_OTHER ClientWriteFailureCategory = "other"
+)
+
+// IsTransient reports whether sending the tuple again may succeed
+func (c ClientWriteFailureCategory) IsTransient() bool {
+	return c == CLIENT_WRITE_FAILURE_RATE_LIMITED || c == CLIENT_WRITE_FAILURE_TRANSIENT
+}
+
+// ClassifyWriteError returns the category of the error of a failed write, or an empty category when err is nil
+func ClassifyWriteError(err error) ClientWriteFailureCategory {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, ErrWriteChunkSkipped) || errors.Is(err, ErrWriteChunkReverted) {
+		return CLIENT_WRITE_FAILURE_NOT_APPLIED
+	}
+	if errors.Is(err, fgaSdk.ErrCircuitOpen) {
+		return CLIENT_WRITE_FAILURE_TRANSIENT
+	}
+
+	var validationErr fgaSdk.FgaApiValidationError
+	var rateLimitErr fgaSdk.FgaApiRateLimitExceededError
+	var internalErr fgaSdk.FgaApiInternalError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &validationErr):
+		return classifyValidationError(validationErr)
+	case errors.As(err, &rateLimitErr):
+		return CLIENT_WRITE_FAILURE_RATE_LIMITED
+	case errors.As(err, &internalErr):
+		if internalErr.ResponseStatusCode() == http.StatusNotImplemented {
+			return CLIENT_WRITE_FAILURE_OTHER
+		}
+		return CLIENT_WRITE_FAILURE_TRANSIENT
+	case errors.As(err, &netErr):
+		return CLIENT_WRITE_FAILURE_TRANSIENT
+	default:
+		return CLIENT_WRITE_FAILURE_OTHER
+	}
+}
+
+// classifyValidationError relies on the message when the error code is shared by several failures, as the server
+// reports both duplicate writes and missing deletes as write_failed_due_to_invalid_input
+func classifyValidationError(err fgaSdk.FgaApiValidationError) ClientWriteFailureCategory {
+	message := ""
+	if model, ok := err.Model().(fgaSdk.ValidationErrorMessageResponse); ok {
+		message = strings.ToLower(model.GetMessage())
+	}
+
+	switch err.ResponseCode() {
+	case fgaSdk.ERRORCODE_CANNOT_ALLOW_DUPLICATE_TUPLES_IN_ONE_REQUEST:
+		return CLIENT_WRITE_FAILURE_DUPLICATE
+	case fgaSdk.ERRORCODE_WRITE_FAILED_DUE_TO_INVALID_INPUT:
+		switch {
+		case strings.Contains(message, "already exists"):
+			return CLIENT_WRITE_FAILURE_DUPLICATE
+		case strings.Contains(message, "does not exist"):
+			return CLIENT_WRITE_FAILURE_MISSING
+		}
+		return CLIENT_WRITE_FAILURE_INVALID_TUPLE
+	case fgaSdk.ERRORCODE_TYPE_NOT_FOUND, fgaSdk.ERRORCODE_RELATION_NOT_FOUND, fgaSdk.ERRORCODE_UNKNOWN_RELATION,
+		fgaSdk.ERRORCODE_INVALID_USER, fgaSdk.ERRORCODE_INVALID_TUPLE, fgaSdk.ERRORCODE_INVALID_OBJECT_FORMAT,
+		fgaSdk.ERRORCODE_OBJECT_INVALID_PATTERN, fgaSdk.ERRORCODE_OBJECT_TOO_LONG, fgaSdk.ERRORCODE_RELATION_TOO_LONG,
+		fgaSdk.ERRORCODE_TUPLE_KEY_VALUE_NOT_SPECIFIED, fgaSdk.ERRORCODE_UNSUPPORTED_USER_SET:
+		if strings.Contains(message, "condition") {
+			return CLIENT_WRITE_FAILURE_CONDITION
+		}
+		return CLIENT_WRITE_FAILURE_INVALID_TUPLE
+	case fgaSdk.ERRORCODE_VALIDATION_ERROR, fgaSdk.ERRORCODE_INVALID_WRITE_INPUT:
+		switch {
+		case strings.Contains(message, "condition"):
+			return CLIENT_WRITE_FAILURE_CONDITION
+		case strings.Contains(message, "tuple"):
+			return CLIENT_WRITE_FAILURE_INVALID_TUPLE
+		}
+	}
+	return CLIENT_WRITE_FAILURE_OTHER
+}
+
+// requeueTransientFailures sends again the chunks of a non-transactional write whose tuples failed with a rate limit or
+// a transient error, up to retries times with exponential backoff, replacing their responses
+func (client *OpenFgaClient) requeueTransientFailures(ctx _context.Context, bodies []ClientWriteRequest, responses []ClientWriteResponse, options ClientWriteOptions, retries int) error {
+	minWaitInMs := retryutils.GetRetryParamsOrDefault(client.config.RetryParams).MinWaitInMs
+	for attempt := 0; attempt < retries; attempt++ {
+		var pending []int
+		var headers http.Header
+		for index, response := range responses {
+			if failed, responseHeaders := response.transientFailure(); failed {
+				pending = append(pending, index)
+				if headers == nil {
+					headers = responseHeaders
+				}
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(retryutils.GetTimeToWait(attempt, retries, minWaitInMs, headers, "Write"))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		for _, index := range pending {
+			response, err := client.writeExecute(&SdkClientWriteRequest{
+				ctx:     ctx,
+				Client:  client,
+				body:    &bodies[index],
+				options: &options,
+			})
+			if _, ok := err.(fgaSdk.FgaApiAuthenticationError); ok {
+				return err
+			}
+			responses[index] = *response
+		}
+	}
+	return nil
+}
+
+// transientFailure reports whether the tuples of a chunk failed with a transient error, and the headers of the failed
+// response to honor its Retry-After
+func (o ClientWriteResponse) transientFailure() (bool, http.Header) {
+	var status ClientWriteStatus
+	var category ClientWriteFailureCategory
+	var httpResponse *http.Response
+	switch {
+	case len(o.Writes) > 0:
+		status, category, httpResponse = o.Writes[0].Status, o.Writes[0].FailureCategory, o.Writes[0].HttpResponse
+	case len(o.Deletes) > 0:
+		status, category, httpResponse = o.Deletes[0].Status, o.Deletes[0].FailureCategory, o.Deletes[0].HttpResponse
+	}
+	if status != FAILURE || !category.IsTransient() {
+		return false, nil
+	}
+	if httpResponse != nil {
+		return true, httpResponse.Header
+	}
+	return true, nil
+}
+
+// FailureCounts returns the number of failed writes and deletes per category
+func (o ClientWriteResponse) FailureCounts() map[ClientWriteFailureCategory]int {
+	counts := map[ClientWriteFailureCategory]int{}
+	for _, write := range o.Writes {
+		if write.Status == FAILURE {
+			counts[write.FailureCategory]++
+		}
+	}
+	for _, deleteResponse := range o.Deletes {
+		if deleteResponse.Status == FAILURE {
+			counts[deleteResponse.FailureCategory]++
+		}
+	}
+	return counts
+}
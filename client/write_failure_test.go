@@ -0,0 +1,157 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	. "github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/fgatest"
+	"github.com/openfga/go-sdk/language"
+)
+
+func TestWriteFailureCategories(t *testing.T) {
+	fgaClient := newSyncTestClient(t, []ClientTupleKey{{User: "user:anne", Relation: "viewer", Object: "document:1"}})
+
+	response, err := fgaClient.Write(context.Background()).Body(ClientWriteRequest{
+		Writes: []ClientTupleKey{
+			{User: "user:anne", Relation: "viewer", Object: "document:1"},
+			{User: "user:anne", Relation: "owner", Object: "document:1"},
+			{User: "user:anne", Relation: "viewer", Object: "document:2", Condition: &openfga.RelationshipCondition{Name: "unknown"}},
+			{User: "user:beth", Relation: "viewer", Object: "document:1"},
+		},
+		Deletes: []ClientTupleKeyWithoutCondition{{User: "user:carl", Relation: "viewer", Object: "document:1"}},
+	}).Options(ClientWriteOptions{Transaction: &TransactionOptions{Disable: true}}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expected := []ClientWriteFailureCategory{CLIENT_WRITE_FAILURE_DUPLICATE, CLIENT_WRITE_FAILURE_INVALID_TUPLE, CLIENT_WRITE_FAILURE_CONDITION, ""}
+	for index, write := range response.Writes {
+		if write.FailureCategory != expected[index] {
+			t.Fatalf("Expected write %d to be classified as %q, got %q (%v)", index, expected[index], write.FailureCategory, write.Error)
+		}
+	}
+	if response.Deletes[0].FailureCategory != CLIENT_WRITE_FAILURE_MISSING {
+		t.Fatalf("Expected the delete to be classified as missing, got %q", response.Deletes[0].FailureCategory)
+	}
+
+	counts := response.FailureCounts()
+	if len(counts) != 4 || counts[CLIENT_WRITE_FAILURE_DUPLICATE] != 1 || counts[CLIENT_WRITE_FAILURE_MISSING] != 1 {
+		t.Fatalf("Unexpected failure counts %v", counts)
+	}
+}
+
+func TestClassifyWriteError(t *testing.T) {
+	if ClassifyWriteError(nil) != "" {
+		t.Fatalf("Expected no category without an error")
+	}
+	if ClassifyWriteError(ErrWriteChunkSkipped) != CLIENT_WRITE_FAILURE_NOT_APPLIED {
+		t.Fatalf("Expected a skipped chunk not to be applied")
+	}
+	if category := ClassifyWriteError(context.Canceled); category != CLIENT_WRITE_FAILURE_OTHER || category.IsTransient() {
+		t.Fatalf("Expected a cancelled write not to be transient, got %q", category)
+	}
+	if !CLIENT_WRITE_FAILURE_RATE_LIMITED.IsTransient() || !CLIENT_WRITE_FAILURE_TRANSIENT.IsTransient() {
+		t.Fatalf("Expected rate limits and transient errors to be transient")
+	}
+}
+
+func newTestServerClient(t *testing.T, server *fgatest.TestServer) *OpenFgaClient {
+	t.Helper()
+	ctx := context.Background()
+
+	fgaClient, err := NewSdkClient(&ClientConfiguration{
+		ApiUrl:      server.URL,
+		RetryParams: &openfga.RetryParams{MaxRetry: 0, MinWaitInMs: 1},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	store, err := fgaClient.CreateStore(ctx).Body(ClientCreateStoreRequest{Name: "test"}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := fgaClient.SetStoreId(store.Id); err != nil {
+		t.Fatalf("%v", err)
+	}
+	model, err := language.TransformDSLToModel("model\n  schema 1.1\ntype user\ntype document\n  relations\n    define viewer: [user]\n")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := fgaClient.WriteAuthorizationModel(ctx).Body(*model).Execute(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	return fgaClient
+}
+
+func TestWriteRequeuesTransientFailures(t *testing.T) {
+	writes := []ClientTupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		{User: "user:beth", Relation: "viewer", Object: "document:1"},
+	}
+
+	for _, fault := range []fgatest.Fault{fgatest.ServerError(http.StatusServiceUnavailable), fgatest.RateLimited(0)} {
+		t.Run(http.StatusText(fault.StatusCode), func(t *testing.T) {
+			server := fgatest.NewTestServer(t)
+			fgaClient := newTestServerClient(t, server)
+
+			fault.Path = "/write"
+			server.Inject(fault)
+			response, err := fgaClient.Write(context.Background()).Body(ClientWriteRequest{Writes: writes}).Options(ClientWriteOptions{
+				Transaction: &TransactionOptions{Disable: true, MaxParallelRequests: 1},
+			}).Execute()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			counts := response.FailureCounts()
+			if len(counts) != 1 || counts[ClassifyWriteError(response.Writes[0].Error)] != 1 || !response.Writes[0].FailureCategory.IsTransient() {
+				t.Fatalf("Expected one transient failure, got %v", counts)
+			}
+
+			server.Inject(fault)
+			response, err = fgaClient.Write(context.Background()).Body(ClientWriteRequest{Writes: writes}).Options(ClientWriteOptions{
+				Transaction: &TransactionOptions{Disable: true, MaxParallelRequests: 1, TransientFailureRetries: 2},
+				Conflict:    ClientWriteConflictOptions{OnDuplicateWrites: CLIENT_WRITE_REQUEST_ON_DUPLICATE_WRITES_IGNORE},
+			}).Execute()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if counts := response.FailureCounts(); len(counts) != 0 {
+				t.Fatalf("Expected the transient failure to be retried, got %v", counts)
+			}
+		})
+	}
+}
+
+func TestClassifyWriteErrorCircuitOpen(t *testing.T) {
+	server := fgatest.NewTestServer(t)
+	fgaClient, err := NewSdkClient(&ClientConfiguration{
+		ApiUrl:         server.URL,
+		StoreId:        "01GXSB9YR785C4FYS3C0RTG7B2",
+		RetryParams:    &openfga.RetryParams{MaxRetry: 0, MinWaitInMs: 1},
+		CircuitBreaker: &openfga.CircuitBreakerConfiguration{ConsecutiveFailures: 1},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	server.Inject(fgatest.ServerError(http.StatusServiceUnavailable))
+
+	body := ClientWriteRequest{Writes: []ClientTupleKey{{User: "user:anne", Relation: "viewer", Object: "document:1"}}}
+	if _, err := fgaClient.Write(context.Background()).Body(body).Execute(); ClassifyWriteError(err) != CLIENT_WRITE_FAILURE_TRANSIENT {
+		t.Fatalf("Expected a transient failure, got %v", err)
+	}
+
+	_, err = fgaClient.Write(context.Background()).Body(body).Execute()
+	if !errors.Is(err, openfga.ErrCircuitOpen) {
+		t.Fatalf("Expected the circuit to be open, got %v", err)
+	}
+	if category := ClassifyWriteError(err); category != CLIENT_WRITE_FAILURE_TRANSIENT {
+		t.Fatalf("Expected a rejected write to be transient, got %q", category)
+	}
+	if requests := len(server.Requests()); requests != 1 {
+		t.Fatalf("Expected the rejected write not to be sent, got %d requests", requests)
+	}
+}
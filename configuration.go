@@ -1,6 +1,7 @@
 package openfga
 
 import (
+	"log/slog"
 	"net/http"
 
 	"github.com/openfga/go-sdk/credentials"
@@ -34,6 +35,22 @@ type Configuration struct {
 	HTTPClient     *http.Client
 	RetryParams    *RetryParams
 	Telemetry      *telemetry.Configuration `json:"telemetry,omitempty"`
+	// CircuitBreaker - optional circuit breaker failing requests fast while the API is failing, disabled when nil
+	CircuitBreaker *CircuitBreakerConfiguration `json:"circuit_breaker,omitempty"`
+	// RateLimiter - optional limits of the rate and concurrency of the requests of the client, disabled when nil
+	RateLimiter *RateLimiterConfiguration `json:"rate_limiter,omitempty"`
+	// Hedging - optional hedging policies of the read operations, disabled when nil
+	Hedging *HedgingConfiguration `json:"hedging,omitempty"`
+	// Retry - optional retry policies replacing the default one of RetryParams, with a retry time budget and a retry
+	// budget shared by the requests of the client
+	Retry *RetryConfiguration `json:"retry,omitempty"`
+	// Timeouts - optional timeouts of each attempt of a request and deadlines of the requests, disabled when nil
+	Timeouts *TimeoutConfiguration `json:"timeouts,omitempty"`
+	// Logger - optional logger receiving the structured events of the client, with bearer tokens and client secrets
+	// redacted. When nil, the events are written to stderr in debug mode, and discarded otherwise.
+	Logger *slog.Logger `json:"-"`
+	// LogRedactUsers - redacts the users of the requests from the events of the Logger
+	LogRedactUsers bool `json:"log_redact_users,omitempty"`
 }
 
 func GetSdkUserAgent() string {
@@ -63,6 +80,13 @@ func NewConfiguration(config Configuration) (*Configuration, error) {
 		HTTPClient:     config.HTTPClient,
 		RetryParams:    config.RetryParams,
 		Telemetry:      config.Telemetry,
+		CircuitBreaker: config.CircuitBreaker,
+		RateLimiter:    config.RateLimiter,
+		Hedging:        config.Hedging,
+		Retry:          config.Retry,
+		Timeouts:       config.Timeouts,
+		Logger:         config.Logger,
+		LogRedactUsers: config.LogRedactUsers,
 	}
 
 	if cfg.UserAgent == "" {
@@ -120,5 +144,25 @@ func (c *Configuration) ValidateConfig() error {
 		return err
 	}
 
+	if err := c.Retry.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Timeouts.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.CircuitBreaker.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.RateLimiter.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Hedging.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
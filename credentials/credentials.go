@@ -3,6 +3,7 @@ package credentials
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -39,12 +40,15 @@ type Credentials struct {
 	Method  CredentialsMethod `json:"method,omitempty"`
 	Config  *Config           `json:"config,omitempty"`
 	Context context.Context
+	// Logger receives the events of the token requests of the client credentials flow
+	Logger *slog.Logger `json:"-"`
 }
 
 func NewCredentials(config Credentials) (*Credentials, error) {
 	creds := &Credentials{
 		Method: config.Method,
 		Config: config.Config,
+		Logger: config.Logger,
 	}
 
 	if creds.Method == "" {
@@ -108,6 +112,7 @@ func (c *Credentials) GetHttpClientAndHeaderOverrides(retryParams retryutils.Ret
 		requestConfig := clientcredentials.RequestConfig{
 			RetryParams: retryParams,
 			Debug:       debug,
+			Logger:      c.Logger,
 		}
 		_ = requestConfig.New(requestConfig)
 		ccConfig := clientcredentials.Config{
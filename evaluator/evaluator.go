@@ -0,0 +1,316 @@
+// Package evaluator answers Check, ListObjects, ListUsers and Expand queries in-process, by interpreting the rewrites of
+// an authorization model over a set of tuples. It returns the same response types as the API so it can stand in for an
+// OpenFGA server in unit tests:
+//
+//	eval, err := evaluator.NewEvaluator(model, []openfga.TupleKey{
+//		{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+//	}, nil)
+//	response, err := eval.Check(ctx, openfga.CheckRequest{
+//		TupleKey: openfga.CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+//	})
+//
+// Conditions are evaluated by a ConditionEvaluator supplied in EvaluatorOptions, as this package does not embed a CEL
+// runtime.
+package evaluator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/language"
+)
+
+// DefaultMaxResolutionDepth is the default number of nested relations a query may traverse, matching the server default
+const DefaultMaxResolutionDepth = 25
+
+var (
+	// ErrInvalidInput is wrapped by the errors returned for invalid models, tuples and requests
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrResolutionDepthExceeded is returned when a query traverses more than MaxResolutionDepth nested relations
+	ErrResolutionDepthExceeded = errors.New("resolution depth exceeded")
+	// ErrNoConditionEvaluator is returned when a query needs to evaluate a condition and no ConditionEvaluator is set
+	ErrNoConditionEvaluator = errors.New("no condition evaluator configured")
+)
+
+// ConditionEvaluator decides whether the condition of a tuple is met. Parameters holds the request context merged with
+// the context stored on the tuple, the latter taking precedence.
+type ConditionEvaluator interface {
+	EvaluateCondition(ctx context.Context, condition fgaSdk.Condition, parameters map[string]interface{}) (bool, error)
+}
+
+// ConditionEvaluatorFunc adapts a function to a ConditionEvaluator
+type ConditionEvaluatorFunc func(ctx context.Context, condition fgaSdk.Condition, parameters map[string]interface{}) (bool, error)
+
+// EvaluateCondition calls f(ctx, condition, parameters)
+func (f ConditionEvaluatorFunc) EvaluateCondition(ctx context.Context, condition fgaSdk.Condition, parameters map[string]interface{}) (bool, error) {
+	return f(ctx, condition, parameters)
+}
+
+// EvaluatorOptions configures an Evaluator
+type EvaluatorOptions struct {
+	// ConditionEvaluator evaluates the conditions of conditional tuples. When nil, queries reaching a conditional tuple
+	// fail with ErrNoConditionEvaluator.
+	ConditionEvaluator ConditionEvaluator
+	// MaxResolutionDepth defaults to DefaultMaxResolutionDepth
+	MaxResolutionDepth int
+}
+
+// Evaluator answers relationship queries against an authorization model and a fixed set of tuples. It is safe for
+// concurrent use.
+type Evaluator struct {
+	types              map[string]fgaSdk.TypeDefinition
+	conditions         map[string]fgaSdk.Condition
+	tuples             *tupleIndex
+	conditionEvaluator ConditionEvaluator
+	maxResolutionDepth int
+}
+
+// NewEvaluator validates the model with language.ValidateAuthorizationModel and every tuple with ValidateTuple, and
+// returns an Evaluator for them. options may be nil.
+func NewEvaluator(model fgaSdk.AuthorizationModel, tuples []fgaSdk.TupleKey, options *EvaluatorOptions) (*Evaluator, error) {
+	issues := language.ValidateAuthorizationModel(fgaSdk.WriteAuthorizationModelRequest{
+		SchemaVersion:   model.SchemaVersion,
+		TypeDefinitions: model.TypeDefinitions,
+		Conditions:      model.Conditions,
+	})
+	for _, issue := range issues {
+		if issue.Severity == language.MODEL_ISSUE_SEVERITY_ERROR {
+			return nil, fmt.Errorf("%w: invalid authorization model: %s", ErrInvalidInput, issue)
+		}
+	}
+
+	if options == nil {
+		options = &EvaluatorOptions{}
+	}
+	e := &Evaluator{
+		types:              make(map[string]fgaSdk.TypeDefinition),
+		conditions:         model.GetConditions(),
+		conditionEvaluator: options.ConditionEvaluator,
+		maxResolutionDepth: options.MaxResolutionDepth,
+	}
+	if e.maxResolutionDepth <= 0 {
+		e.maxResolutionDepth = DefaultMaxResolutionDepth
+	}
+	for _, typeDefinition := range model.TypeDefinitions {
+		e.types[typeDefinition.Type] = typeDefinition
+	}
+
+	for _, tuple := range tuples {
+		if err := e.ValidateTuple(tuple); err != nil {
+			return nil, err
+		}
+	}
+	e.tuples = newTupleIndex(nil, tuples)
+
+	return e, nil
+}
+
+// Check reports whether the user has the relation with the object. AuthorizationModelId, Trace and Consistency are
+// ignored.
+func (e *Evaluator) Check(ctx context.Context, body fgaSdk.CheckRequest) (*fgaSdk.CheckResponse, error) {
+	objectType, objectId, err := parseObject(body.TupleKey.Object)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.validateRelation(objectType, body.TupleKey.Relation); err != nil {
+		return nil, err
+	}
+	user, err := parseUser(body.TupleKey.User)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.validateUser(user); err != nil {
+		return nil, err
+	}
+
+	r, err := e.newResolution(ctx, contextualTupleKeys(body.ContextualTuples), body.Context)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := r.check(objectType, objectId, body.TupleKey.Relation, user, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &fgaSdk.CheckResponse{Allowed: fgaSdk.ToPtr(allowed)}, nil
+}
+
+func (e *Evaluator) relations(objectType string) map[string]fgaSdk.Userset {
+	typeDefinition := e.types[objectType]
+	return typeDefinition.GetRelations()
+}
+
+func contextualTupleKeys(contextualTuples *fgaSdk.ContextualTupleKeys) []fgaSdk.TupleKey {
+	if contextualTuples == nil {
+		return nil
+	}
+	return contextualTuples.TupleKeys
+}
+
+// resolution holds the state of a single query
+type resolution struct {
+	ctx        context.Context
+	evaluator  *Evaluator
+	tuples     *tupleIndex
+	parameters map[string]interface{}
+	// visiting holds the checks being resolved on the current path, to stop at cycles
+	visiting map[string]bool
+}
+
+func (e *Evaluator) newResolution(ctx context.Context, contextualTuples []fgaSdk.TupleKey, parameters *map[string]interface{}) (*resolution, error) {
+	for _, tuple := range contextualTuples {
+		if err := e.ValidateTuple(tuple); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &resolution{ctx: ctx, evaluator: e, tuples: e.tuples, visiting: make(map[string]bool)}
+	if len(contextualTuples) > 0 {
+		r.tuples = newTupleIndex(e.tuples, contextualTuples)
+	}
+	if parameters != nil {
+		r.parameters = *parameters
+	}
+	return r, nil
+}
+
+func (r *resolution) check(objectType string, objectId string, relation string, user userRef, depth int) (bool, error) {
+	if err := r.ctx.Err(); err != nil {
+		return false, err
+	}
+	if depth > r.evaluator.maxResolutionDepth {
+		return false, ErrResolutionDepthExceeded
+	}
+	// a userset always includes itself
+	if user.relation == relation && user.objectType == objectType && user.objectId == objectId {
+		return true, nil
+	}
+
+	userset, ok := r.evaluator.relations(objectType)[relation]
+	if !ok {
+		return false, nil
+	}
+
+	key := objectType + ":" + objectId + "#" + relation + "@" + user.String()
+	if r.visiting[key] {
+		return false, nil
+	}
+	r.visiting[key] = true
+	defer delete(r.visiting, key)
+
+	return r.rewrite(objectType, objectId, relation, userset, user, depth)
+}
+
+func (r *resolution) rewrite(objectType string, objectId string, relation string, userset fgaSdk.Userset, user userRef, depth int) (bool, error) {
+	object := objectType + ":" + objectId
+
+	switch {
+	case userset.This != nil:
+		return r.direct(objectType, object, relation, user, depth)
+	case userset.ComputedUserset != nil:
+		return r.check(objectType, objectId, userset.ComputedUserset.GetRelation(), user, depth+1)
+	case userset.TupleToUserset != nil:
+		computed := userset.TupleToUserset.ComputedUserset.GetRelation()
+		return anyAllowed(r.tuples.get(object, userset.TupleToUserset.Tupleset.GetRelation()), func(tuple fgaSdk.TupleKey) (bool, error) {
+			parent, err := parseUser(tuple.User)
+			if err != nil || parent.wildcard || parent.relation != "" {
+				return false, nil
+			}
+			if _, ok := r.evaluator.relations(parent.objectType)[computed]; !ok {
+				return false, nil
+			}
+			if met, err := r.conditionMet(tuple); err != nil || !met {
+				return false, err
+			}
+			return r.check(parent.objectType, parent.objectId, computed, user, depth+1)
+		})
+	case userset.Union != nil:
+		return anyAllowed(userset.Union.Child, func(child fgaSdk.Userset) (bool, error) {
+			return r.rewrite(objectType, objectId, relation, child, user, depth)
+		})
+	case userset.Intersection != nil:
+		for _, child := range userset.Intersection.Child {
+			allowed, err := r.rewrite(objectType, objectId, relation, child, user, depth)
+			if err != nil || !allowed {
+				return false, err
+			}
+		}
+		return len(userset.Intersection.Child) > 0, nil
+	case userset.Difference != nil:
+		allowed, err := r.rewrite(objectType, objectId, relation, userset.Difference.Base, user, depth)
+		if err != nil || !allowed {
+			return false, err
+		}
+		excluded, err := r.rewrite(objectType, objectId, relation, userset.Difference.Subtract, user, depth)
+		if err != nil {
+			return false, err
+		}
+		return !excluded, nil
+	}
+	return false, fmt.Errorf("%w: relation '%s' of type '%s' has an empty rewrite", ErrInvalidInput, relation, objectType)
+}
+
+// direct resolves the tuples assigned to the relation of the object
+func (r *resolution) direct(objectType string, object string, relation string, user userRef, depth int) (bool, error) {
+	return anyAllowed(r.tuples.get(object, relation), func(tuple fgaSdk.TupleKey) (bool, error) {
+		tupleUser, err := parseUser(tuple.User)
+		if err != nil || !r.evaluator.allowsUser(objectType, relation, tupleUser, conditionName(tuple)) {
+			return false, nil
+		}
+
+		matches := tupleUser == user ||
+			(tupleUser.wildcard && !user.wildcard && user.relation == "" && tupleUser.objectType == user.objectType)
+		if !matches && tupleUser.relation == "" {
+			return false, nil
+		}
+		if met, err := r.conditionMet(tuple); err != nil || !met {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+		return r.check(tupleUser.objectType, tupleUser.objectId, tupleUser.relation, user, depth+1)
+	})
+}
+
+// anyAllowed returns true as soon as one of the values resolves to true. Errors are only returned when no value does.
+func anyAllowed[T any](values []T, resolve func(T) (bool, error)) (bool, error) {
+	var firstErr error
+	for _, value := range values {
+		allowed, err := resolve(value)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if allowed {
+			return true, nil
+		}
+	}
+	return false, firstErr
+}
+
+func (r *resolution) conditionMet(tuple fgaSdk.TupleKey) (bool, error) {
+	name := conditionName(tuple)
+	if name == "" {
+		return true, nil
+	}
+	condition, ok := r.evaluator.conditions[name]
+	if !ok {
+		return false, fmt.Errorf("%w: condition '%s' is not defined in the model", ErrInvalidInput, name)
+	}
+	if r.evaluator.conditionEvaluator == nil {
+		return false, fmt.Errorf("%w: tuple %s#%s@%s has condition '%s'", ErrNoConditionEvaluator, tuple.Object, tuple.Relation, tuple.User, name)
+	}
+
+	parameters := make(map[string]interface{}, len(r.parameters))
+	for key, value := range r.parameters {
+		parameters[key] = value
+	}
+	for key, value := range tuple.Condition.GetContext() {
+		parameters[key] = value
+	}
+	return r.evaluator.conditionEvaluator.EvaluateCondition(r.ctx, condition, parameters)
+}
@@ -0,0 +1,247 @@
+package evaluator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/language"
+)
+
+const testModelDSL = `model
+  schema 1.1
+
+type user
+
+type group
+  relations
+    define member: [user, group#member]
+
+type folder
+  relations
+    define owner: [user]
+    define viewer: [user, user:*] or owner
+
+type document
+  relations
+    define blocked: [user]
+    define editor: [user with ip_allowed]
+    define owner: [user]
+    define parent: [folder]
+    define viewer: ([user, group#member] or editor or viewer from parent) but not blocked
+    define can_delete: owner and editor
+
+condition ip_allowed(allowed: bool) {
+  allowed
+}
+`
+
+func testTuples() []fgaSdk.TupleKey {
+	return []fgaSdk.TupleKey{
+		{User: "user:anne", Relation: "member", Object: "group:eng"},
+		{User: "group:eng#member", Relation: "member", Object: "group:all"},
+		{User: "group:all#member", Relation: "viewer", Object: "document:roadmap"},
+		{User: "user:carl", Relation: "blocked", Object: "document:roadmap"},
+		{User: "folder:public", Relation: "parent", Object: "document:roadmap"},
+		{User: "user:*", Relation: "viewer", Object: "folder:public"},
+		{User: "user:dave", Relation: "owner", Object: "document:plan"},
+		{User: "user:dave", Relation: "editor", Object: "document:plan", Condition: &fgaSdk.RelationshipCondition{
+			Name: "ip_allowed", Context: &map[string]interface{}{"allowed": true},
+		}},
+		{User: "user:erin", Relation: "editor", Object: "document:plan", Condition: &fgaSdk.RelationshipCondition{
+			Name: "ip_allowed", Context: &map[string]interface{}{"allowed": false},
+		}},
+	}
+}
+
+// testConditionEvaluator evaluates conditions whose expression is the name of a boolean parameter
+var testConditionEvaluator = ConditionEvaluatorFunc(func(_ context.Context, condition fgaSdk.Condition, parameters map[string]interface{}) (bool, error) {
+	value, ok := parameters[condition.Expression].(bool)
+	if !ok {
+		return false, fmt.Errorf("missing parameter '%s'", condition.Expression)
+	}
+	return value, nil
+})
+
+func testModel(t *testing.T, dsl string) fgaSdk.AuthorizationModel {
+	t.Helper()
+
+	request, err := language.TransformDSLToModel(dsl)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return fgaSdk.AuthorizationModel{
+		SchemaVersion:   request.SchemaVersion,
+		TypeDefinitions: request.TypeDefinitions,
+		Conditions:      request.Conditions,
+	}
+}
+
+func newTestEvaluator(t *testing.T, options *EvaluatorOptions) *Evaluator {
+	t.Helper()
+
+	e, err := NewEvaluator(testModel(t, testModelDSL), testTuples(), options)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return e
+}
+
+func check(e *Evaluator, user string, relation string, object string) (bool, error) {
+	response, err := e.Check(context.Background(), fgaSdk.CheckRequest{
+		TupleKey: fgaSdk.CheckRequestTupleKey{User: user, Relation: relation, Object: object},
+	})
+	if err != nil {
+		return false, err
+	}
+	return response.GetAllowed(), nil
+}
+
+func TestEvaluatorCheck(t *testing.T) {
+	e := newTestEvaluator(t, &EvaluatorOptions{ConditionEvaluator: testConditionEvaluator})
+
+	tests := []struct {
+		user     string
+		relation string
+		object   string
+		allowed  bool
+	}{
+		{user: "user:anne", relation: "member", object: "group:all", allowed: true},
+		{user: "user:anne", relation: "viewer", object: "document:roadmap", allowed: true},
+		{user: "group:eng#member", relation: "viewer", object: "document:roadmap", allowed: true},
+		{user: "group:eng#member", relation: "member", object: "group:eng", allowed: true},
+		{user: "user:bob", relation: "viewer", object: "document:roadmap", allowed: true},
+		{user: "user:*", relation: "viewer", object: "folder:public", allowed: true},
+		{user: "user:carl", relation: "viewer", object: "document:roadmap", allowed: false},
+		{user: "user:anne", relation: "viewer", object: "document:plan", allowed: false},
+		{user: "user:dave", relation: "can_delete", object: "document:plan", allowed: true},
+		{user: "user:dave", relation: "viewer", object: "document:plan", allowed: true},
+		{user: "user:erin", relation: "editor", object: "document:plan", allowed: false},
+		{user: "user:anne", relation: "owner", object: "folder:unknown", allowed: false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s %s", test.user, test.relation, test.object), func(t *testing.T) {
+			allowed, err := check(e, test.user, test.relation, test.object)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if allowed != test.allowed {
+				t.Fatalf("Check() = %v, want %v", allowed, test.allowed)
+			}
+		})
+	}
+}
+
+func TestEvaluatorCheckContextualTuplesAndContext(t *testing.T) {
+	e := newTestEvaluator(t, &EvaluatorOptions{ConditionEvaluator: testConditionEvaluator})
+
+	response, err := e.Check(context.Background(), fgaSdk.CheckRequest{
+		TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:frank", Relation: "viewer", Object: "document:secret"},
+		ContextualTuples: &fgaSdk.ContextualTupleKeys{TupleKeys: []fgaSdk.TupleKey{
+			{User: "user:frank", Relation: "editor", Object: "document:secret", Condition: &fgaSdk.RelationshipCondition{Name: "ip_allowed"}},
+		}},
+		Context: &map[string]interface{}{"allowed": true},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !response.GetAllowed() {
+		t.Fatalf("Expected the contextual tuple to grant access")
+	}
+
+	// the context stored on the tuple takes precedence over the request context
+	response, err = e.Check(context.Background(), fgaSdk.CheckRequest{
+		TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:erin", Relation: "editor", Object: "document:plan"},
+		Context:  &map[string]interface{}{"allowed": true},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if response.GetAllowed() {
+		t.Fatalf("Expected the tuple context to take precedence")
+	}
+
+	// contextual tuples are not persisted
+	if allowed, err := check(e, "user:frank", "viewer", "document:secret"); err != nil || allowed {
+		t.Fatalf("Expected contextual tuples to only apply to their request, got %v, %v", allowed, err)
+	}
+}
+
+func TestEvaluatorCheckErrors(t *testing.T) {
+	e := newTestEvaluator(t, nil)
+
+	if _, err := check(e, "user:dave", "editor", "document:plan"); !errors.Is(err, ErrNoConditionEvaluator) {
+		t.Fatalf("Expected ErrNoConditionEvaluator, got %v", err)
+	}
+	// a condition that does not need to be evaluated does not fail the check
+	if allowed, err := check(e, "user:anne", "viewer", "document:roadmap"); err != nil || !allowed {
+		t.Fatalf("Expected the check to be allowed, got %v, %v", allowed, err)
+	}
+
+	for _, test := range []struct{ user, relation, object string }{
+		{user: "user:anne", relation: "viewer", object: "report:1"},
+		{user: "user:anne", relation: "approver", object: "document:1"},
+		{user: "anne", relation: "viewer", object: "document:1"},
+		{user: "user:anne", relation: "viewer", object: "document"},
+		{user: "group:eng#admin", relation: "viewer", object: "document:1"},
+	} {
+		if _, err := check(e, test.user, test.relation, test.object); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("Expected ErrInvalidInput for %v, got %v", test, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := e.Check(ctx, fgaSdk.CheckRequest{
+		TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+	}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestEvaluatorCyclesAndDepth(t *testing.T) {
+	model := testModel(t, testModelDSL)
+
+	e, err := NewEvaluator(model, []fgaSdk.TupleKey{
+		{User: "group:b#member", Relation: "member", Object: "group:a"},
+		{User: "group:a#member", Relation: "member", Object: "group:b"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if allowed, err := check(e, "user:anne", "member", "group:a"); err != nil || allowed {
+		t.Fatalf("Expected a cycle to resolve to false, got %v, %v", allowed, err)
+	}
+
+	e, err = NewEvaluator(model, []fgaSdk.TupleKey{
+		{User: "user:anne", Relation: "member", Object: "group:1"},
+		{User: "group:1#member", Relation: "member", Object: "group:2"},
+		{User: "group:2#member", Relation: "member", Object: "group:3"},
+		{User: "group:3#member", Relation: "member", Object: "group:4"},
+	}, &EvaluatorOptions{MaxResolutionDepth: 2})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := check(e, "user:anne", "member", "group:4"); !errors.Is(err, ErrResolutionDepthExceeded) {
+		t.Fatalf("Expected ErrResolutionDepthExceeded, got %v", err)
+	}
+	if allowed, err := check(e, "user:anne", "member", "group:2"); err != nil || !allowed {
+		t.Fatalf("Expected the check to be allowed, got %v, %v", allowed, err)
+	}
+}
+
+func TestNewEvaluatorValidation(t *testing.T) {
+	model := testModel(t, testModelDSL)
+
+	if _, err := NewEvaluator(model, []fgaSdk.TupleKey{{User: "group:eng", Relation: "viewer", Object: "document:1"}}, nil); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("Expected ErrInvalidInput for a tuple with a disallowed user type, got %v", err)
+	}
+
+	invalid := testModel(t, "model\n  schema 1.1\ntype document\n  relations\n    define viewer: [user]\n")
+	if _, err := NewEvaluator(invalid, nil, nil); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("Expected ErrInvalidInput for an invalid model, got %v", err)
+	}
+}
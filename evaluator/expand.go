@@ -0,0 +1,103 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// Expand returns the userset tree of the relation of the object, one level deep like the API: leaves list the directly
+// assigned users, the computed usersets and the usersets reached through tuplesets, which can be expanded in turn.
+// Conditions of the tuples are not evaluated. AuthorizationModelId and Consistency are ignored.
+func (e *Evaluator) Expand(ctx context.Context, body fgaSdk.ExpandRequest) (*fgaSdk.ExpandResponse, error) {
+	objectType, _, err := parseObject(body.TupleKey.Object)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.validateRelation(objectType, body.TupleKey.Relation); err != nil {
+		return nil, err
+	}
+
+	r, err := e.newResolution(ctx, contextualTupleKeys(body.ContextualTuples), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := r.expand(objectType, body.TupleKey.Object, body.TupleKey.Relation, e.relations(objectType)[body.TupleKey.Relation])
+	if err != nil {
+		return nil, err
+	}
+	return &fgaSdk.ExpandResponse{Tree: &fgaSdk.UsersetTree{Root: root}}, nil
+}
+
+func (r *resolution) expand(objectType string, object string, relation string, userset fgaSdk.Userset) (*fgaSdk.Node, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	node := &fgaSdk.Node{Name: object + "#" + relation}
+	switch {
+	case userset.This != nil:
+		users := []string{}
+		for _, tuple := range r.tuples.get(object, relation) {
+			if user, err := parseUser(tuple.User); err == nil && r.evaluator.allowsUser(objectType, relation, user, conditionName(tuple)) {
+				users = append(users, tuple.User)
+			}
+		}
+		node.Leaf = &fgaSdk.Leaf{Users: &fgaSdk.Users{Users: users}}
+	case userset.ComputedUserset != nil:
+		node.Leaf = &fgaSdk.Leaf{Computed: &fgaSdk.Computed{Userset: object + "#" + userset.ComputedUserset.GetRelation()}}
+	case userset.TupleToUserset != nil:
+		tupleset := userset.TupleToUserset.Tupleset.GetRelation()
+		computedRelation := userset.TupleToUserset.ComputedUserset.GetRelation()
+		computed := []fgaSdk.Computed{}
+		for _, tuple := range r.tuples.get(object, tupleset) {
+			parent, err := parseUser(tuple.User)
+			if err != nil || parent.wildcard || parent.relation != "" {
+				continue
+			}
+			if _, ok := r.evaluator.relations(parent.objectType)[computedRelation]; ok {
+				computed = append(computed, fgaSdk.Computed{Userset: parent.object() + "#" + computedRelation})
+			}
+		}
+		node.Leaf = &fgaSdk.Leaf{TupleToUserset: &fgaSdk.UsersetTreeTupleToUserset{Tupleset: object + "#" + tupleset, Computed: computed}}
+	case userset.Union != nil:
+		nodes, err := r.expandChildren(objectType, object, relation, userset.Union.Child)
+		if err != nil {
+			return nil, err
+		}
+		node.Union = nodes
+	case userset.Intersection != nil:
+		nodes, err := r.expandChildren(objectType, object, relation, userset.Intersection.Child)
+		if err != nil {
+			return nil, err
+		}
+		node.Intersection = nodes
+	case userset.Difference != nil:
+		base, err := r.expand(objectType, object, relation, userset.Difference.Base)
+		if err != nil {
+			return nil, err
+		}
+		subtract, err := r.expand(objectType, object, relation, userset.Difference.Subtract)
+		if err != nil {
+			return nil, err
+		}
+		node.Difference = &fgaSdk.UsersetTreeDifference{Base: *base, Subtract: *subtract}
+	default:
+		return nil, fmt.Errorf("%w: relation '%s' of type '%s' has an empty rewrite", ErrInvalidInput, relation, objectType)
+	}
+	return node, nil
+}
+
+func (r *resolution) expandChildren(objectType string, object string, relation string, children []fgaSdk.Userset) (*fgaSdk.Nodes, error) {
+	nodes := &fgaSdk.Nodes{Nodes: make([]fgaSdk.Node, 0, len(children))}
+	for _, child := range children {
+		node, err := r.expand(objectType, object, relation, child)
+		if err != nil {
+			return nil, err
+		}
+		nodes.Nodes = append(nodes.Nodes, *node)
+	}
+	return nodes, nil
+}
@@ -0,0 +1,56 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func TestEvaluatorExpand(t *testing.T) {
+	e := newTestEvaluator(t, nil)
+
+	response, err := e.Expand(context.Background(), fgaSdk.ExpandRequest{
+		TupleKey: fgaSdk.ExpandRequestTupleKey{Relation: "viewer", Object: "document:roadmap"},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	name := "document:roadmap#viewer"
+	expected := fgaSdk.ExpandResponse{Tree: &fgaSdk.UsersetTree{Root: &fgaSdk.Node{
+		Name: name,
+		Difference: &fgaSdk.UsersetTreeDifference{
+			Base: fgaSdk.Node{Name: name, Union: &fgaSdk.Nodes{Nodes: []fgaSdk.Node{
+				{Name: name, Leaf: &fgaSdk.Leaf{Users: &fgaSdk.Users{Users: []string{"group:all#member"}}}},
+				{Name: name, Leaf: &fgaSdk.Leaf{Computed: &fgaSdk.Computed{Userset: "document:roadmap#editor"}}},
+				{Name: name, Leaf: &fgaSdk.Leaf{TupleToUserset: &fgaSdk.UsersetTreeTupleToUserset{
+					Tupleset: "document:roadmap#parent",
+					Computed: []fgaSdk.Computed{{Userset: "folder:public#viewer"}},
+				}}},
+			}}},
+			Subtract: fgaSdk.Node{Name: name, Leaf: &fgaSdk.Leaf{Computed: &fgaSdk.Computed{Userset: "document:roadmap#blocked"}}},
+		},
+	}}}
+
+	got, _ := json.Marshal(response)
+	want, _ := json.Marshal(expected)
+	if string(got) != string(want) {
+		t.Fatalf("Expand() =\n%s\nwant\n%s", got, want)
+	}
+
+	response, err = e.Expand(context.Background(), fgaSdk.ExpandRequest{
+		TupleKey: fgaSdk.ExpandRequestTupleKey{Relation: "editor", Object: "document:plan"},
+		ContextualTuples: &fgaSdk.ContextualTupleKeys{TupleKeys: []fgaSdk.TupleKey{
+			{User: "user:frank", Relation: "editor", Object: "document:plan", Condition: &fgaSdk.RelationshipCondition{Name: "ip_allowed"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	users := response.Tree.Root.Leaf.Users.Users
+	if len(users) != 3 || users[0] != "user:frank" {
+		t.Fatalf("Expected contextual and conditional tuples to be expanded, got %v", users)
+	}
+}
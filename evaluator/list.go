@@ -0,0 +1,125 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// ListObjects returns the objects of the given type the user has the relation with, sorted. Candidates are the objects
+// of the type appearing in the stored and contextual tuples. AuthorizationModelId and Consistency are ignored.
+func (e *Evaluator) ListObjects(ctx context.Context, body fgaSdk.ListObjectsRequest) (*fgaSdk.ListObjectsResponse, error) {
+	if err := e.validateRelation(body.Type, body.Relation); err != nil {
+		return nil, err
+	}
+	user, err := parseUser(body.User)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.validateUser(user); err != nil {
+		return nil, err
+	}
+
+	r, err := e.newResolution(ctx, contextualTupleKeys(body.ContextualTuples), body.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]bool)
+	for _, tuple := range r.tuples.all() {
+		if objectType, objectId, err := parseObject(tuple.Object); err == nil && objectType == body.Type {
+			candidates[objectId] = true
+		}
+	}
+
+	objects := []string{}
+	for _, objectId := range sortedKeys(candidates) {
+		allowed, err := r.check(body.Type, objectId, body.Relation, user, 0)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			objects = append(objects, body.Type+":"+objectId)
+		}
+	}
+	return &fgaSdk.ListObjectsResponse{Objects: objects}, nil
+}
+
+// ListUsers returns the users matching the single user filter that have the relation with the object, sorted.
+// Candidates are the objects, wildcards and usersets of the filtered type appearing in the stored and contextual tuples.
+// AuthorizationModelId and Consistency are ignored.
+func (e *Evaluator) ListUsers(ctx context.Context, body fgaSdk.ListUsersRequest) (*fgaSdk.ListUsersResponse, error) {
+	if err := e.validateRelation(body.Object.Type, body.Relation); err != nil {
+		return nil, err
+	}
+	if body.Object.Id == "" {
+		return nil, fmt.Errorf("%w: object id is required", ErrInvalidInput)
+	}
+	if len(body.UserFilters) != 1 {
+		return nil, fmt.Errorf("%w: exactly one user filter is required", ErrInvalidInput)
+	}
+	filter := body.UserFilters[0]
+	if filter.GetRelation() != "" {
+		if err := e.validateRelation(filter.Type, filter.GetRelation()); err != nil {
+			return nil, err
+		}
+	} else if _, ok := e.types[filter.Type]; !ok {
+		return nil, fmt.Errorf("%w: type '%s' is not defined in the model", ErrInvalidInput, filter.Type)
+	}
+
+	var contextualTuples []fgaSdk.TupleKey
+	if body.ContextualTuples != nil {
+		contextualTuples = *body.ContextualTuples
+	}
+	r, err := e.newResolution(ctx, contextualTuples, body.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]userRef)
+	addCandidate := func(candidate userRef) {
+		if candidate.objectType != filter.Type {
+			return
+		}
+		if filter.GetRelation() != "" {
+			if candidate.wildcard {
+				return
+			}
+			candidate.relation = filter.GetRelation()
+		} else if candidate.relation != "" {
+			candidate.relation = ""
+		}
+		candidates[candidate.String()] = candidate
+	}
+	for _, tuple := range r.tuples.all() {
+		if user, err := parseUser(tuple.User); err == nil {
+			addCandidate(user)
+		}
+		if objectType, objectId, err := parseObject(tuple.Object); err == nil {
+			addCandidate(userRef{objectType: objectType, objectId: objectId})
+		}
+	}
+
+	users := []fgaSdk.User{}
+	for _, key := range sortedKeys(candidates) {
+		allowed, err := r.check(body.Object.Type, body.Object.Id, body.Relation, candidates[key], 0)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			users = append(users, candidates[key].toUser())
+		}
+	}
+	return &fgaSdk.ListUsersResponse{Users: users}, nil
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
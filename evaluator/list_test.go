@@ -0,0 +1,112 @@
+package evaluator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func TestEvaluatorListObjects(t *testing.T) {
+	e := newTestEvaluator(t, &EvaluatorOptions{ConditionEvaluator: testConditionEvaluator})
+
+	tests := []struct {
+		user     string
+		relation string
+		objects  []string
+	}{
+		{user: "user:anne", relation: "viewer", objects: []string{"document:roadmap"}},
+		{user: "user:carl", relation: "viewer", objects: []string{}},
+		{user: "user:dave", relation: "viewer", objects: []string{"document:plan", "document:roadmap"}},
+		{user: "user:dave", relation: "can_delete", objects: []string{"document:plan"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.user+" "+test.relation, func(t *testing.T) {
+			response, err := e.ListObjects(context.Background(), fgaSdk.ListObjectsRequest{
+				Type:     "document",
+				Relation: test.relation,
+				User:     test.user,
+			})
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if !reflect.DeepEqual(response.Objects, test.objects) {
+				t.Fatalf("ListObjects() = %v, want %v", response.Objects, test.objects)
+			}
+		})
+	}
+
+	response, err := e.ListObjects(context.Background(), fgaSdk.ListObjectsRequest{
+		Type:             "document",
+		Relation:         "viewer",
+		User:             "user:carl",
+		ContextualTuples: &fgaSdk.ContextualTupleKeys{TupleKeys: []fgaSdk.TupleKey{{User: "user:carl", Relation: "owner", Object: "document:draft"}}},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !reflect.DeepEqual(response.Objects, []string{}) {
+		t.Fatalf("Expected owners not to be viewers, got %v", response.Objects)
+	}
+
+	if _, err := e.ListObjects(context.Background(), fgaSdk.ListObjectsRequest{Type: "report", Relation: "viewer", User: "user:anne"}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestEvaluatorListUsers(t *testing.T) {
+	e := newTestEvaluator(t, &EvaluatorOptions{ConditionEvaluator: testConditionEvaluator})
+
+	response, err := e.ListUsers(context.Background(), fgaSdk.ListUsersRequest{
+		Object:      fgaSdk.FgaObject{Type: "document", Id: "roadmap"},
+		Relation:    "viewer",
+		UserFilters: []fgaSdk.UserTypeFilter{{Type: "user"}},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	expected := []fgaSdk.User{
+		{Wildcard: &fgaSdk.TypedWildcard{Type: "user"}},
+		{Object: &fgaSdk.FgaObject{Type: "user", Id: "anne"}},
+		{Object: &fgaSdk.FgaObject{Type: "user", Id: "dave"}},
+		{Object: &fgaSdk.FgaObject{Type: "user", Id: "erin"}},
+	}
+	if !reflect.DeepEqual(response.Users, expected) {
+		t.Fatalf("ListUsers() = %v, want %v", response.Users, expected)
+	}
+
+	response, err = e.ListUsers(context.Background(), fgaSdk.ListUsersRequest{
+		Object:      fgaSdk.FgaObject{Type: "document", Id: "roadmap"},
+		Relation:    "viewer",
+		UserFilters: []fgaSdk.UserTypeFilter{{Type: "group", Relation: fgaSdk.ToPtr("member")}},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	expected = []fgaSdk.User{
+		{Userset: &fgaSdk.UsersetUser{Type: "group", Id: "all", Relation: "member"}},
+		{Userset: &fgaSdk.UsersetUser{Type: "group", Id: "eng", Relation: "member"}},
+	}
+	if !reflect.DeepEqual(response.Users, expected) {
+		t.Fatalf("ListUsers() = %v, want %v", response.Users, expected)
+	}
+
+	for _, filters := range [][]fgaSdk.UserTypeFilter{
+		nil,
+		{{Type: "user"}, {Type: "group"}},
+		{{Type: "team"}},
+		{{Type: "group", Relation: fgaSdk.ToPtr("admin")}},
+	} {
+		_, err := e.ListUsers(context.Background(), fgaSdk.ListUsersRequest{
+			Object:      fgaSdk.FgaObject{Type: "document", Id: "roadmap"},
+			Relation:    "viewer",
+			UserFilters: filters,
+		})
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("Expected ErrInvalidInput for filters %v, got %v", filters, err)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// userRef is a parsed user of a tuple or a query: an object (user:anne), a wildcard (user:*) or a userset
+// (group:eng#member)
+type userRef struct {
+	objectType string
+	objectId   string
+	relation   string
+	wildcard   bool
+}
+
+func (u userRef) String() string {
+	if u.wildcard {
+		return u.objectType + ":*"
+	}
+	if u.relation != "" {
+		return u.objectType + ":" + u.objectId + "#" + u.relation
+	}
+	return u.objectType + ":" + u.objectId
+}
+
+func (u userRef) object() string {
+	return u.objectType + ":" + u.objectId
+}
+
+func (u userRef) toUser() fgaSdk.User {
+	switch {
+	case u.wildcard:
+		return fgaSdk.User{Wildcard: &fgaSdk.TypedWildcard{Type: u.objectType}}
+	case u.relation != "":
+		return fgaSdk.User{Userset: &fgaSdk.UsersetUser{Type: u.objectType, Id: u.objectId, Relation: u.relation}}
+	}
+	return fgaSdk.User{Object: &fgaSdk.FgaObject{Type: u.objectType, Id: u.objectId}}
+}
+
+func parseObject(object string) (string, string, error) {
+	objectType, objectId, found := strings.Cut(object, ":")
+	if !found || objectType == "" || objectId == "" || objectId == "*" || strings.Contains(objectId, "#") {
+		return "", "", fmt.Errorf("%w: invalid object '%s', expected type:id", ErrInvalidInput, object)
+	}
+	return objectType, objectId, nil
+}
+
+func parseUser(user string) (userRef, error) {
+	object, relation, isUserset := strings.Cut(user, "#")
+	objectType, objectId, found := strings.Cut(object, ":")
+	if !found || objectType == "" || objectId == "" || (isUserset && (relation == "" || objectId == "*")) {
+		return userRef{}, fmt.Errorf("%w: invalid user '%s', expected type:id, type:* or type:id#relation", ErrInvalidInput, user)
+	}
+	if objectId == "*" {
+		return userRef{objectType: objectType, wildcard: true}, nil
+	}
+	return userRef{objectType: objectType, objectId: objectId, relation: relation}, nil
+}
+
+// tupleIndex indexes tuples by object and relation. A request-scoped index holding contextual tuples points to the
+// stored tuples through parent.
+type tupleIndex struct {
+	parent           *tupleIndex
+	tuples           []fgaSdk.TupleKey
+	byObjectRelation map[string][]fgaSdk.TupleKey
+}
+
+func newTupleIndex(parent *tupleIndex, tuples []fgaSdk.TupleKey) *tupleIndex {
+	index := &tupleIndex{parent: parent, byObjectRelation: make(map[string][]fgaSdk.TupleKey)}
+	for _, tuple := range tuples {
+		index.tuples = append(index.tuples, tuple)
+		key := tuple.Object + "#" + tuple.Relation
+		index.byObjectRelation[key] = append(index.byObjectRelation[key], tuple)
+	}
+	return index
+}
+
+// get returns the tuples of an object and relation, contextual tuples first
+func (i *tupleIndex) get(object string, relation string) []fgaSdk.TupleKey {
+	tuples := i.byObjectRelation[object+"#"+relation]
+	if i.parent == nil {
+		return tuples
+	}
+	return append(append([]fgaSdk.TupleKey{}, tuples...), i.parent.get(object, relation)...)
+}
+
+// all returns every tuple of the index, contextual tuples first
+func (i *tupleIndex) all() []fgaSdk.TupleKey {
+	if i.parent == nil {
+		return i.tuples
+	}
+	return append(append([]fgaSdk.TupleKey{}, i.tuples...), i.parent.all()...)
+}
+
+// ValidateTuple checks that a tuple can be written with the model: the object and user are well-formed and of defined
+// types, the relation is defined on the object type, the user type is one of its directly related user types and the
+// condition, if any, is defined in the model.
+func (e *Evaluator) ValidateTuple(tuple fgaSdk.TupleKey) error {
+	objectType, _, err := parseObject(tuple.Object)
+	if err != nil {
+		return err
+	}
+	if err := e.validateRelation(objectType, tuple.Relation); err != nil {
+		return err
+	}
+	user, err := parseUser(tuple.User)
+	if err != nil {
+		return err
+	}
+	if err := e.validateUser(user); err != nil {
+		return err
+	}
+	if tuple.Condition != nil && tuple.Condition.Name != "" {
+		if _, ok := e.conditions[tuple.Condition.Name]; !ok {
+			return fmt.Errorf("%w: condition '%s' is not defined in the model", ErrInvalidInput, tuple.Condition.Name)
+		}
+	}
+	if !e.allowsUser(objectType, tuple.Relation, user, conditionName(tuple)) {
+		return fmt.Errorf("%w: '%s' is not an allowed type restriction for %s#%s", ErrInvalidInput,
+			userTypeRestriction(user, conditionName(tuple)), objectType, tuple.Relation)
+	}
+	return nil
+}
+
+func (e *Evaluator) validateRelation(objectType string, relation string) error {
+	typeDefinition, ok := e.types[objectType]
+	if !ok {
+		return fmt.Errorf("%w: type '%s' is not defined in the model", ErrInvalidInput, objectType)
+	}
+	if _, ok := typeDefinition.GetRelations()[relation]; !ok {
+		return fmt.Errorf("%w: relation '%s' is not defined in type '%s'", ErrInvalidInput, relation, objectType)
+	}
+	return nil
+}
+
+func (e *Evaluator) validateUser(user userRef) error {
+	if user.relation != "" {
+		return e.validateRelation(user.objectType, user.relation)
+	}
+	if _, ok := e.types[user.objectType]; !ok {
+		return fmt.Errorf("%w: type '%s' is not defined in the model", ErrInvalidInput, user.objectType)
+	}
+	return nil
+}
+
+// allowsUser reports whether the directly related user types of a relation accept a user with the given condition.
+// Tuples that are no longer allowed, e.g. after the model changed, are ignored during evaluation like the server does.
+func (e *Evaluator) allowsUser(objectType string, relation string, user userRef, condition string) bool {
+	typeDefinition := e.types[objectType]
+	if typeDefinition.Metadata == nil {
+		return false
+	}
+	metadata, ok := typeDefinition.Metadata.GetRelations()[relation]
+	if !ok {
+		return false
+	}
+	for _, reference := range metadata.GetDirectlyRelatedUserTypes() {
+		if reference.Type == user.objectType && (reference.Wildcard != nil) == user.wildcard &&
+			reference.GetRelation() == user.relation && reference.GetCondition() == condition {
+			return true
+		}
+	}
+	return false
+}
+
+func conditionName(tuple fgaSdk.TupleKey) string {
+	if tuple.Condition == nil {
+		return ""
+	}
+	return tuple.Condition.Name
+}
+
+func userTypeRestriction(user userRef, condition string) string {
+	restriction := user.objectType
+	if user.wildcard {
+		restriction += ":*"
+	} else if user.relation != "" {
+		restriction += "#" + user.relation
+	}
+	if condition != "" {
+		restriction += " with " + condition
+	}
+	return restriction
+}
@@ -0,0 +1,87 @@
+package evaluator
+
+import (
+	"errors"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func TestParseUser(t *testing.T) {
+	tests := []struct {
+		user     string
+		expected userRef
+		valid    bool
+	}{
+		{user: "user:anne", expected: userRef{objectType: "user", objectId: "anne"}, valid: true},
+		{user: "user:*", expected: userRef{objectType: "user", wildcard: true}, valid: true},
+		{user: "group:eng#member", expected: userRef{objectType: "group", objectId: "eng", relation: "member"}, valid: true},
+		{user: "url:https://example.com", expected: userRef{objectType: "url", objectId: "https://example.com"}, valid: true},
+		{user: "anne"},
+		{user: ":anne"},
+		{user: "user:"},
+		{user: "group:eng#"},
+		{user: "group:*#member"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.user, func(t *testing.T) {
+			user, err := parseUser(test.user)
+			if !test.valid {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Fatalf("Expected ErrInvalidInput, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			if user != test.expected || user.String() != test.user {
+				t.Fatalf("parseUser() = %+v (%s), want %+v", user, user, test.expected)
+			}
+		})
+	}
+}
+
+func TestParseObject(t *testing.T) {
+	if objectType, objectId, err := parseObject("document:1"); err != nil || objectType != "document" || objectId != "1" {
+		t.Fatalf("parseObject() = %s, %s, %v", objectType, objectId, err)
+	}
+	for _, object := range []string{"document", "document:", "document:*", "document:1#viewer", ":1"} {
+		if _, _, err := parseObject(object); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("Expected ErrInvalidInput for %q, got %v", object, err)
+		}
+	}
+}
+
+func TestValidateTuple(t *testing.T) {
+	e := newTestEvaluator(t, nil)
+
+	valid := []fgaSdk.TupleKey{
+		{User: "user:*", Relation: "viewer", Object: "folder:1"},
+		{User: "group:eng#member", Relation: "viewer", Object: "document:1"},
+		{User: "user:anne", Relation: "editor", Object: "document:1", Condition: &fgaSdk.RelationshipCondition{Name: "ip_allowed"}},
+	}
+	for _, tuple := range valid {
+		if err := e.ValidateTuple(tuple); err != nil {
+			t.Fatalf("Expected %v to be valid, got %v", tuple, err)
+		}
+	}
+
+	invalid := []fgaSdk.TupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "report:1"},
+		{User: "user:anne", Relation: "approver", Object: "document:1"},
+		{User: "team:a", Relation: "viewer", Object: "document:1"},
+		{User: "group:eng#admin", Relation: "viewer", Object: "document:1"},
+		{User: "user:*", Relation: "viewer", Object: "document:1"},
+		{User: "user:anne", Relation: "editor", Object: "document:1"},
+		{User: "user:anne", Relation: "owner", Object: "document:1", Condition: &fgaSdk.RelationshipCondition{Name: "ip_allowed"}},
+		{User: "user:anne", Relation: "owner", Object: "document:1", Condition: &fgaSdk.RelationshipCondition{Name: "unknown"}},
+		{User: "user:anne", Relation: "can_delete", Object: "document:1"},
+	}
+	for _, tuple := range invalid {
+		if err := e.ValidateTuple(tuple); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("Expected %v to be invalid, got %v", tuple, err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package fgatest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Fault describes a response the server returns instead of, or before, the regular one
+type Fault struct {
+	// Method restricts the fault to requests with this HTTP method, when set
+	Method string
+	// Path restricts the fault to requests whose path ends with it, e.g. "/check" or "/streamed-list-objects"
+	Path string
+	// Times is the number of matching requests the fault applies to. Zero applies it to every matching request.
+	Times int
+
+	// Delay is waited before responding, or until the request is cancelled
+	Delay time.Duration
+	// StatusCode is the status of the response. When both StatusCode and Body are empty, the regular response is
+	// returned once Delay has passed.
+	StatusCode int
+	// RetryAfter is returned in the Retry-After header, rounded up to the second, when set
+	RetryAfter time.Duration
+	// Body is the body of the response, returned as is
+	Body string
+}
+
+// RateLimited returns a fault responding with a 429 and a Retry-After header, once
+func RateLimited(retryAfter time.Duration) Fault {
+	return Fault{
+		Times:      1,
+		StatusCode: http.StatusTooManyRequests,
+		RetryAfter: retryAfter,
+		Body:       `{"code":"rate_limit_exceeded","message":"Rate Limit exceeded"}`,
+	}
+}
+
+// ServerError returns a fault responding with the 5xx status code, once
+func ServerError(statusCode int) Fault {
+	return Fault{
+		Times:      1,
+		StatusCode: statusCode,
+		Body:       fmt.Sprintf(`{"code":"internal_error","message":"%s"}`, http.StatusText(statusCode)),
+	}
+}
+
+// Slow returns a fault delaying the regular response, once
+func Slow(delay time.Duration) Fault {
+	return Fault{Times: 1, Delay: delay}
+}
+
+// MalformedBody returns a fault responding with a 200 and a body which is not valid JSON, once
+func MalformedBody() Fault {
+	return Fault{Times: 1, StatusCode: http.StatusOK, Body: `{"allowed":tru`}
+}
@@ -0,0 +1,182 @@
+// Package fgatest provides an OpenFGA HTTP server for integration tests, backed by an in-memory store.
+//
+// NewTestServer serves the REST API used by OpenFgaApi, so code using APIClient or OpenFgaClient can be tested end to
+// end without a running OpenFGA instance. Faults can be injected to exercise the retry and error handling of the SDK:
+//
+//	server := fgatest.NewTestServer(t)
+//	server.Inject(fgatest.RateLimited(time.Second))
+//
+//	configuration, err := openfga.NewConfiguration(openfga.Configuration{ApiUrl: server.URL})
+//	apiClient := openfga.NewAPIClient(configuration)
+package fgatest
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openfga/go-sdk/evaluator"
+	"github.com/openfga/go-sdk/internal/constants"
+	"github.com/openfga/go-sdk/internal/memstore"
+)
+
+// TestServerOptions configures a TestServer
+type TestServerOptions struct {
+	// ConditionEvaluator evaluates the conditions of conditional tuples. When nil, queries reaching a conditional tuple
+	// fail with a validation error.
+	ConditionEvaluator evaluator.ConditionEvaluator
+	// Now returns the time used for store, model and change timestamps. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Request is a request received by a TestServer
+type Request struct {
+	Method string
+	Path   string
+}
+
+// TestServer is an httptest.Server implementing the OpenFGA REST API in memory
+type TestServer struct {
+	*httptest.Server
+
+	handler  http.Handler
+	mu       sync.Mutex
+	faults   []*activeFault
+	requests []Request
+}
+
+type activeFault struct {
+	fault Fault
+	// remaining is the number of requests the fault still applies to, negative when unlimited
+	remaining int
+}
+
+// NewTestServer starts an empty TestServer, closed when the test ends
+func NewTestServer(t testing.TB) *TestServer {
+	return NewTestServerWithOptions(t, nil)
+}
+
+// NewTestServerWithOptions starts an empty TestServer configured by options, closed when the test ends. options may be
+// nil.
+func NewTestServerWithOptions(t testing.TB, options *TestServerOptions) *TestServer {
+	t.Helper()
+
+	if options == nil {
+		options = &TestServerOptions{}
+	}
+	s := &TestServer{
+		handler: memstore.NewHandler(memstore.NewServer(&memstore.Options{
+			ConditionEvaluator: options.ConditionEvaluator,
+			Now:                options.Now,
+		})),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Inject adds a fault to the responses of the server. Faults apply in the order they were injected, and a request is
+// affected by at most one of them.
+func (s *TestServer) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remaining := fault.Times
+	if remaining <= 0 {
+		remaining = -1
+	}
+	s.faults = append(s.faults, &activeFault{fault: fault, remaining: remaining})
+}
+
+// ClearFaults removes the faults which have not been exhausted yet
+func (s *TestServer) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests returns the requests received by the server, including those answered by a fault
+func (s *TestServer) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+func (s *TestServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	fault := s.record(r)
+	if fault == nil {
+		s.handler.ServeHTTP(w, r)
+		return
+	}
+
+	if fault.Delay > 0 {
+		// the server only notices a cancelled request once its body is read, so it is buffered for the handler
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		timer := time.NewTimer(fault.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if fault.StatusCode == 0 && fault.Body == "" {
+		s.handler.ServeHTTP(w, r)
+		return
+	}
+
+	statusCode := fault.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	if fault.RetryAfter > 0 {
+		w.Header().Set(constants.RetryAfterHeaderName, strconv.Itoa(int(math.Ceil(fault.RetryAfter.Seconds()))))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write([]byte(fault.Body))
+}
+
+// record stores the request and returns the fault it is affected by, if any
+func (s *TestServer) record(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+	for index, active := range s.faults {
+		if !active.fault.matches(r) {
+			continue
+		}
+		if active.remaining > 0 {
+			active.remaining--
+			if active.remaining == 0 {
+				s.faults = append(s.faults[:index], s.faults[index+1:]...)
+			}
+		}
+		fault := active.fault
+		return &fault
+	}
+	return nil
+}
+
+func (f Fault) matches(r *http.Request) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+	return f.Path == "" || strings.HasSuffix(r.URL.Path, f.Path)
+}
@@ -0,0 +1,192 @@
+package fgatest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/fgatest"
+	"github.com/openfga/go-sdk/language"
+)
+
+const testModelDSL = `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`
+
+func newAPIClient(t *testing.T, server *fgatest.TestServer, retryParams *fgaSdk.RetryParams, httpClient *http.Client) *fgaSdk.APIClient {
+	t.Helper()
+
+	configuration, err := fgaSdk.NewConfiguration(fgaSdk.Configuration{
+		ApiUrl:      server.URL,
+		RetryParams: retryParams,
+		HTTPClient:  httpClient,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return fgaSdk.NewAPIClient(configuration)
+}
+
+// setupStore creates a store with the test model and a single tuple, returning the id of the store
+func setupStore(t *testing.T, apiClient *fgaSdk.APIClient) string {
+	t.Helper()
+	ctx := context.Background()
+
+	store, _, err := apiClient.OpenFgaApi.CreateStore(ctx).Body(fgaSdk.CreateStoreRequest{Name: "test"}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	model, err := language.TransformDSLToModel(testModelDSL)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, _, err := apiClient.OpenFgaApi.WriteAuthorizationModel(ctx, store.Id).Body(*model).Execute(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, _, err = apiClient.OpenFgaApi.Write(ctx, store.Id).Body(fgaSdk.WriteRequest{
+		Writes: &fgaSdk.WriteRequestWrites{TupleKeys: []fgaSdk.TupleKey{{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}},
+	}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return store.Id
+}
+
+func check(apiClient *fgaSdk.APIClient, storeId string) (fgaSdk.CheckResponse, error) {
+	response, _, err := apiClient.OpenFgaApi.Check(context.Background(), storeId).Body(fgaSdk.CheckRequest{
+		TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"},
+	}).Execute()
+	return response, err
+}
+
+func TestTestServer(t *testing.T) {
+	server := fgatest.NewTestServer(t)
+	apiClient := newAPIClient(t, server, nil, nil)
+	storeId := setupStore(t, apiClient)
+	ctx := context.Background()
+
+	response, err := check(apiClient, storeId)
+	if err != nil || !response.GetAllowed() {
+		t.Fatalf("Check() = %+v, %v", response, err)
+	}
+
+	channel, err := fgaSdk.ExecuteStreamedListObjects(apiClient, ctx, storeId, fgaSdk.ListObjectsRequest{
+		Type: "document", Relation: "viewer", User: "user:anne",
+	}, fgaSdk.RequestOptions{})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer channel.Close()
+	var objects []string
+	for object := range channel.Objects {
+		objects = append(objects, object.Object)
+	}
+	if len(objects) != 1 || objects[0] != "document:roadmap" {
+		t.Fatalf("Unexpected streamed objects %v", objects)
+	}
+
+	_, _, err = apiClient.OpenFgaApi.GetStore(ctx, "01ARZ3NDEKTSV4RRFFQ69G5FAV").Execute()
+	var notFound fgaSdk.FgaApiNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected FgaApiNotFoundError, got %v", err)
+	}
+}
+
+func TestTestServerRateLimited(t *testing.T) {
+	server := fgatest.NewTestServer(t)
+	apiClient := newAPIClient(t, server, &fgaSdk.RetryParams{MaxRetry: 1, MinWaitInMs: 10}, nil)
+	storeId := setupStore(t, apiClient)
+
+	fault := fgatest.RateLimited(time.Second)
+	fault.Path = "/check"
+	server.Inject(fault)
+
+	start := time.Now()
+	response, err := check(apiClient, storeId)
+	if err != nil || !response.GetAllowed() {
+		t.Fatalf("Expected the check to be retried, got %+v, %v", response, err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("Expected the retry to wait for the Retry-After header, waited %v", elapsed)
+	}
+	if checks := countRequests(server, "/check"); checks != 2 {
+		t.Fatalf("Expected 2 check requests, got %d", checks)
+	}
+
+	apiClient = newAPIClient(t, server, &fgaSdk.RetryParams{MaxRetry: 0, MinWaitInMs: 10}, nil)
+	server.Inject(fault)
+	_, err = check(apiClient, storeId)
+	var rateLimitErr fgaSdk.FgaApiRateLimitExceededError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.ResponseHeader().Get("Retry-After") != "1" {
+		t.Fatalf("Expected FgaApiRateLimitExceededError with a Retry-After header, got %v", err)
+	}
+}
+
+func TestTestServerServerError(t *testing.T) {
+	server := fgatest.NewTestServer(t)
+	apiClient := newAPIClient(t, server, &fgaSdk.RetryParams{MaxRetry: 2, MinWaitInMs: 1}, nil)
+	storeId := setupStore(t, apiClient)
+
+	fault := fgatest.ServerError(http.StatusInternalServerError)
+	fault.Times = 2
+	server.Inject(fault)
+	response, err := check(apiClient, storeId)
+	if err != nil || !response.GetAllowed() {
+		t.Fatalf("Expected the check to succeed on the third attempt, got %+v, %v", response, err)
+	}
+	if checks := countRequests(server, "/check"); checks != 3 {
+		t.Fatalf("Expected 3 check requests, got %d", checks)
+	}
+
+	fault.Times = 3
+	server.Inject(fault)
+	_, err = check(apiClient, storeId)
+	var internalErr fgaSdk.FgaApiInternalError
+	if !errors.As(err, &internalErr) || internalErr.ResponseCode() != fgaSdk.INTERNALERRORCODE_INTERNAL_ERROR {
+		t.Fatalf("Expected FgaApiInternalError once the retries are exhausted, got %v", err)
+	}
+}
+
+func TestTestServerSlowAndMalformed(t *testing.T) {
+	server := fgatest.NewTestServer(t)
+	storeId := setupStore(t, newAPIClient(t, server, nil, nil))
+
+	apiClient := newAPIClient(t, server, &fgaSdk.RetryParams{MaxRetry: 0, MinWaitInMs: 1}, &http.Client{Timeout: 50 * time.Millisecond})
+	server.Inject(fgatest.Slow(200 * time.Millisecond))
+	if _, err := check(apiClient, storeId); err == nil {
+		t.Fatalf("Expected the slow response to time out")
+	}
+	if response, err := check(apiClient, storeId); err != nil || !response.GetAllowed() {
+		t.Fatalf("Expected the fault to apply once, got %+v, %v", response, err)
+	}
+
+	server.Inject(fgatest.MalformedBody())
+	if _, err := check(apiClient, storeId); err == nil {
+		t.Fatalf("Expected a malformed body to fail")
+	}
+
+	server.Inject(fgatest.Fault{Path: "/write", StatusCode: http.StatusBadGateway})
+	server.ClearFaults()
+	if response, err := check(apiClient, storeId); err != nil || !response.GetAllowed() {
+		t.Fatalf("Expected no fault after ClearFaults, got %+v, %v", response, err)
+	}
+}
+
+func countRequests(server *fgatest.TestServer, path string) int {
+	count := 0
+	for _, request := range server.Requests() {
+		if len(request.Path) >= len(path) && request.Path[len(request.Path)-len(path):] == path {
+			count++
+		}
+	}
+	return count
+}
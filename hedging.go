@@ -0,0 +1,269 @@
+package openfga
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openfga/go-sdk/internal/constants"
+	"github.com/openfga/go-sdk/telemetry"
+)
+
+// HedgingPolicy configures when a second request is sent while the first one has not received a response yet.
+// The first final response wins, and the other request is cancelled.
+type HedgingPolicy struct {
+	// DelayInMs is the time to wait for a response before sending the second request
+	DelayInMs int `json:"delay_in_ms,omitempty"`
+	// Percentile, between 0 and 100 (excluded), waits for that percentile of the recent request durations of the
+	// method instead, e.g. 95 sends the second request when the first one is slower than 95% of the recent ones.
+	// DelayInMs applies until enough durations are observed, and no second request is sent before then without it.
+	Percentile float64 `json:"percentile,omitempty"`
+}
+
+// Validate ensures that the hedging policy is valid
+func (p *HedgingPolicy) Validate(operationName string) error {
+	if p == nil {
+		return nil
+	}
+
+	if p.DelayInMs < 0 {
+		return reportError("Hedging.%s.DelayInMs cannot be negative", operationName)
+	}
+
+	if p.Percentile < 0 || p.Percentile >= 100 {
+		return reportError("Hedging.%s.Percentile must be between 0 and 100", operationName)
+	}
+
+	if p.DelayInMs == 0 && p.Percentile == 0 {
+		return reportError("Hedging.%s requires DelayInMs or Percentile", operationName)
+	}
+
+	return nil
+}
+
+// HedgingConfiguration holds the hedging policies of the idempotent read operations. An operation without a policy is
+// never hedged.
+type HedgingConfiguration struct {
+	Check       *HedgingPolicy `json:"check,omitempty"`
+	BatchCheck  *HedgingPolicy `json:"batch_check,omitempty"`
+	ListObjects *HedgingPolicy `json:"list_objects,omitempty"`
+	ListUsers   *HedgingPolicy `json:"list_users,omitempty"`
+	Expand      *HedgingPolicy `json:"expand,omitempty"`
+	Read        *HedgingPolicy `json:"read,omitempty"`
+}
+
+// policies returns the hedging policies by operation name
+func (c *HedgingConfiguration) policies() map[string]*HedgingPolicy {
+	return map[string]*HedgingPolicy{
+		"Check":       c.Check,
+		"BatchCheck":  c.BatchCheck,
+		"ListObjects": c.ListObjects,
+		"ListUsers":   c.ListUsers,
+		"Expand":      c.Expand,
+		"Read":        c.Read,
+	}
+}
+
+// Validate ensures that the hedging configuration is valid
+func (c *HedgingConfiguration) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	for operationName, policy := range c.policies() {
+		if err := policy.Validate(operationName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// List of the requests which can win a hedged request
+const (
+	hedgeWinnerPrimary = "primary"
+	hedgeWinnerHedge   = "hedge"
+	hedgeWinnerNone    = "none"
+)
+
+// hedging holds the hedgers of the operations of an API client
+type hedging struct {
+	host    string
+	hedgers map[string]*hedger
+}
+
+func newHedging(cfg *Configuration) *hedging {
+	if cfg.Hedging == nil {
+		return nil
+	}
+
+	host := ""
+	if apiUrl, err := url.Parse(cfg.ApiUrl); err == nil {
+		host = apiUrl.Host
+	}
+
+	hedgers := map[string]*hedger{}
+	for operationName, policy := range cfg.Hedging.policies() {
+		if policy != nil {
+			hedgers[operationName] = &hedger{policy: *policy}
+		}
+	}
+
+	return &hedging{
+		host:    host,
+		hedgers: hedgers,
+	}
+}
+
+// get returns the hedger of an operation, or nil when the operation is not hedged
+func (h *hedging) get(operationName string) *hedger {
+	if h == nil {
+		return nil
+	}
+
+	return h.hedgers[operationName]
+}
+
+// hedger decides when to hedge the requests of an operation
+type hedger struct {
+	policy HedgingPolicy
+
+	lock sync.Mutex
+	// durations is a ring buffer of the recent request durations, only kept for a percentile
+	durations []time.Duration
+	next      int
+}
+
+// observe records the duration of a request which received a final response
+func (h *hedger) observe(duration time.Duration) {
+	if h.policy.Percentile == 0 {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if len(h.durations) < constants.HedgingLatencySamples {
+		h.durations = append(h.durations, duration)
+		return
+	}
+	h.durations[h.next] = duration
+	h.next = (h.next + 1) % constants.HedgingLatencySamples
+}
+
+// delay returns the time to wait before hedging a request, or false when the request is not hedged
+func (h *hedger) delay() (time.Duration, bool) {
+	if h.policy.Percentile > 0 {
+		h.lock.Lock()
+		durations := append([]time.Duration(nil), h.durations...)
+		h.lock.Unlock()
+
+		if len(durations) >= constants.HedgingMinLatencySamples {
+			sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+			index := int(math.Ceil(h.policy.Percentile/100*float64(len(durations)))) - 1
+			return durations[max(index, 0)], true
+		}
+	}
+
+	if h.policy.DelayInMs > 0 {
+		return time.Duration(h.policy.DelayInMs) * time.Millisecond, true
+	}
+
+	return 0, false
+}
+
+// hedgedResponse is the response of one of the requests of a hedged request
+type hedgedResponse struct {
+	apiResponse
+	hedge    bool
+	duration time.Duration
+}
+
+// sendHedged sends a request once, and sends it a second time when its operation is hedged and no response was
+// received within the delay of its policy. It returns the first final response, cancelling the other request, or the
+// response of the first request when neither is final.
+func (c *APIClient) sendHedged(r apiRequest) apiResponse {
+	h := c.hedging.get(r.operationName)
+	if h == nil {
+		return c.send(r.ctx, r)
+	}
+
+	primaryCtx, cancelPrimary := context.WithCancel(r.ctx)
+	defer cancelPrimary()
+	hedgeCtx, cancelHedge := context.WithCancel(r.ctx)
+	defer cancelHedge()
+
+	// both requests can send their response without waiting for it to be received
+	responses := make(chan hedgedResponse, 2)
+	send := func(ctx context.Context, hedge bool) {
+		started := time.Now()
+		response := c.send(ctx, r)
+		responses <- hedgedResponse{apiResponse: response, hedge: hedge, duration: time.Since(started)}
+	}
+	go send(primaryCtx, false)
+
+	var hedgeTimer <-chan time.Time
+	if delay, ok := h.delay(); ok {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		hedgeTimer = timer.C
+	}
+
+	pending, hedged := 1, false
+	var failed *hedgedResponse
+	for {
+		select {
+		case <-hedgeTimer:
+			hedgeTimer = nil
+			hedged = true
+			pending++
+			go send(hedgeCtx, true)
+		case response := <-responses:
+			pending--
+			if response.final() {
+				h.observe(response.duration)
+				winner := hedgeWinnerPrimary
+				if response.hedge {
+					winner = hedgeWinnerHedge
+					cancelPrimary()
+				} else {
+					cancelHedge()
+				}
+				if hedged {
+					c.recordHedgedRequest(r, winner)
+				}
+				return response.apiResponse
+			}
+
+			// the failure of the first request is returned, as it would have been without hedging
+			if failed == nil || !response.hedge {
+				failed = &response
+			}
+			if pending == 0 {
+				if hedged {
+					c.recordHedgedRequest(r, hedgeWinnerNone)
+				}
+				return failed.apiResponse
+			}
+		}
+	}
+}
+
+// recordHedgedRequest records which request won a hedged request
+func (c *APIClient) recordHedgedRequest(r apiRequest, winner string) {
+	attrs := map[*telemetry.Attribute]string{
+		telemetry.FGAClientRequestMethod:      r.operationName,
+		telemetry.FGAClientRequestHedgeWinner: winner,
+	}
+	if r.storeId != "" {
+		attrs[telemetry.FGAClientRequestStoreID] = r.storeId
+	}
+	if c.hedging.host != "" {
+		attrs[telemetry.HTTPHost] = c.hedging.host
+	}
+
+	_, _ = c.metrics().RequestHedged(1, attrs)
+}
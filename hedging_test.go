@@ -0,0 +1,169 @@
+package openfga
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openfga/go-sdk/internal/constants"
+)
+
+func TestHedgingConfigurationValidate(t *testing.T) {
+	invalid := map[string]HedgingConfiguration{
+		"no delay":            {Check: &HedgingPolicy{}},
+		"negative delay":      {Read: &HedgingPolicy{DelayInMs: -1}},
+		"percentile of 100":   {ListObjects: &HedgingPolicy{Percentile: 100}},
+		"negative percentile": {Expand: &HedgingPolicy{Percentile: -5, DelayInMs: 10}},
+	}
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080", Hedging: &config})
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+		})
+	}
+
+	cfg, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080", Hedging: &HedgingConfiguration{
+		Check:     &HedgingPolicy{Percentile: 95, DelayInMs: 50},
+		ListUsers: &HedgingPolicy{DelayInMs: 100},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.Hedging == nil || cfg.Hedging.Check.Percentile != 95 {
+		t.Fatalf("Expected the hedging configuration to be kept, got %+v", cfg.Hedging)
+	}
+	if newHedging(cfg).get("Write") != nil {
+		t.Fatalf("Expected an operation without a policy not to be hedged")
+	}
+}
+
+func TestHedgerDelay(t *testing.T) {
+	fixed := &hedger{policy: HedgingPolicy{DelayInMs: 30}}
+	if delay, ok := fixed.delay(); !ok || delay != 30*time.Millisecond {
+		t.Fatalf("Expected a delay of 30ms, got %v", delay)
+	}
+
+	percentile := &hedger{policy: HedgingPolicy{Percentile: 90}}
+	if _, ok := percentile.delay(); ok {
+		t.Fatalf("Expected no hedging before enough durations are observed")
+	}
+
+	fallback := &hedger{policy: HedgingPolicy{Percentile: 90, DelayInMs: 200}}
+	for i := 1; i <= constants.HedgingLatencySamples+50; i++ {
+		// the oldest durations are replaced once the buffer is full
+		duration := time.Second
+		if i > 50 {
+			duration = time.Duration(i-50) * time.Millisecond
+		}
+		percentile.observe(duration)
+		fallback.observe(duration)
+		if i == constants.HedgingMinLatencySamples-1 {
+			if delay, _ := fallback.delay(); delay != 200*time.Millisecond {
+				t.Fatalf("Expected the fixed delay until enough durations are observed, got %v", delay)
+			}
+		}
+	}
+	if delay, ok := percentile.delay(); !ok || delay != 90*time.Millisecond {
+		t.Fatalf("Expected the 90th percentile of the recent durations, got %v", delay)
+	}
+}
+
+// withHedging configures the hedging of a test client, which does not retry the failed requests
+func withHedging(hedging *HedgingConfiguration) func(*Configuration) {
+	return func(cfg *Configuration) {
+		cfg.RetryParams.MaxRetry = 0
+		cfg.Hedging = hedging
+	}
+}
+
+func checkWith(apiClient *APIClient) (CheckResponse, error) {
+	response, _, err := apiClient.OpenFgaApi.Check(context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2").
+		Body(CheckRequest{TupleKey: CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}).
+		Execute()
+	return response, err
+}
+
+func TestHedgingSlowPrimary(t *testing.T) {
+	var requests int32
+	cancelled := make(chan struct{})
+	apiClient := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// the server only notices the client going away once the request body is read
+			_, _ = io.Copy(io.Discard, r.Body)
+			select {
+			case <-r.Context().Done():
+				close(cancelled)
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}, withHedging(&HedgingConfiguration{Check: &HedgingPolicy{DelayInMs: 20}}))
+
+	started := time.Now()
+	response, err := checkWith(apiClient)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !response.GetAllowed() {
+		t.Fatalf("Expected the response of the hedged request")
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Expected the hedged request to answer before the slow one, took %v", elapsed)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the slow request to be cancelled")
+	}
+}
+
+func TestHedgingFastPrimary(t *testing.T) {
+	var requests int32
+	apiClient := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}, withHedging(&HedgingConfiguration{Check: &HedgingPolicy{DelayInMs: 500}}))
+
+	if _, err := checkWith(apiClient); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Fatalf("Expected a fast request not to be hedged, got %d requests", got)
+	}
+}
+
+func TestHedgingFailedPrimary(t *testing.T) {
+	var requests int32
+	apiClient := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// the first request fails after the hedged request was sent, which still answers
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code":"internal_error","message":"unavailable"}`))
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}, withHedging(&HedgingConfiguration{Check: &HedgingPolicy{DelayInMs: 10}}))
+
+	response, err := checkWith(apiClient)
+	if err != nil {
+		t.Fatalf("Expected the hedged request to answer, got %v", err)
+	}
+	if !response.GetAllowed() {
+		t.Fatalf("Expected the response of the hedged request")
+	}
+}
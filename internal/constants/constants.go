@@ -65,12 +65,64 @@ const (
 	// ClientMaxBatchSize is the maximum batch size for batch requests.
 	ClientMaxBatchSize = 50
 
+	// ClientMaxTuplesPerWrite is the maximum number of writes and deletes the server accepts in a single Write request.
+	ClientMaxTuplesPerWrite = 100
+
 	// ClientMethodHeader is the header used to identify the client method.
 	ClientMethodHeader = "X-OpenFGA-Client-Method"
 
 	// ClientBulkRequestIdHeader is the header used to identify bulk requests.
 	ClientBulkRequestIdHeader = "X-OpenFGA-Client-Bulk-Request-Id"
 
+	// Check cache
+
+	// DefaultCheckCacheMaxEntries is the default maximum number of results kept in the client-side check cache.
+	DefaultCheckCacheMaxEntries = 10000
+
+	// DefaultCheckCacheTTLInMs is the default time-to-live of a result in the client-side check cache in milliseconds.
+	DefaultCheckCacheTTLInMs = 10000
+
+	// Circuit breaker
+
+	// DefaultCircuitBreakerConsecutiveFailures is the default number of failures in a row opening a circuit.
+	DefaultCircuitBreakerConsecutiveFailures = 5
+
+	// DefaultCircuitBreakerMinRequests is the default number of requests of the window before the failure ratio applies.
+	DefaultCircuitBreakerMinRequests = 10
+
+	// DefaultCircuitBreakerWindowInMs is the default window over which the failure ratio is computed in milliseconds.
+	DefaultCircuitBreakerWindowInMs = 10000
+
+	// DefaultCircuitBreakerOpenDurationInMs is the default time an open circuit rejects requests in milliseconds.
+	DefaultCircuitBreakerOpenDurationInMs = 30000
+
+	// DefaultCircuitBreakerHalfOpenProbes is the default number of requests let through a half-open circuit.
+	DefaultCircuitBreakerHalfOpenProbes = 1
+
+	// Rate limiter
+
+	// DefaultRateLimiterBurst is the default number of requests the rate limiter lets through at once.
+	DefaultRateLimiterBurst = 1
+
+	// DefaultRateLimiterMinInFlightRequests is the default lowest in-flight limit of the adaptive rate limiter.
+	DefaultRateLimiterMinInFlightRequests = 1
+
+	// Retry budget
+
+	// DefaultRetryBudgetMaxTokens is the default size of the retry budget, i.e. the number of retries allowed in a row.
+	DefaultRetryBudgetMaxTokens = 10
+
+	// DefaultRetryBudgetTokensPerRequest is the default number of tokens a request adds to the retry budget.
+	DefaultRetryBudgetTokensPerRequest = 0.1
+
+	// Hedging
+
+	// HedgingLatencySamples is the number of recent request durations a latency percentile of a hedging policy is computed over.
+	HedgingLatencySamples = 100
+
+	// HedgingMinLatencySamples is the number of request durations needed before a latency percentile of a hedging policy applies.
+	HedgingMinLatencySamples = 20
+
 	// Connection options
 
 	// DefaultRequestTimeoutInMs is the default timeout for HTTP requests in milliseconds.
@@ -0,0 +1,41 @@
+package memstore
+
+import (
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// WriteAssertions replaces the assertions of a model
+func (s *Server) WriteAssertions(storeId string, modelId string, body fgaSdk.WriteAssertionsRequest) error {
+	if len(body.Assertions) > maxAssertions {
+		return validationError(fgaSdk.ERRORCODE_ASSERTIONS_TOO_MANY_ITEMS, "a model accepts at most %d assertions", maxAssertions)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return err
+	}
+	if _, err := st.model(modelId); err != nil {
+		return err
+	}
+	st.assertions[modelId] = append([]fgaSdk.Assertion{}, body.Assertions...)
+	return nil
+}
+
+// ReadAssertions returns the assertions of a model, an empty list when none were written
+func (s *Server) ReadAssertions(storeId string, modelId string) (*fgaSdk.ReadAssertionsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := st.model(modelId); err != nil {
+		return nil, err
+	}
+	assertions := append([]fgaSdk.Assertion{}, st.assertions[modelId]...)
+	return &fgaSdk.ReadAssertionsResponse{AuthorizationModelId: modelId, Assertions: &assertions}, nil
+}
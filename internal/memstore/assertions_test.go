@@ -0,0 +1,31 @@
+package memstore
+
+import (
+	"net/http"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func TestServerAssertions(t *testing.T) {
+	s, storeId, modelId := newTestServer(t)
+
+	response, err := s.ReadAssertions(storeId, modelId)
+	if err != nil || response.Assertions == nil || len(*response.Assertions) != 0 {
+		t.Fatalf("Expected an empty list of assertions, got %+v, %v", response, err)
+	}
+
+	assertion := fgaSdk.Assertion{TupleKey: fgaSdk.AssertionTupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}, Expectation: true}
+	if err := s.WriteAssertions(storeId, modelId, fgaSdk.WriteAssertionsRequest{Assertions: []fgaSdk.Assertion{assertion}}); err != nil {
+		t.Fatalf("%v", err)
+	}
+	response, err = s.ReadAssertions(storeId, modelId)
+	if err != nil || len(*response.Assertions) != 1 || response.AuthorizationModelId != modelId {
+		t.Fatalf("ReadAssertions() = %+v, %v", response, err)
+	}
+
+	err = s.WriteAssertions(storeId, modelId, fgaSdk.WriteAssertionsRequest{Assertions: make([]fgaSdk.Assertion, maxAssertions+1)})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_ASSERTIONS_TOO_MANY_ITEMS))
+	err = s.WriteAssertions(storeId, "01ARZ3NDEKTSV4RRFFQ69G5FAV", fgaSdk.WriteAssertionsRequest{})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_AUTHORIZATION_MODEL_NOT_FOUND))
+}
@@ -0,0 +1,206 @@
+package memstore
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// NewHandler returns an http.Handler serving the OpenFGA REST API from the server. Errors are returned with the
+// status codes and bodies of an OpenFGA server, so the SDK maps them to the same error types.
+func NewHandler(s *Server) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /stores", func(w http.ResponseWriter, r *http.Request) {
+		pageSize, err := pageSizeParam(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		query := r.URL.Query()
+		respond(w, http.StatusOK)(s.ListStores(pageSize, query.Get("continuation_token"), query.Get("name")))
+	})
+	mux.HandleFunc("POST /stores", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.CreateStoreRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusCreated)(s.CreateStore(body))
+		}
+	})
+	mux.HandleFunc("GET /stores/{store_id}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusOK)(s.GetStore(r.PathValue("store_id")))
+	})
+	mux.HandleFunc("DELETE /stores/{store_id}", func(w http.ResponseWriter, r *http.Request) {
+		respondNoContent(w, s.DeleteStore(r.PathValue("store_id")))
+	})
+
+	mux.HandleFunc("GET /stores/{store_id}/authorization-models", func(w http.ResponseWriter, r *http.Request) {
+		pageSize, err := pageSizeParam(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		respond(w, http.StatusOK)(s.ReadAuthorizationModels(r.PathValue("store_id"), pageSize, r.URL.Query().Get("continuation_token")))
+	})
+	mux.HandleFunc("POST /stores/{store_id}/authorization-models", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.WriteAuthorizationModelRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusCreated)(s.WriteAuthorizationModel(r.PathValue("store_id"), body))
+		}
+	})
+	mux.HandleFunc("GET /stores/{store_id}/authorization-models/{id}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusOK)(s.ReadAuthorizationModel(r.PathValue("store_id"), r.PathValue("id")))
+	})
+
+	mux.HandleFunc("GET /stores/{store_id}/changes", func(w http.ResponseWriter, r *http.Request) {
+		pageSize, err := pageSizeParam(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		query := r.URL.Query()
+		var startTime time.Time
+		if value := query.Get("start_time"); value != "" {
+			if startTime, err = time.Parse(time.RFC3339Nano, value); err != nil {
+				writeError(w, validationError(fgaSdk.ERRORCODE_INVALID_START_TIME, "invalid start time '%s'", value))
+				return
+			}
+		}
+		respond(w, http.StatusOK)(s.ReadChanges(r.PathValue("store_id"), query.Get("type"), pageSize, query.Get("continuation_token"), startTime))
+	})
+	mux.HandleFunc("POST /stores/{store_id}/read", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.ReadRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusOK)(s.Read(r.PathValue("store_id"), body))
+		}
+	})
+	mux.HandleFunc("POST /stores/{store_id}/write", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.WriteRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusOK)(struct{}{}, s.Write(r.PathValue("store_id"), body))
+		}
+	})
+
+	mux.HandleFunc("POST /stores/{store_id}/check", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.CheckRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusOK)(s.Check(r.Context(), r.PathValue("store_id"), body))
+		}
+	})
+	mux.HandleFunc("POST /stores/{store_id}/batch-check", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.BatchCheckRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusOK)(s.BatchCheck(r.Context(), r.PathValue("store_id"), body))
+		}
+	})
+	mux.HandleFunc("POST /stores/{store_id}/expand", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.ExpandRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusOK)(s.Expand(r.Context(), r.PathValue("store_id"), body))
+		}
+	})
+	mux.HandleFunc("POST /stores/{store_id}/list-objects", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.ListObjectsRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusOK)(s.ListObjects(r.Context(), r.PathValue("store_id"), body))
+		}
+	})
+	mux.HandleFunc("POST /stores/{store_id}/streamed-list-objects", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.ListObjectsRequest
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		response, err := s.ListObjects(r.Context(), r.PathValue("store_id"), body)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		encoder := json.NewEncoder(w)
+		for _, object := range response.Objects {
+			_ = encoder.Encode(fgaSdk.StreamResultOfStreamedListObjectsResponse{
+				Result: &fgaSdk.StreamedListObjectsResponse{Object: object},
+			})
+		}
+	})
+	mux.HandleFunc("POST /stores/{store_id}/list-users", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.ListUsersRequest
+		if decodeBody(w, r, &body) {
+			respond(w, http.StatusOK)(s.ListUsers(r.Context(), r.PathValue("store_id"), body))
+		}
+	})
+
+	mux.HandleFunc("GET /stores/{store_id}/assertions/{authorization_model_id}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusOK)(s.ReadAssertions(r.PathValue("store_id"), r.PathValue("authorization_model_id")))
+	})
+	mux.HandleFunc("PUT /stores/{store_id}/assertions/{authorization_model_id}", func(w http.ResponseWriter, r *http.Request) {
+		var body fgaSdk.WriteAssertionsRequest
+		if decodeBody(w, r, &body) {
+			respondNoContent(w, s.WriteAssertions(r.PathValue("store_id"), r.PathValue("authorization_model_id"), body))
+		}
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &Error{StatusCode: http.StatusNotFound, Code: "undefined_endpoint", Message: "unknown endpoint " + r.Method + " " + r.URL.Path})
+	})
+
+	return mux
+}
+
+// respond returns a function writing either the response or the error, so handlers can pass the results of a Server
+// method straight through
+func respond(w http.ResponseWriter, statusCode int) func(response interface{}, err error) {
+	return func(response interface{}, err error) {
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, statusCode, response)
+	}
+}
+
+func respondNoContent(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, validationError(fgaSdk.ERRORCODE_VALIDATION_ERROR, "invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func pageSizeParam(r *http.Request) (*int32, error) {
+	value := r.URL.Query().Get("page_size")
+	if value == "" {
+		return nil, nil
+	}
+	pageSize, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, validationError(fgaSdk.ERRORCODE_PAGE_SIZE_INVALID, "invalid page size '%s'", value)
+	}
+	return fgaSdk.ToPtr(int32(pageSize)), nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = &Error{StatusCode: http.StatusInternalServerError, Code: string(fgaSdk.INTERNALERRORCODE_INTERNAL_ERROR), Message: err.Error()}
+	}
+	writeJSON(w, apiErr.StatusCode, map[string]string{"code": apiErr.Code, "message": apiErr.Message})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
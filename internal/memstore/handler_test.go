@@ -0,0 +1,72 @@
+package memstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func serve(handler http.Handler, method string, path string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return recorder
+}
+
+func TestHandler(t *testing.T) {
+	s, storeId, _ := newTestServer(t)
+	handler := NewHandler(s)
+
+	recorder := serve(handler, http.MethodPost, "/stores/"+storeId+"/write",
+		`{"writes":{"tuple_keys":[{"user":"user:anne","relation":"viewer","object":"document:1"}]}}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body)
+	}
+
+	recorder = serve(handler, http.MethodPost, "/stores/"+storeId+"/check",
+		`{"tuple_key":{"user":"user:anne","relation":"viewer","object":"document:1"}}`)
+	var check fgaSdk.CheckResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &check); err != nil || !check.GetAllowed() {
+		t.Fatalf("Unexpected check response %s (%v)", recorder.Body, err)
+	}
+
+	recorder = serve(handler, http.MethodPost, "/stores/"+storeId+"/streamed-list-objects",
+		`{"type":"document","relation":"viewer","user":"user:anne"}`)
+	scanner := bufio.NewScanner(recorder.Body)
+	var lines []fgaSdk.StreamResultOfStreamedListObjectsResponse
+	for scanner.Scan() {
+		var line fgaSdk.StreamResultOfStreamedListObjectsResponse
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("%v", err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 1 || lines[0].Result.Object != "document:1" {
+		t.Fatalf("Unexpected streamed objects %+v", lines)
+	}
+
+	recorder = serve(handler, http.MethodGet, "/stores/"+storeId+"/changes?start_time=yesterday", "")
+	var validationErr fgaSdk.ValidationErrorMessageResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &validationErr); err != nil || recorder.Code != http.StatusBadRequest ||
+		validationErr.GetCode() != fgaSdk.ERRORCODE_INVALID_START_TIME {
+		t.Fatalf("Expected an invalid_start_time error, got %d: %s", recorder.Code, recorder.Body)
+	}
+
+	recorder = serve(handler, http.MethodPost, "/stores/"+storeId+"/read", `{not json`)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected a malformed body to be rejected, got %d", recorder.Code)
+	}
+
+	recorder = serve(handler, http.MethodDelete, "/stores/"+storeId, "")
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", recorder.Code)
+	}
+	recorder = serve(handler, http.MethodGet, "/stores/"+storeId, "")
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("Expected 404, got %d", recorder.Code)
+	}
+}
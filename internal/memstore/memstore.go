@@ -0,0 +1,301 @@
+// Package memstore is an in-memory implementation of the OpenFGA API, used by the fakes and test servers of the SDK.
+// Server implements the operations on the API types and Handler serves them over the OpenFGA REST surface.
+package memstore
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/evaluator"
+	internalutils "github.com/openfga/go-sdk/internal/utils"
+	"github.com/openfga/go-sdk/language"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+	// maxTuplesPerWrite is the number of writes and deletes the server accepts in a single Write
+	maxTuplesPerWrite = 100
+	// maxChecksPerBatchCheck is the number of checks the server accepts in a single BatchCheck
+	maxChecksPerBatchCheck = 50
+	// maxListObjectsResults is the number of objects the server returns from ListObjects
+	maxListObjectsResults = 1000
+	maxAssertions         = 100
+)
+
+// Error is an error returned by the API, with the HTTP status and error code the server responds with
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func validationError(code fgaSdk.ErrorCode, format string, args ...interface{}) *Error {
+	return &Error{StatusCode: http.StatusBadRequest, Code: string(code), Message: fmt.Sprintf(format, args...)}
+}
+
+func storeNotFoundError(storeId string) *Error {
+	return &Error{StatusCode: http.StatusNotFound, Code: string(fgaSdk.NOTFOUNDERRORCODE_STORE_ID_NOT_FOUND),
+		Message: fmt.Sprintf("store '%s' not found", storeId)}
+}
+
+// Options configures a Server
+type Options struct {
+	// ConditionEvaluator evaluates the conditions of conditional tuples during queries
+	ConditionEvaluator evaluator.ConditionEvaluator
+	// Now returns the time used for store, model and change timestamps. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Server holds any number of stores in memory. It is safe for concurrent use.
+type Server struct {
+	mu       sync.RWMutex
+	options  Options
+	stores   map[string]*store
+	storeIds []string
+}
+
+type store struct {
+	store fgaSdk.Store
+	// models holds the authorization models, newest first
+	models     []fgaSdk.AuthorizationModel
+	tuples     []fgaSdk.Tuple
+	tupleKeys  map[string]bool
+	changes    []fgaSdk.TupleChange
+	assertions map[string][]fgaSdk.Assertion
+	// version is incremented on every write so cached evaluators can be discarded
+	version   int
+	evaluator *cachedEvaluator
+}
+
+type cachedEvaluator struct {
+	modelId   string
+	version   int
+	evaluator *evaluator.Evaluator
+}
+
+// NewServer returns an empty Server. options may be nil.
+func NewServer(options *Options) *Server {
+	s := &Server{stores: make(map[string]*store)}
+	if options != nil {
+		s.options = *options
+	}
+	if s.options.Now == nil {
+		s.options.Now = time.Now
+	}
+	return s
+}
+
+func (s *Server) getStore(storeId string) (*store, error) {
+	st, ok := s.stores[storeId]
+	if !ok {
+		return nil, storeNotFoundError(storeId)
+	}
+	return st, nil
+}
+
+// ListStores returns the stores in creation order, optionally only those with the given name
+func (s *Server) ListStores(pageSize *int32, continuationToken string, name string) (*fgaSdk.ListStoresResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stores := []fgaSdk.Store{}
+	for _, storeId := range s.storeIds {
+		if st := s.stores[storeId]; name == "" || st.store.Name == name {
+			stores = append(stores, st.store)
+		}
+	}
+
+	page, next, err := paginate(stores, pageSize, continuationToken)
+	if err != nil {
+		return nil, err
+	}
+	return &fgaSdk.ListStoresResponse{Stores: page, ContinuationToken: next}, nil
+}
+
+// CreateStore creates a store with a new ULID id
+func (s *Server) CreateStore(body fgaSdk.CreateStoreRequest) (*fgaSdk.CreateStoreResponse, error) {
+	if body.Name == "" {
+		return nil, validationError(fgaSdk.ERRORCODE_VALIDATION_ERROR, "store name is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.options.Now().UTC()
+	st := &store{
+		store:      fgaSdk.Store{Id: internalutils.NewUlidString(now), Name: body.Name, CreatedAt: now, UpdatedAt: now},
+		tupleKeys:  make(map[string]bool),
+		assertions: make(map[string][]fgaSdk.Assertion),
+	}
+	s.stores[st.store.Id] = st
+	s.storeIds = append(s.storeIds, st.store.Id)
+
+	return &fgaSdk.CreateStoreResponse{Id: st.store.Id, Name: st.store.Name, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// GetStore returns a store
+func (s *Server) GetStore(storeId string) (*fgaSdk.GetStoreResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+	return &fgaSdk.GetStoreResponse{Id: st.store.Id, Name: st.store.Name, CreatedAt: st.store.CreatedAt, UpdatedAt: st.store.UpdatedAt}, nil
+}
+
+// DeleteStore deletes a store and everything in it
+func (s *Server) DeleteStore(storeId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.getStore(storeId); err != nil {
+		return err
+	}
+	delete(s.stores, storeId)
+	for index, id := range s.storeIds {
+		if id == storeId {
+			s.storeIds = append(s.storeIds[:index], s.storeIds[index+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// WriteAuthorizationModel validates a model with language.ValidateAuthorizationModel and stores it with a new ULID id
+func (s *Server) WriteAuthorizationModel(storeId string, body fgaSdk.WriteAuthorizationModelRequest) (*fgaSdk.WriteAuthorizationModelResponse, error) {
+	if len(body.TypeDefinitions) == 0 {
+		return nil, validationError(fgaSdk.ERRORCODE_TYPE_DEFINITIONS_TOO_FEW_ITEMS, "a model needs at least one type definition")
+	}
+	for _, issue := range language.ValidateAuthorizationModel(body) {
+		if issue.Severity == language.MODEL_ISSUE_SEVERITY_ERROR {
+			return nil, validationError(fgaSdk.ERRORCODE_INVALID_AUTHORIZATION_MODEL, "%s", issue.String())
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+	model := fgaSdk.AuthorizationModel{
+		Id:              internalutils.NewUlidString(s.options.Now()),
+		SchemaVersion:   body.SchemaVersion,
+		TypeDefinitions: body.TypeDefinitions,
+		Conditions:      body.Conditions,
+	}
+	st.models = append([]fgaSdk.AuthorizationModel{model}, st.models...)
+
+	return &fgaSdk.WriteAuthorizationModelResponse{AuthorizationModelId: model.Id}, nil
+}
+
+// ReadAuthorizationModels returns the models of a store, newest first
+func (s *Server) ReadAuthorizationModels(storeId string, pageSize *int32, continuationToken string) (*fgaSdk.ReadAuthorizationModelsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+	page, next, err := paginate(st.models, pageSize, continuationToken)
+	if err != nil {
+		return nil, err
+	}
+	return &fgaSdk.ReadAuthorizationModelsResponse{AuthorizationModels: page, ContinuationToken: fgaSdk.ToPtr(next)}, nil
+}
+
+// ReadAuthorizationModel returns a model of a store
+func (s *Server) ReadAuthorizationModel(storeId string, modelId string) (*fgaSdk.ReadAuthorizationModelResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+	model, err := st.model(modelId)
+	if err != nil {
+		return nil, err
+	}
+	return &fgaSdk.ReadAuthorizationModelResponse{AuthorizationModel: &model}, nil
+}
+
+// model returns the model with the given id, or the latest one when modelId is empty
+func (st *store) model(modelId string) (fgaSdk.AuthorizationModel, error) {
+	if modelId == "" {
+		if len(st.models) == 0 {
+			return fgaSdk.AuthorizationModel{}, validationError(fgaSdk.ERRORCODE_LATEST_AUTHORIZATION_MODEL_NOT_FOUND,
+				"no authorization model found for store '%s'", st.store.Id)
+		}
+		return st.models[0], nil
+	}
+	for _, model := range st.models {
+		if model.Id == modelId {
+			return model, nil
+		}
+	}
+	return fgaSdk.AuthorizationModel{}, validationError(fgaSdk.ERRORCODE_AUTHORIZATION_MODEL_NOT_FOUND,
+		"authorization model '%s' not found", modelId)
+}
+
+// paginate returns the page of values starting at the offset encoded in continuationToken, and the token of the next
+// page, empty when there are no more values
+func paginate[T any](values []T, pageSize *int32, continuationToken string) ([]T, string, error) {
+	size, err := resolvePageSize(pageSize)
+	if err != nil {
+		return nil, "", err
+	}
+	offset := 0
+	if continuationToken != "" {
+		offset, err = decodeOffset(continuationToken)
+		if err != nil || offset > len(values) {
+			return nil, "", validationError(fgaSdk.ERRORCODE_INVALID_CONTINUATION_TOKEN, "invalid continuation token")
+		}
+	}
+
+	end := offset + size
+	if end >= len(values) {
+		return append([]T{}, values[offset:]...), "", nil
+	}
+	return append([]T{}, values[offset:end]...), encodeOffset(end), nil
+}
+
+func resolvePageSize(pageSize *int32) (int, error) {
+	if pageSize == nil || *pageSize == 0 {
+		return defaultPageSize, nil
+	}
+	if *pageSize < 0 || *pageSize > maxPageSize {
+		return 0, validationError(fgaSdk.ERRORCODE_PAGE_SIZE_INVALID, "page size must be between 1 and %d", maxPageSize)
+	}
+	return int(*pageSize), nil
+}
+
+func encodeOffset(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeOffset(token string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid offset")
+	}
+	return offset, nil
+}
@@ -0,0 +1,130 @@
+package memstore
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	internalutils "github.com/openfga/go-sdk/internal/utils"
+	"github.com/openfga/go-sdk/language"
+)
+
+const testModelDSL = `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define owner: [user]
+    define viewer: [user, user:*] or owner
+`
+
+func newTestServer(t *testing.T) (*Server, string, string) {
+	t.Helper()
+
+	s := NewServer(nil)
+	store, err := s.CreateStore(fgaSdk.CreateStoreRequest{Name: "test"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	model, err := language.TransformDSLToModel(testModelDSL)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	written, err := s.WriteAuthorizationModel(store.Id, *model)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return s, store.Id, written.AuthorizationModelId
+}
+
+func expectErrorCode(t *testing.T, err error, statusCode int, code string) {
+	t.Helper()
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != statusCode || apiErr.Code != code {
+		t.Fatalf("Expected a %d %s error, got %v", statusCode, code, err)
+	}
+}
+
+func TestServerStores(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := NewServer(&Options{Now: func() time.Time { return now }})
+
+	for _, name := range []string{"a", "b", "a"} {
+		store, err := s.CreateStore(fgaSdk.CreateStoreRequest{Name: name})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if !internalutils.IsWellFormedUlidString(store.Id) || !store.CreatedAt.Equal(now) {
+			t.Fatalf("Unexpected store %+v", store)
+		}
+	}
+	_, err := s.CreateStore(fgaSdk.CreateStoreRequest{})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_VALIDATION_ERROR))
+
+	named, err := s.ListStores(nil, "", "a")
+	if err != nil || len(named.Stores) != 2 {
+		t.Fatalf("Expected two stores named a, got %+v (%v)", named, err)
+	}
+
+	if err := s.DeleteStore(named.Stores[0].Id); err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, err = s.GetStore(named.Stores[0].Id)
+	expectErrorCode(t, err, http.StatusNotFound, string(fgaSdk.NOTFOUNDERRORCODE_STORE_ID_NOT_FOUND))
+	all, _ := s.ListStores(nil, "", "")
+	if len(all.Stores) != 2 {
+		t.Fatalf("Expected the deleted store not to be listed, got %+v", all)
+	}
+}
+
+func TestServerAuthorizationModels(t *testing.T) {
+	s, storeId, modelId := newTestServer(t)
+
+	_, err := s.WriteAuthorizationModel(storeId, fgaSdk.WriteAuthorizationModelRequest{SchemaVersion: "1.1"})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_TYPE_DEFINITIONS_TOO_FEW_ITEMS))
+
+	model, _ := language.TransformDSLToModel(testModelDSL)
+	latest, err := s.WriteAuthorizationModel(storeId, *model)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	models, err := s.ReadAuthorizationModels(storeId, fgaSdk.ToPtr(int32(1)), "")
+	if err != nil || len(models.AuthorizationModels) != 1 || models.AuthorizationModels[0].Id != latest.AuthorizationModelId {
+		t.Fatalf("Expected the newest model first, got %+v (%v)", models, err)
+	}
+	models, err = s.ReadAuthorizationModels(storeId, fgaSdk.ToPtr(int32(1)), models.GetContinuationToken())
+	if err != nil || len(models.AuthorizationModels) != 1 || models.AuthorizationModels[0].Id != modelId || models.GetContinuationToken() != "" {
+		t.Fatalf("Unexpected second page %+v (%v)", models, err)
+	}
+
+	_, err = s.ReadAuthorizationModel(storeId, "01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_AUTHORIZATION_MODEL_NOT_FOUND))
+}
+
+func TestPaginate(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5}
+
+	page, token, err := paginate(values, fgaSdk.ToPtr(int32(2)), "")
+	if err != nil || len(page) != 2 || token == "" {
+		t.Fatalf("paginate() = %v, %q, %v", page, token, err)
+	}
+	page, token, _ = paginate(values, fgaSdk.ToPtr(int32(2)), token)
+	if page[0] != 3 || token == "" {
+		t.Fatalf("paginate() = %v, %q", page, token)
+	}
+	page, token, _ = paginate(values, fgaSdk.ToPtr(int32(2)), token)
+	if len(page) != 1 || token != "" {
+		t.Fatalf("Expected the last page without a token, got %v, %q", page, token)
+	}
+
+	_, _, err = paginate(values, fgaSdk.ToPtr(int32(101)), "")
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_PAGE_SIZE_INVALID))
+	_, _, err = paginate(values, nil, "not a token")
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_INVALID_CONTINUATION_TOKEN))
+}
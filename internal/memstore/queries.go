@@ -0,0 +1,174 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/evaluator"
+)
+
+var correlationIdPattern = regexp.MustCompile(`^[\w\d-]{1,36}$`)
+
+// evaluator returns an evaluator for the model over the tuples currently in the store, reusing the cached one when
+// neither the model nor the tuples changed. Stored tuples which are not valid for the model are ignored, as the server
+// does when they were written against another model. The caller must hold the write lock.
+func (s *Server) evaluator(st *store, model fgaSdk.AuthorizationModel) (*evaluator.Evaluator, error) {
+	if cached := st.evaluator; cached != nil && cached.modelId == model.Id && cached.version == st.version {
+		return cached.evaluator, nil
+	}
+
+	options := &evaluator.EvaluatorOptions{ConditionEvaluator: s.options.ConditionEvaluator}
+	base, err := evaluator.NewEvaluator(model, nil, options)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	tuples := make([]fgaSdk.TupleKey, 0, len(st.tuples))
+	for _, tuple := range st.tuples {
+		if base.ValidateTuple(tuple.Key) == nil {
+			tuples = append(tuples, tuple.Key)
+		}
+	}
+	eval, err := evaluator.NewEvaluator(model, tuples, options)
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	st.evaluator = &cachedEvaluator{modelId: model.Id, version: st.version, evaluator: eval}
+	return eval, nil
+}
+
+func (s *Server) queryEvaluator(storeId string, modelId string) (*evaluator.Evaluator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+	model, err := st.model(modelId)
+	if err != nil {
+		return nil, err
+	}
+	return s.evaluator(st, model)
+}
+
+// queryError maps an error returned by the evaluator to the error the server would respond with
+func queryError(err error) error {
+	var apiErr *Error
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case errors.Is(err, evaluator.ErrResolutionDepthExceeded):
+		return validationError(fgaSdk.ERRORCODE_AUTHORIZATION_MODEL_RESOLUTION_TOO_COMPLEX, "%s", err)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return validationError(fgaSdk.ERRORCODE_CANCELLED, "%s", err)
+	case errors.Is(err, evaluator.ErrInvalidInput), errors.Is(err, evaluator.ErrNoConditionEvaluator):
+		return validationError(fgaSdk.ERRORCODE_VALIDATION_ERROR, "%s", err)
+	default:
+		return &Error{StatusCode: http.StatusInternalServerError, Code: string(fgaSdk.INTERNALERRORCODE_INTERNAL_ERROR), Message: err.Error()}
+	}
+}
+
+// Check answers a check against the tuples of the store
+func (s *Server) Check(ctx context.Context, storeId string, body fgaSdk.CheckRequest) (*fgaSdk.CheckResponse, error) {
+	eval, err := s.queryEvaluator(storeId, body.GetAuthorizationModelId())
+	if err != nil {
+		return nil, err
+	}
+	response, err := eval.Check(ctx, body)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return response, nil
+}
+
+// BatchCheck answers up to 50 checks. Checks failing on their input are reported in their result rather than failing
+// the request.
+func (s *Server) BatchCheck(ctx context.Context, storeId string, body fgaSdk.BatchCheckRequest) (*fgaSdk.BatchCheckResponse, error) {
+	if len(body.Checks) == 0 || len(body.Checks) > maxChecksPerBatchCheck {
+		return nil, validationError(fgaSdk.ERRORCODE_VALIDATION_ERROR, "a batch check needs between 1 and %d checks", maxChecksPerBatchCheck)
+	}
+	seen := make(map[string]bool, len(body.Checks))
+	for _, check := range body.Checks {
+		if !correlationIdPattern.MatchString(check.CorrelationId) {
+			return nil, validationError(fgaSdk.ERRORCODE_VALIDATION_ERROR, "invalid correlation id '%s'", check.CorrelationId)
+		}
+		if seen[check.CorrelationId] {
+			return nil, validationError(fgaSdk.ERRORCODE_VALIDATION_ERROR, "duplicate correlation id '%s'", check.CorrelationId)
+		}
+		seen[check.CorrelationId] = true
+	}
+
+	eval, err := s.queryEvaluator(storeId, body.GetAuthorizationModelId())
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]fgaSdk.BatchCheckSingleResult, len(body.Checks))
+	for _, check := range body.Checks {
+		response, err := eval.Check(ctx, fgaSdk.CheckRequest{
+			TupleKey:         check.TupleKey,
+			ContextualTuples: check.ContextualTuples,
+			Context:          check.Context,
+		})
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, queryError(ctxErr)
+			}
+			var apiErr *Error
+			errors.As(queryError(err), &apiErr)
+			results[check.CorrelationId] = fgaSdk.BatchCheckSingleResult{Error: &fgaSdk.CheckError{
+				InputError: fgaSdk.ToPtr(fgaSdk.ErrorCode(apiErr.Code)),
+				Message:    fgaSdk.ToPtr(apiErr.Message),
+			}}
+			continue
+		}
+		results[check.CorrelationId] = fgaSdk.BatchCheckSingleResult{Allowed: response.Allowed}
+	}
+	return &fgaSdk.BatchCheckResponse{Result: &results}, nil
+}
+
+// Expand expands a relation of an object one level deep
+func (s *Server) Expand(ctx context.Context, storeId string, body fgaSdk.ExpandRequest) (*fgaSdk.ExpandResponse, error) {
+	eval, err := s.queryEvaluator(storeId, body.GetAuthorizationModelId())
+	if err != nil {
+		return nil, err
+	}
+	response, err := eval.Expand(ctx, body)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return response, nil
+}
+
+// ListObjects returns up to 1000 objects the user has the relation with
+func (s *Server) ListObjects(ctx context.Context, storeId string, body fgaSdk.ListObjectsRequest) (*fgaSdk.ListObjectsResponse, error) {
+	eval, err := s.queryEvaluator(storeId, body.GetAuthorizationModelId())
+	if err != nil {
+		return nil, err
+	}
+	response, err := eval.ListObjects(ctx, body)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	if len(response.Objects) > maxListObjectsResults {
+		response.Objects = response.Objects[:maxListObjectsResults]
+	}
+	return response, nil
+}
+
+// ListUsers returns the users matching the user filter which have the relation with the object
+func (s *Server) ListUsers(ctx context.Context, storeId string, body fgaSdk.ListUsersRequest) (*fgaSdk.ListUsersResponse, error) {
+	eval, err := s.queryEvaluator(storeId, body.GetAuthorizationModelId())
+	if err != nil {
+		return nil, err
+	}
+	response, err := eval.ListUsers(ctx, body)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return response, nil
+}
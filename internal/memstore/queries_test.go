@@ -0,0 +1,114 @@
+package memstore
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/language"
+)
+
+func TestServerCheck(t *testing.T) {
+	s, storeId, _ := newTestServer(t)
+	ctx := context.Background()
+	check := fgaSdk.CheckRequest{TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}}
+
+	response, err := s.Check(ctx, storeId, check)
+	if err != nil || response.GetAllowed() {
+		t.Fatalf("Check() = %+v, %v", response, err)
+	}
+
+	if err := writeTuples(s, storeId, fgaSdk.TupleKey{User: "user:anne", Relation: "owner", Object: "document:1"}); err != nil {
+		t.Fatalf("%v", err)
+	}
+	response, err = s.Check(ctx, storeId, check)
+	if err != nil || !response.GetAllowed() {
+		t.Fatalf("Expected the check to see the new tuple, got %+v, %v", response, err)
+	}
+
+	_, err = s.Check(ctx, storeId, fgaSdk.CheckRequest{TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:anne", Relation: "editor", Object: "document:1"}})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_VALIDATION_ERROR))
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = s.Check(cancelled, storeId, check)
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_CANCELLED))
+}
+
+func TestServerQueriesIgnoreStaleTuples(t *testing.T) {
+	s, storeId, _ := newTestServer(t)
+	if err := writeTuples(s, storeId, fgaSdk.TupleKey{User: "user:anne", Relation: "owner", Object: "document:1"}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	model, _ := language.TransformDSLToModel(`model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`)
+	written, err := s.WriteAuthorizationModel(storeId, *model)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	response, err := s.Check(context.Background(), storeId, fgaSdk.CheckRequest{
+		TupleKey:             fgaSdk.CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		AuthorizationModelId: &written.AuthorizationModelId,
+	})
+	if err != nil || response.GetAllowed() {
+		t.Fatalf("Expected tuples invalid for the model to be ignored, got %+v, %v", response, err)
+	}
+}
+
+func TestServerBatchCheck(t *testing.T) {
+	s, storeId, _ := newTestServer(t)
+	if err := writeTuples(s, storeId, fgaSdk.TupleKey{User: "user:*", Relation: "viewer", Object: "document:1"}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	response, err := s.BatchCheck(context.Background(), storeId, fgaSdk.BatchCheckRequest{Checks: []fgaSdk.BatchCheckItem{
+		{TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}, CorrelationId: "a"},
+		{TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:anne", Relation: "owner", Object: "document:1"}, CorrelationId: "b"},
+		{TupleKey: fgaSdk.CheckRequestTupleKey{User: "user:anne", Relation: "owner", Object: "folder:1"}, CorrelationId: "c"},
+	}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	results := response.GetResult()
+	if !*results["a"].Allowed || *results["b"].Allowed || results["c"].Error.GetInputError() != fgaSdk.ERRORCODE_VALIDATION_ERROR {
+		t.Fatalf("Unexpected results %+v", results)
+	}
+
+	for _, checks := range [][]fgaSdk.BatchCheckItem{
+		nil,
+		make([]fgaSdk.BatchCheckItem, maxChecksPerBatchCheck+1),
+		{{CorrelationId: "a"}, {CorrelationId: "a"}},
+		{{CorrelationId: "not valid!"}},
+	} {
+		_, err := s.BatchCheck(context.Background(), storeId, fgaSdk.BatchCheckRequest{Checks: checks})
+		expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_VALIDATION_ERROR))
+	}
+}
+
+func TestServerListObjects(t *testing.T) {
+	s, storeId, _ := newTestServer(t)
+	if err := writeTuples(s, storeId,
+		fgaSdk.TupleKey{User: "user:anne", Relation: "owner", Object: "document:2"},
+		fgaSdk.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"},
+	); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	response, err := s.ListObjects(context.Background(), storeId, fgaSdk.ListObjectsRequest{Type: "document", Relation: "viewer", User: "user:anne"})
+	if err != nil || len(response.Objects) != 2 {
+		t.Fatalf("ListObjects() = %+v, %v", response, err)
+	}
+
+	_, err = s.ListObjects(context.Background(), "01ARZ3NDEKTSV4RRFFQ69G5FAV", fgaSdk.ListObjectsRequest{Type: "document", Relation: "viewer", User: "user:anne"})
+	expectErrorCode(t, err, http.StatusNotFound, string(fgaSdk.NOTFOUNDERRORCODE_STORE_ID_NOT_FOUND))
+}
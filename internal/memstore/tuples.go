@@ -0,0 +1,240 @@
+package memstore
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func tupleKeyId(object string, relation string, user string) string {
+	return object + "#" + relation + "@" + user
+}
+
+// Read returns the tuples of a store matching the tuple key, in the order they were written. The object of the tuple
+// key may be a type followed by a colon to match every object of that type.
+func (s *Server) Read(storeId string, body fgaSdk.ReadRequest) (*fgaSdk.ReadResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := fgaSdk.ReadRequestTupleKey{}
+	if body.TupleKey != nil {
+		filter = *body.TupleKey
+	}
+	if filter.Object != nil && *filter.Object != "" && !strings.Contains(*filter.Object, ":") {
+		return nil, validationError(fgaSdk.ERRORCODE_INVALID_OBJECT_FORMAT, "invalid object '%s', expected type:id or type:", *filter.Object)
+	}
+
+	matches := []fgaSdk.Tuple{}
+	for _, tuple := range st.tuples {
+		if matchesReadFilter(tuple.Key, filter) {
+			matches = append(matches, tuple)
+		}
+	}
+
+	page, next, err := paginate(matches, body.PageSize, body.GetContinuationToken())
+	if err != nil {
+		return nil, err
+	}
+	return &fgaSdk.ReadResponse{Tuples: page, ContinuationToken: next}, nil
+}
+
+func matchesReadFilter(key fgaSdk.TupleKey, filter fgaSdk.ReadRequestTupleKey) bool {
+	if filter.User != nil && *filter.User != "" && key.User != *filter.User {
+		return false
+	}
+	if filter.Relation != nil && *filter.Relation != "" && key.Relation != *filter.Relation {
+		return false
+	}
+	if filter.Object != nil && *filter.Object != "" {
+		if strings.HasSuffix(*filter.Object, ":") {
+			return strings.HasPrefix(key.Object, *filter.Object)
+		}
+		return key.Object == *filter.Object
+	}
+	return true
+}
+
+// Write atomically deletes and writes tuples, validating the writes against the model. Writing an existing tuple and
+// deleting a missing one fail unless OnDuplicate and OnMissing are "ignore"; an ignored duplicate must have the same
+// condition as the existing tuple.
+func (s *Server) Write(storeId string, body fgaSdk.WriteRequest) error {
+	var writes []fgaSdk.TupleKey
+	var deletes []fgaSdk.TupleKeyWithoutCondition
+	ignoreDuplicates, ignoreMissing := false, false
+	if body.Writes != nil {
+		writes = body.Writes.TupleKeys
+		ignoreDuplicates = body.Writes.GetOnDuplicate() == "ignore"
+	}
+	if body.Deletes != nil {
+		deletes = body.Deletes.TupleKeys
+		ignoreMissing = body.Deletes.GetOnMissing() == "ignore"
+	}
+	if len(writes)+len(deletes) == 0 {
+		return validationError(fgaSdk.ERRORCODE_INVALID_WRITE_INPUT, "a write needs at least one tuple to write or delete")
+	}
+	if len(writes)+len(deletes) > maxTuplesPerWrite {
+		return validationError(fgaSdk.ERRORCODE_EXCEEDED_ENTITY_LIMIT, "a write accepts at most %d tuples", maxTuplesPerWrite)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return err
+	}
+	model, err := st.model(body.GetAuthorizationModelId())
+	if err != nil {
+		return err
+	}
+	eval, err := s.evaluator(st, model)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(writes)+len(deletes))
+	for _, tuple := range writes {
+		id := tupleKeyId(tuple.Object, tuple.Relation, tuple.User)
+		if seen[id] {
+			return validationError(fgaSdk.ERRORCODE_CANNOT_ALLOW_DUPLICATE_TUPLES_IN_ONE_REQUEST, "duplicate tuple %s in the request", id)
+		}
+		seen[id] = true
+		if err := eval.ValidateTuple(tuple); err != nil {
+			return validationError(fgaSdk.ERRORCODE_VALIDATION_ERROR, "invalid tuple %s: %s", id, err)
+		}
+	}
+	for _, tuple := range deletes {
+		id := tupleKeyId(tuple.Object, tuple.Relation, tuple.User)
+		if seen[id] {
+			return validationError(fgaSdk.ERRORCODE_CANNOT_ALLOW_DUPLICATE_TUPLES_IN_ONE_REQUEST, "duplicate tuple %s in the request", id)
+		}
+		seen[id] = true
+	}
+
+	var applyDeletes []string
+	for _, tuple := range deletes {
+		id := tupleKeyId(tuple.Object, tuple.Relation, tuple.User)
+		if st.tupleKeys[id] {
+			applyDeletes = append(applyDeletes, id)
+		} else if !ignoreMissing {
+			return validationError(fgaSdk.ERRORCODE_WRITE_FAILED_DUE_TO_INVALID_INPUT, "cannot delete a tuple which does not exist: %s", id)
+		}
+	}
+	var applyWrites []fgaSdk.TupleKey
+	for _, tuple := range writes {
+		id := tupleKeyId(tuple.Object, tuple.Relation, tuple.User)
+		if !st.tupleKeys[id] {
+			applyWrites = append(applyWrites, tuple)
+			continue
+		}
+		if !ignoreDuplicates || !reflect.DeepEqual(st.tuple(id).Key.Condition, tuple.Condition) {
+			return validationError(fgaSdk.ERRORCODE_WRITE_FAILED_DUE_TO_INVALID_INPUT, "cannot write a tuple which already exists: %s", id)
+		}
+	}
+
+	now := s.options.Now().UTC()
+	for _, id := range applyDeletes {
+		for index, tuple := range st.tuples {
+			if tupleKeyId(tuple.Key.Object, tuple.Key.Relation, tuple.Key.User) == id {
+				st.tuples = append(st.tuples[:index], st.tuples[index+1:]...)
+				st.changes = append(st.changes, fgaSdk.TupleChange{
+					TupleKey:  fgaSdk.TupleKey{User: tuple.Key.User, Relation: tuple.Key.Relation, Object: tuple.Key.Object},
+					Operation: fgaSdk.TUPLEOPERATION_DELETE,
+					Timestamp: now,
+				})
+				break
+			}
+		}
+		delete(st.tupleKeys, id)
+	}
+	for _, tuple := range applyWrites {
+		st.tuples = append(st.tuples, fgaSdk.Tuple{Key: tuple, Timestamp: now})
+		st.tupleKeys[tupleKeyId(tuple.Object, tuple.Relation, tuple.User)] = true
+		st.changes = append(st.changes, fgaSdk.TupleChange{TupleKey: tuple, Operation: fgaSdk.TUPLEOPERATION_WRITE, Timestamp: now})
+	}
+	st.version++
+
+	return nil
+}
+
+func (st *store) tuple(id string) fgaSdk.Tuple {
+	for _, tuple := range st.tuples {
+		if tupleKeyId(tuple.Key.Object, tuple.Key.Relation, tuple.Key.User) == id {
+			return tuple
+		}
+	}
+	return fgaSdk.Tuple{}
+}
+
+// ReadChanges returns the changes of a store in the order they happened, optionally only those on objects of a type.
+// The continuation token is returned even when there are no more changes, so it can be used to poll for new ones.
+func (s *Server) ReadChanges(storeId string, objectType string, pageSize *int32, continuationToken string, startTime time.Time) (*fgaSdk.ReadChangesResponse, error) {
+	size, err := resolvePageSize(pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, err := s.getStore(storeId)
+	if err != nil {
+		return nil, err
+	}
+
+	position := 0
+	if continuationToken != "" {
+		var tokenType string
+		position, tokenType, err = decodeChangesToken(continuationToken)
+		if err != nil || position > len(st.changes) {
+			return nil, validationError(fgaSdk.ERRORCODE_INVALID_CONTINUATION_TOKEN, "invalid continuation token")
+		}
+		if tokenType != objectType {
+			return nil, validationError(fgaSdk.ERRORCODE_QUERY_STRING_TYPE_CONTINUATION_TOKEN_MISMATCH,
+				"the type '%s' does not match the type of the continuation token", objectType)
+		}
+	} else if !startTime.IsZero() {
+		position = len(st.changes)
+		for index, change := range st.changes {
+			if !change.Timestamp.Before(startTime) {
+				position = index
+				break
+			}
+		}
+	}
+
+	changes := []fgaSdk.TupleChange{}
+	for ; position < len(st.changes) && len(changes) < size; position++ {
+		change := st.changes[position]
+		if objectType == "" || strings.HasPrefix(change.TupleKey.Object, objectType+":") {
+			changes = append(changes, change)
+		}
+	}
+	return &fgaSdk.ReadChangesResponse{Changes: changes, ContinuationToken: fgaSdk.ToPtr(encodeChangesToken(position, objectType))}, nil
+}
+
+func encodeChangesToken(position int, objectType string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(position) + "|" + objectType))
+}
+
+func decodeChangesToken(token string) (int, string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, "", err
+	}
+	positionText, objectType, _ := strings.Cut(string(decoded), "|")
+	position, err := strconv.Atoi(positionText)
+	if err != nil || position < 0 {
+		return 0, "", validationError(fgaSdk.ERRORCODE_INVALID_CONTINUATION_TOKEN, "invalid continuation token")
+	}
+	return position, objectType, nil
+}
@@ -0,0 +1,127 @@
+package memstore
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func writeTuples(s *Server, storeId string, tuples ...fgaSdk.TupleKey) error {
+	return s.Write(storeId, fgaSdk.WriteRequest{Writes: &fgaSdk.WriteRequestWrites{TupleKeys: tuples}})
+}
+
+func TestServerWrite(t *testing.T) {
+	s, storeId, _ := newTestServer(t)
+	anne := fgaSdk.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}
+	beth := fgaSdk.TupleKey{User: "user:beth", Relation: "viewer", Object: "document:1"}
+
+	if err := writeTuples(s, storeId, anne); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	err := writeTuples(s, storeId, beth, anne)
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_WRITE_FAILED_DUE_TO_INVALID_INPUT))
+	err = writeTuples(s, storeId, beth, beth)
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_CANNOT_ALLOW_DUPLICATE_TUPLES_IN_ONE_REQUEST))
+	err = writeTuples(s, storeId, fgaSdk.TupleKey{User: "user:anne", Relation: "editor", Object: "document:1"})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_VALIDATION_ERROR))
+	err = s.Write(storeId, fgaSdk.WriteRequest{})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_INVALID_WRITE_INPUT))
+
+	response, _ := s.Read(storeId, fgaSdk.ReadRequest{})
+	if len(response.Tuples) != 1 {
+		t.Fatalf("Expected failed writes not to be applied, got %+v", response.Tuples)
+	}
+
+	err = s.Write(storeId, fgaSdk.WriteRequest{
+		Writes: &fgaSdk.WriteRequestWrites{TupleKeys: []fgaSdk.TupleKey{anne, beth}, OnDuplicate: fgaSdk.ToPtr("ignore")},
+		Deletes: &fgaSdk.WriteRequestDeletes{
+			TupleKeys: []fgaSdk.TupleKeyWithoutCondition{{User: "user:carl", Relation: "viewer", Object: "document:1"}},
+			OnMissing: fgaSdk.ToPtr("ignore"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	err = s.Write(storeId, fgaSdk.WriteRequest{
+		Deletes: &fgaSdk.WriteRequestDeletes{TupleKeys: []fgaSdk.TupleKeyWithoutCondition{{User: "user:carl", Relation: "viewer", Object: "document:1"}}},
+	})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_WRITE_FAILED_DUE_TO_INVALID_INPUT))
+
+	tuples := make([]fgaSdk.TupleKey, maxTuplesPerWrite+1)
+	err = writeTuples(s, storeId, tuples...)
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_EXCEEDED_ENTITY_LIMIT))
+}
+
+func TestServerRead(t *testing.T) {
+	s, storeId, _ := newTestServer(t)
+	err := writeTuples(s, storeId,
+		fgaSdk.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		fgaSdk.TupleKey{User: "user:anne", Relation: "owner", Object: "document:2"},
+		fgaSdk.TupleKey{User: "user:beth", Relation: "viewer", Object: "document:2"},
+	)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   fgaSdk.ReadRequestTupleKey
+		expected int
+	}{
+		{name: "type", filter: fgaSdk.ReadRequestTupleKey{Object: fgaSdk.ToPtr("document:")}, expected: 3},
+		{name: "object", filter: fgaSdk.ReadRequestTupleKey{Object: fgaSdk.ToPtr("document:2")}, expected: 2},
+		{name: "user and type", filter: fgaSdk.ReadRequestTupleKey{User: fgaSdk.ToPtr("user:anne"), Object: fgaSdk.ToPtr("document:")}, expected: 2},
+		{name: "relation", filter: fgaSdk.ReadRequestTupleKey{Relation: fgaSdk.ToPtr("viewer"), Object: fgaSdk.ToPtr("document:2")}, expected: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := s.Read(storeId, fgaSdk.ReadRequest{TupleKey: &test.filter})
+			if err != nil || len(response.Tuples) != test.expected {
+				t.Fatalf("Read() = %+v, %v, want %d tuples", response, err, test.expected)
+			}
+		})
+	}
+
+	_, err = s.Read(storeId, fgaSdk.ReadRequest{TupleKey: &fgaSdk.ReadRequestTupleKey{Object: fgaSdk.ToPtr("document")}})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_INVALID_OBJECT_FORMAT))
+}
+
+func TestServerReadChanges(t *testing.T) {
+	s, storeId, _ := newTestServer(t)
+	start := time.Now()
+	now := start
+	s.options.Now = func() time.Time { return now }
+
+	for _, object := range []string{"document:1", "document:2", "document:3"} {
+		now = now.Add(time.Minute)
+		if err := writeTuples(s, storeId, fgaSdk.TupleKey{User: "user:anne", Relation: "viewer", Object: object}); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	response, err := s.ReadChanges(storeId, "", fgaSdk.ToPtr(int32(2)), "", time.Time{})
+	if err != nil || len(response.Changes) != 2 {
+		t.Fatalf("ReadChanges() = %+v, %v", response, err)
+	}
+	response, err = s.ReadChanges(storeId, "", nil, response.GetContinuationToken(), time.Time{})
+	if err != nil || len(response.Changes) != 1 || response.Changes[0].TupleKey.Object != "document:3" {
+		t.Fatalf("ReadChanges() = %+v, %v", response, err)
+	}
+	token := response.GetContinuationToken()
+	response, err = s.ReadChanges(storeId, "", nil, token, time.Time{})
+	if err != nil || len(response.Changes) != 0 || response.GetContinuationToken() != token {
+		t.Fatalf("Expected no changes and the same token, got %+v, %v", response, err)
+	}
+
+	response, err = s.ReadChanges(storeId, "", nil, "", start.Add(2*time.Minute))
+	if err != nil || len(response.Changes) != 2 || response.Changes[0].TupleKey.Object != "document:2" {
+		t.Fatalf("Expected the changes since the start time, got %+v, %v", response, err)
+	}
+
+	_, err = s.ReadChanges(storeId, "document", nil, token, time.Time{})
+	expectErrorCode(t, err, http.StatusBadRequest, string(fgaSdk.ERRORCODE_QUERY_STRING_TYPE_CONTINUATION_TOKEN_MISMATCH))
+}
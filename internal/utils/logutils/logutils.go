@@ -0,0 +1,135 @@
+package logutils
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the redacted values in the events
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the keys of the attributes whose value is always redacted, compared case-insensitively
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"api_token":     true,
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"password":      true,
+}
+
+// userKeys are the keys of the attributes identifying a user, redacted when the users are redacted
+var userKeys = map[string]bool{
+	"user":    true,
+	"subject": true,
+}
+
+// secretPatterns match the secrets embedded in a message or a string value, e.g. in an error message, keeping their
+// first group
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[^\s"',;]+`),
+	regexp.MustCompile(`(?i)((?:client_secret|access_token|refresh_token|api_token)["']?\s*[=:]\s*["']?)[^\s"'&,;}]+`),
+}
+
+// New returns the logger of the SDK, which redacts the secrets of its events, and the users when redactUsers is set.
+// It wraps logger when it is set. Otherwise, it writes the events from the debug level to stderr when debug is set,
+// and discards them when it is not.
+func New(logger *slog.Logger, debug bool, redactUsers bool) *slog.Logger {
+	var handler slog.Handler
+	switch {
+	case logger != nil:
+		handler = logger.Handler()
+	case debug:
+		handler = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})
+	default:
+		handler = slog.DiscardHandler
+	}
+
+	return slog.New(NewRedactingHandler(handler, redactUsers))
+}
+
+// RedactString redacts the bearer tokens and the client secrets found in s
+func RedactString(s string) string {
+	for _, pattern := range secretPatterns {
+		s = pattern.ReplaceAllString(s, "${1}"+Redacted)
+	}
+
+	return s
+}
+
+// redactingHandler redacts the secrets of the events before passing them to its handler
+type redactingHandler struct {
+	handler     slog.Handler
+	redactUsers bool
+}
+
+// NewRedactingHandler returns a handler redacting the secrets of the events, and the users when redactUsers is set,
+// before passing them to handler
+func NewRedactingHandler(handler slog.Handler, redactUsers bool) slog.Handler {
+	if redacting, ok := handler.(*redactingHandler); ok {
+		return &redactingHandler{handler: redacting.handler, redactUsers: redactUsers || redacting.redactUsers}
+	}
+
+	return &redactingHandler{handler: handler, redactUsers: redactUsers}
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, RedactString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(h.redact(attr))
+		return true
+	})
+
+	return h.handler.Handle(ctx, redacted)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = h.redact(attr)
+	}
+
+	return &redactingHandler{handler: h.handler.WithAttrs(redacted), redactUsers: h.redactUsers}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{handler: h.handler.WithGroup(name), redactUsers: h.redactUsers}
+}
+
+// redact returns attr with its sensitive values redacted, including in its groups
+func (h *redactingHandler) redact(attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	if sensitiveKeys[key] || (h.redactUsers && userKeys[key]) {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, RedactString(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, groupAttr := range group {
+			redacted[i] = h.redact(groupAttr)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			return slog.String(attr.Key, RedactString(v.Error()))
+		case fmt.Stringer:
+			return slog.String(attr.Key, RedactString(v.String()))
+		}
+	}
+
+	return slog.Attr{Key: attr.Key, Value: value}
+}
@@ -0,0 +1,88 @@
+package logutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func newTestLogger(redactUsers bool) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	return New(logger, false, redactUsers), &buf
+}
+
+func decodeEvent(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var event map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &event); err != nil {
+		t.Fatalf("failed to decode the event %q: %v", buf.String(), err)
+	}
+
+	return event
+}
+
+func TestRedactString(t *testing.T) {
+	tests := map[string]string{
+		"Authorization: Bearer eyJhbGciOi.abc":                            "Authorization: Bearer " + Redacted,
+		`{"access_token":"eyJhbGciOi","expires_in":3}`:                    `{"access_token":"` + Redacted + `","expires_in":3}`,
+		"grant_type=client_credentials&client_secret=s3cr3t&client_id=id": "grant_type=client_credentials&client_secret=" + Redacted + "&client_id=id",
+		"no secret here": "no secret here",
+	}
+	for input, expected := range tests {
+		if got := RedactString(input); got != expected {
+			t.Errorf("Expected %q to be redacted to %q, got %q", input, expected, got)
+		}
+	}
+}
+
+func TestNewRedactsEvents(t *testing.T) {
+	logger, buf := newTestLogger(false)
+
+	logger.With(slog.String("Authorization", "Bearer abc")).Warn("request failed with token Bearer abc",
+		slog.String("client_secret", "s3cr3t"),
+		slog.Any("error", errors.New("oauth2: cannot fetch token: access_token=abc")),
+		slog.Group("request", slog.String("header", "Bearer abc"), slog.String("user", "user:anne")),
+	)
+
+	event := decodeEvent(t, buf)
+	if strings.Contains(buf.String(), "abc") || strings.Contains(buf.String(), "s3cr3t") {
+		t.Fatalf("Expected the secrets to be redacted, got %s", buf.String())
+	}
+	if event["Authorization"] != Redacted || event["client_secret"] != Redacted {
+		t.Fatalf("Expected the sensitive attributes to be redacted, got %v", event)
+	}
+	request := event["request"].(map[string]interface{})
+	if request["user"] != "user:anne" {
+		t.Fatalf("Expected the user not to be redacted by default, got %v", request)
+	}
+}
+
+func TestNewRedactsUsers(t *testing.T) {
+	logger, buf := newTestLogger(true)
+
+	logger.WithGroup("request").Info("request started", slog.String("user", "user:anne"), slog.String("method", "Check"))
+
+	request := decodeEvent(t, buf)["request"].(map[string]interface{})
+	if request["user"] != Redacted || request["method"] != "Check" {
+		t.Fatalf("Expected only the user to be redacted, got %v", request)
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	if logger := New(nil, false, false); logger.Enabled(t.Context(), slog.LevelError) {
+		t.Fatalf("Expected the events to be discarded without a logger")
+	}
+	if logger := New(nil, true, false); !logger.Enabled(t.Context(), slog.LevelDebug) {
+		t.Fatalf("Expected the debug events to be logged in debug mode")
+	}
+
+	wrapped := NewRedactingHandler(NewRedactingHandler(slog.DiscardHandler, true), false)
+	if handler := wrapped.(*redactingHandler); handler.handler != slog.DiscardHandler || !handler.redactUsers {
+		t.Fatalf("Expected a redacting handler not to be wrapped twice")
+	}
+}
@@ -1,7 +1,10 @@
 package internalutils
 
 import (
+	"crypto/rand"
+	"encoding/binary"
 	"regexp"
+	"time"
 )
 
 // cUlidRegex contains the regex for valid ULID
@@ -12,3 +15,28 @@ func IsWellFormedUlidString(ulidString string) bool {
 	re := regexp.MustCompile(cUlidRegex)
 	return re.MatchString(ulidString)
 }
+
+// crockfordAlphabet is the base32 alphabet used to encode ULIDs
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewUlidString returns a new ULID whose timestamp part is t, followed by 80 random bits
+func NewUlidString(t time.Time) string {
+	var data [16]byte
+	milliseconds := uint64(t.UnixMilli())
+	for index := 5; index >= 0; index-- {
+		data[index] = byte(milliseconds)
+		milliseconds >>= 8
+	}
+	_, _ = rand.Read(data[6:])
+
+	// 128 bits encoded 5 bits at a time, the first character only holding the 3 most significant bits
+	encoded := make([]byte, 26)
+	high := binary.BigEndian.Uint64(data[:8])
+	low := binary.BigEndian.Uint64(data[8:])
+	for index := 25; index >= 0; index-- {
+		encoded[index] = crockfordAlphabet[low&0x1f]
+		low = low>>5 | high<<59
+		high >>= 5
+	}
+	return string(encoded)
+}
@@ -14,6 +14,7 @@ package internalutils
 
 import (
 	"testing"
+	"time"
 )
 
 func TestIsWellFormedUlidString(t *testing.T) {
@@ -59,3 +60,23 @@ func TestIsWellFormedUlidString(t *testing.T) {
 	}
 
 }
+
+func TestNewUlidString(t *testing.T) {
+	now := time.Now()
+	first := NewUlidString(now)
+	second := NewUlidString(now)
+	later := NewUlidString(now.Add(time.Millisecond))
+
+	if !IsWellFormedUlidString(first) || !IsWellFormedUlidString(later) {
+		t.Fatalf("Expected well formed ULIDs, got %s and %s", first, later)
+	}
+	if first == second {
+		t.Fatalf("Expected ULIDs generated at the same time to differ, got %s twice", first)
+	}
+	if first[:10] != second[:10] || later[:10] <= first[:10] {
+		t.Fatalf("Expected the timestamp part to sort by time, got %s and %s", first, later)
+	}
+	if got := NewUlidString(time.UnixMilli(0))[:10]; got != "0000000000" {
+		t.Fatalf("Expected the epoch to encode as zeros, got %s", got)
+	}
+}
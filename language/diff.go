@@ -0,0 +1,385 @@
+package language
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// ModelChangeKind describes how an element differs between two models
+type ModelChangeKind string
+
+// List of ModelChangeKind
+const (
+	MODEL_CHANGE_ADDED   ModelChangeKind = "added"
+	MODEL_CHANGE_REMOVED ModelChangeKind = "removed"
+	MODEL_CHANGE_CHANGED ModelChangeKind = "changed"
+)
+
+// TypeDiff is a type that was added to or removed from the model
+type TypeDiff struct {
+	Type     string          `json:"type"`
+	Change   ModelChangeKind `json:"change"`
+	Breaking bool            `json:"breaking"`
+}
+
+// RelationDiff is a relation that was added, removed or whose rewrite or directly related user types changed. Before and
+// After hold the rewrites as rendered by RenderRelation, with directly related user types sorted; Before is empty for added
+// relations and After is empty for removed ones.
+type RelationDiff struct {
+	Type             string          `json:"type"`
+	Relation         string          `json:"relation"`
+	Change           ModelChangeKind `json:"change"`
+	Before           string          `json:"before,omitempty"`
+	After            string          `json:"after,omitempty"`
+	AddedUserTypes   []string        `json:"added_user_types,omitempty"`
+	RemovedUserTypes []string        `json:"removed_user_types,omitempty"`
+	Breaking         bool            `json:"breaking"`
+	BreakingReasons  []string        `json:"breaking_reasons,omitempty"`
+}
+
+// ConditionDiff is a condition that was added, removed or whose parameters or expression changed. Before and After hold
+// the condition signatures, e.g. condition name(param: int), and BeforeExpression and AfterExpression the CEL expressions.
+type ConditionDiff struct {
+	Name             string          `json:"name"`
+	Change           ModelChangeKind `json:"change"`
+	Before           string          `json:"before,omitempty"`
+	After            string          `json:"after,omitempty"`
+	BeforeExpression string          `json:"before_expression,omitempty"`
+	AfterExpression  string          `json:"after_expression,omitempty"`
+	Breaking         bool            `json:"breaking"`
+	BreakingReasons  []string        `json:"breaking_reasons,omitempty"`
+}
+
+// ModelDiff is the semantic difference between two authorization models, as returned by DiffAuthorizationModels.
+//
+// A change is flagged as breaking when tuples that were valid under the old model may be rejected or ignored under the new
+// one. Whether such tuples actually exist can only be told by reading the store, e.g. with Read filtered by the type.
+type ModelDiff struct {
+	Types      []TypeDiff      `json:"types,omitempty"`
+	Relations  []RelationDiff  `json:"relations,omitempty"`
+	Conditions []ConditionDiff `json:"conditions,omitempty"`
+}
+
+// HasChanges reports whether the two models differ
+func (d ModelDiff) HasChanges() bool {
+	return len(d.Types) > 0 || len(d.Relations) > 0 || len(d.Conditions) > 0
+}
+
+// IsBreaking reports whether any of the changes is breaking for existing tuples
+func (d ModelDiff) IsBreaking() bool {
+	for _, typeDiff := range d.Types {
+		if typeDiff.Breaking {
+			return true
+		}
+	}
+	for _, relationDiff := range d.Relations {
+		if relationDiff.Breaking {
+			return true
+		}
+	}
+	for _, conditionDiff := range d.Conditions {
+		if conditionDiff.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the diff in a readable, line based format suitable for code reviews. Added elements are prefixed with
+// "+", removed ones with "-", changed ones with "~" and the reasons a change is breaking with "!".
+func (d ModelDiff) String() string {
+	if !d.HasChanges() {
+		return "no changes\n"
+	}
+
+	var builder strings.Builder
+	for _, typeDiff := range d.Types {
+		builder.WriteString(changePrefix(typeDiff.Change) + " type " + typeDiff.Type + breakingSuffix(typeDiff.Breaking) + "\n")
+	}
+
+	for _, relationDiff := range d.Relations {
+		name := relationDiff.Type + "#" + relationDiff.Relation
+		switch relationDiff.Change {
+		case MODEL_CHANGE_ADDED:
+			builder.WriteString("+ define " + name + ": " + relationDiff.After + "\n")
+		case MODEL_CHANGE_REMOVED:
+			builder.WriteString("- define " + name + ": " + relationDiff.Before + breakingSuffix(relationDiff.Breaking) + "\n")
+		default:
+			builder.WriteString("~ define " + name + breakingSuffix(relationDiff.Breaking) + "\n")
+			if relationDiff.Before != relationDiff.After {
+				builder.WriteString("    - " + relationDiff.Before + "\n")
+				builder.WriteString("    + " + relationDiff.After + "\n")
+			}
+		}
+		writeBreakingReasons(&builder, relationDiff.BreakingReasons)
+	}
+
+	for _, conditionDiff := range d.Conditions {
+		switch conditionDiff.Change {
+		case MODEL_CHANGE_ADDED:
+			builder.WriteString("+ " + conditionDiff.After + "\n")
+		case MODEL_CHANGE_REMOVED:
+			builder.WriteString("- " + conditionDiff.Before + breakingSuffix(conditionDiff.Breaking) + "\n")
+		default:
+			builder.WriteString("~ condition " + conditionDiff.Name + breakingSuffix(conditionDiff.Breaking) + "\n")
+			if conditionDiff.Before != conditionDiff.After {
+				builder.WriteString("    - " + conditionDiff.Before + "\n")
+				builder.WriteString("    + " + conditionDiff.After + "\n")
+			}
+			if conditionDiff.BeforeExpression != conditionDiff.AfterExpression {
+				builder.WriteString("    - { " + conditionDiff.BeforeExpression + " }\n")
+				builder.WriteString("    + { " + conditionDiff.AfterExpression + " }\n")
+			}
+		}
+		writeBreakingReasons(&builder, conditionDiff.BreakingReasons)
+	}
+
+	return builder.String()
+}
+
+func changePrefix(change ModelChangeKind) string {
+	switch change {
+	case MODEL_CHANGE_ADDED:
+		return "+"
+	case MODEL_CHANGE_REMOVED:
+		return "-"
+	}
+	return "~"
+}
+
+func breakingSuffix(breaking bool) string {
+	if breaking {
+		return " (breaking)"
+	}
+	return ""
+}
+
+func writeBreakingReasons(builder *strings.Builder, reasons []string) {
+	for _, reason := range reasons {
+		builder.WriteString("    ! " + reason + "\n")
+	}
+}
+
+// DiffAuthorizationModels compares two models and reports the types, relations and conditions that were added, removed
+// or changed going from oldModel to newModel. Types are reported in the order of newModel followed by the removed ones,
+// and relations and conditions are sorted by name.
+func DiffAuthorizationModels(oldModel fgaSdk.AuthorizationModel, newModel fgaSdk.AuthorizationModel) ModelDiff {
+	diff := ModelDiff{}
+
+	oldTypes := make(map[string]fgaSdk.TypeDefinition)
+	for _, typeDefinition := range uniqueTypeDefinitions(oldModel.TypeDefinitions) {
+		oldTypes[typeDefinition.Type] = typeDefinition
+	}
+	newTypes := make(map[string]fgaSdk.TypeDefinition)
+	typeNames := []string{}
+	for _, typeDefinition := range uniqueTypeDefinitions(newModel.TypeDefinitions) {
+		newTypes[typeDefinition.Type] = typeDefinition
+		typeNames = append(typeNames, typeDefinition.Type)
+	}
+	for _, typeDefinition := range uniqueTypeDefinitions(oldModel.TypeDefinitions) {
+		if _, ok := newTypes[typeDefinition.Type]; !ok {
+			typeNames = append(typeNames, typeDefinition.Type)
+		}
+	}
+
+	for _, typeName := range typeNames {
+		oldType, inOld := oldTypes[typeName]
+		newType, inNew := newTypes[typeName]
+		switch {
+		case !inOld:
+			diff.Types = append(diff.Types, TypeDiff{Type: typeName, Change: MODEL_CHANGE_ADDED})
+		case !inNew:
+			diff.Types = append(diff.Types, TypeDiff{Type: typeName, Change: MODEL_CHANGE_REMOVED, Breaking: true})
+		}
+		diff.Relations = append(diff.Relations, diffRelations(typeName, oldType, newType)...)
+	}
+
+	oldConditions := oldModel.GetConditions()
+	newConditions := newModel.GetConditions()
+	for _, name := range sortedKeys(mergeKeys(oldConditions, newConditions)) {
+		oldCondition, inOld := oldConditions[name]
+		newCondition, inNew := newConditions[name]
+		if conditionDiff, changed := diffCondition(name, oldCondition, inOld, newCondition, inNew); changed {
+			diff.Conditions = append(diff.Conditions, conditionDiff)
+		}
+	}
+
+	return diff
+}
+
+// diffRelations compares the relations of a type; a type missing from one of the models has no relations in it
+func diffRelations(typeName string, oldType fgaSdk.TypeDefinition, newType fgaSdk.TypeDefinition) []RelationDiff {
+	oldRelations := oldType.GetRelations()
+	newRelations := newType.GetRelations()
+
+	var diffs []RelationDiff
+	for _, relation := range sortedKeys(mergeKeys(oldRelations, newRelations)) {
+		oldUserset, inOld := oldRelations[relation]
+		newUserset, inNew := newRelations[relation]
+		oldUserTypes := sortedDirectlyRelatedUserTypes(oldType, relation)
+		newUserTypes := sortedDirectlyRelatedUserTypes(newType, relation)
+
+		relationDiff := RelationDiff{Type: typeName, Relation: relation}
+		if inOld {
+			relationDiff.Before = renderRewriteForDiff(typeName, relation, oldUserset, oldUserTypes)
+		}
+		if inNew {
+			relationDiff.After = renderRewriteForDiff(typeName, relation, newUserset, newUserTypes)
+		}
+
+		switch {
+		case !inOld:
+			relationDiff.Change = MODEL_CHANGE_ADDED
+		case !inNew:
+			relationDiff.Change = MODEL_CHANGE_REMOVED
+			if usesThis(oldUserset) {
+				relationDiff.Breaking = true
+				relationDiff.BreakingReasons = append(relationDiff.BreakingReasons,
+					fmt.Sprintf("existing tuples for relation '%s' of type '%s' are no longer valid", relation, typeName))
+			}
+		default:
+			relationDiff.Change = MODEL_CHANGE_CHANGED
+			oldRendered := renderedReferences(oldUserTypes, usesThis(oldUserset))
+			newRendered := renderedReferences(newUserTypes, usesThis(newUserset))
+			relationDiff.AddedUserTypes = missingFrom(newRendered, oldRendered)
+			relationDiff.RemovedUserTypes = missingFrom(oldRendered, newRendered)
+			for _, userType := range relationDiff.RemovedUserTypes {
+				relationDiff.Breaking = true
+				relationDiff.BreakingReasons = append(relationDiff.BreakingReasons,
+					fmt.Sprintf("user type '%s' can no longer be directly related, existing tuples with it are no longer valid", userType))
+			}
+			if relationDiff.Before == relationDiff.After && len(relationDiff.AddedUserTypes) == 0 && len(relationDiff.RemovedUserTypes) == 0 {
+				continue
+			}
+		}
+		diffs = append(diffs, relationDiff)
+	}
+	return diffs
+}
+
+// sortedDirectlyRelatedUserTypes returns the directly related user types of a relation sorted by their rendering
+func sortedDirectlyRelatedUserTypes(typeDefinition fgaSdk.TypeDefinition, relation string) []fgaSdk.RelationReference {
+	references, _ := directlyRelatedUserTypes(typeDefinition, relation)
+	sorted := append([]fgaSdk.RelationReference{}, references...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return RenderRelationReference(sorted[i]) < RenderRelationReference(sorted[j])
+	})
+	return sorted
+}
+
+func renderRewriteForDiff(typeName string, relation string, userset fgaSdk.Userset, references []fgaSdk.RelationReference) string {
+	rendered, err := renderRewrite(typeName, relation, userset, references)
+	if err != nil {
+		return "<" + err.Error() + ">"
+	}
+	return rendered
+}
+
+// renderedReferences renders the directly related user types of a relation, which only matter when it is directly
+// assignable
+func renderedReferences(references []fgaSdk.RelationReference, directlyAssignable bool) []string {
+	if !directlyAssignable {
+		return nil
+	}
+	rendered := make([]string, 0, len(references))
+	for _, reference := range references {
+		rendered = append(rendered, RenderRelationReference(reference))
+	}
+	return rendered
+}
+
+// missingFrom returns the values of from that are not in other, keeping their order
+func missingFrom(from []string, other []string) []string {
+	present := make(map[string]bool, len(other))
+	for _, value := range other {
+		present[value] = true
+	}
+	var missing []string
+	for _, value := range from {
+		if !present[value] {
+			missing = append(missing, value)
+		}
+	}
+	return missing
+}
+
+func mergeKeys[T any](first map[string]T, second map[string]T) map[string]bool {
+	keys := make(map[string]bool, len(first)+len(second))
+	for key := range first {
+		keys[key] = true
+	}
+	for key := range second {
+		keys[key] = true
+	}
+	return keys
+}
+
+func diffCondition(name string, oldCondition fgaSdk.Condition, inOld bool, newCondition fgaSdk.Condition, inNew bool) (ConditionDiff, bool) {
+	conditionDiff := ConditionDiff{Name: name}
+	if inOld {
+		conditionDiff.Before = renderConditionSignature(name, oldCondition)
+		conditionDiff.BeforeExpression = normalizeExpression(oldCondition.Expression)
+	}
+	if inNew {
+		conditionDiff.After = renderConditionSignature(name, newCondition)
+		conditionDiff.AfterExpression = normalizeExpression(newCondition.Expression)
+	}
+
+	switch {
+	case !inOld:
+		conditionDiff.Change = MODEL_CHANGE_ADDED
+		return conditionDiff, true
+	case !inNew:
+		conditionDiff.Change = MODEL_CHANGE_REMOVED
+		conditionDiff.Breaking = true
+		conditionDiff.BreakingReasons = []string{fmt.Sprintf("existing tuples with condition '%s' are no longer valid", name)}
+		return conditionDiff, true
+	}
+
+	conditionDiff.Change = MODEL_CHANGE_CHANGED
+	oldParameters := oldCondition.GetParameters()
+	newParameters := newCondition.GetParameters()
+	for _, parameter := range sortedKeys(oldParameters) {
+		newParameter, ok := newParameters[parameter]
+		if !ok {
+			conditionDiff.BreakingReasons = append(conditionDiff.BreakingReasons,
+				fmt.Sprintf("parameter '%s' was removed, existing tuples may carry it in their context", parameter))
+			continue
+		}
+		oldTypeName := conditionParamTypeName(oldParameters[parameter])
+		newTypeName := conditionParamTypeName(newParameter)
+		if oldTypeName != newTypeName {
+			conditionDiff.BreakingReasons = append(conditionDiff.BreakingReasons,
+				fmt.Sprintf("parameter '%s' changed type from %s to %s, existing tuple contexts may no longer match", parameter, oldTypeName, newTypeName))
+		}
+	}
+	conditionDiff.Breaking = len(conditionDiff.BreakingReasons) > 0
+
+	changed := conditionDiff.Before != conditionDiff.After || conditionDiff.BeforeExpression != conditionDiff.AfterExpression
+	return conditionDiff, changed
+}
+
+func renderConditionSignature(name string, condition fgaSdk.Condition) string {
+	parameters := condition.GetParameters()
+	rendered := make([]string, 0, len(parameters))
+	for _, parameter := range sortedKeys(parameters) {
+		rendered = append(rendered, parameter+": "+conditionParamTypeName(parameters[parameter]))
+	}
+	return "condition " + name + "(" + strings.Join(rendered, ", ") + ")"
+}
+
+// conditionParamTypeName renders a parameter type, falling back to the raw type name for types the DSL cannot express
+func conditionParamTypeName(typeRef fgaSdk.ConditionParamTypeRef) string {
+	if rendered, err := RenderConditionParamType(typeRef); err == nil {
+		return rendered
+	}
+	return string(typeRef.TypeName)
+}
+
+// normalizeExpression collapses the whitespace of an expression so that reformatting is not reported as a change
+func normalizeExpression(expression string) string {
+	return strings.Join(strings.Fields(expression), " ")
+}
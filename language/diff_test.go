@@ -0,0 +1,183 @@
+package language
+
+import (
+	"strings"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func mustParseModel(t *testing.T, dsl string) fgaSdk.AuthorizationModel {
+	t.Helper()
+
+	request := mustParse(t, dsl)
+	return fgaSdk.AuthorizationModel{
+		SchemaVersion:   request.SchemaVersion,
+		TypeDefinitions: request.TypeDefinitions,
+		Conditions:      request.Conditions,
+	}
+}
+
+func findRelationDiff(diff ModelDiff, typeName string, relation string) *RelationDiff {
+	for index := range diff.Relations {
+		if diff.Relations[index].Type == typeName && diff.Relations[index].Relation == relation {
+			return &diff.Relations[index]
+		}
+	}
+	return nil
+}
+
+func TestDiffAuthorizationModelsNoChanges(t *testing.T) {
+	model := mustParseModel(t, testModelDSL)
+	reordered := mustParseModel(t, strings.Replace(testModelDSL, "[user, group#member]", "[group#member, user]", 1))
+
+	diff := DiffAuthorizationModels(model, reordered)
+
+	if diff.HasChanges() || diff.IsBreaking() {
+		t.Fatalf("Expected no changes, got %s", diff)
+	}
+	if diff.String() != "no changes\n" {
+		t.Fatalf("String() = %q", diff.String())
+	}
+}
+
+func TestDiffAuthorizationModels(t *testing.T) {
+	oldModel := mustParseModel(t, `model
+  schema 1.1
+type user
+type legacy
+type folder
+  relations
+    define viewer: [user, user:*]
+type document
+  relations
+    define owner: [user]
+    define archived_by: [user]
+    define computed: owner
+    define viewer: [user] or owner
+condition in_range(x: int, y: string) {
+  x < 100
+}
+condition unused(x: int) {
+  x > 1
+}
+`)
+	newModel := mustParseModel(t, `model
+  schema 1.1
+type user
+type team
+  relations
+    define member: [user]
+type folder
+  relations
+    define viewer: [user, team#member]
+type document
+  relations
+    define owner: [user with in_range]
+    define viewer: [user, team#member] or owner
+condition in_range(x: string, z: int) {
+  x == "a"
+}
+condition fresh(x: int) {
+  x > 1
+}
+`)
+
+	diff := DiffAuthorizationModels(oldModel, newModel)
+
+	if !diff.HasChanges() || !diff.IsBreaking() {
+		t.Fatalf("Expected breaking changes, got %s", diff)
+	}
+
+	expectedTypes := []TypeDiff{
+		{Type: "team", Change: MODEL_CHANGE_ADDED},
+		{Type: "legacy", Change: MODEL_CHANGE_REMOVED, Breaking: true},
+	}
+	if len(diff.Types) != len(expectedTypes) {
+		t.Fatalf("Expected %d type changes, got %v", len(expectedTypes), diff.Types)
+	}
+	for index, expected := range expectedTypes {
+		if diff.Types[index] != expected {
+			t.Fatalf("Types[%d] = %v, want %v", index, diff.Types[index], expected)
+		}
+	}
+
+	added := findRelationDiff(diff, "team", "member")
+	if added == nil || added.Change != MODEL_CHANGE_ADDED || added.After != "[user]" || added.Breaking {
+		t.Fatalf("Unexpected diff for team#member: %+v", added)
+	}
+
+	removed := findRelationDiff(diff, "document", "archived_by")
+	if removed == nil || removed.Change != MODEL_CHANGE_REMOVED || removed.Before != "[user]" || !removed.Breaking {
+		t.Fatalf("Unexpected diff for document#archived_by: %+v", removed)
+	}
+
+	removedComputed := findRelationDiff(diff, "document", "computed")
+	if removedComputed == nil || removedComputed.Change != MODEL_CHANGE_REMOVED || removedComputed.Breaking {
+		t.Fatalf("Unexpected diff for document#computed: %+v", removedComputed)
+	}
+
+	folderViewer := findRelationDiff(diff, "folder", "viewer")
+	if folderViewer == nil || folderViewer.Change != MODEL_CHANGE_CHANGED || !folderViewer.Breaking {
+		t.Fatalf("Unexpected diff for folder#viewer: %+v", folderViewer)
+	}
+	if folderViewer.Before != "[user, user:*]" || folderViewer.After != "[team#member, user]" {
+		t.Fatalf("Unexpected rewrites for folder#viewer: %q -> %q", folderViewer.Before, folderViewer.After)
+	}
+	if strings.Join(folderViewer.AddedUserTypes, ",") != "team#member" || strings.Join(folderViewer.RemovedUserTypes, ",") != "user:*" {
+		t.Fatalf("Unexpected user type changes for folder#viewer: %+v", folderViewer)
+	}
+
+	owner := findRelationDiff(diff, "document", "owner")
+	if owner == nil || !owner.Breaking || strings.Join(owner.RemovedUserTypes, ",") != "user" ||
+		strings.Join(owner.AddedUserTypes, ",") != "user with in_range" {
+		t.Fatalf("Unexpected diff for document#owner: %+v", owner)
+	}
+
+	viewer := findRelationDiff(diff, "document", "viewer")
+	if viewer == nil || viewer.Breaking || viewer.Before != "[user] or owner" || viewer.After != "[team#member, user] or owner" {
+		t.Fatalf("Unexpected diff for document#viewer: %+v", viewer)
+	}
+
+	if len(diff.Conditions) != 3 {
+		t.Fatalf("Expected 3 condition changes, got %+v", diff.Conditions)
+	}
+	fresh, inRange, unused := diff.Conditions[0], diff.Conditions[1], diff.Conditions[2]
+	if fresh.Name != "fresh" || fresh.Change != MODEL_CHANGE_ADDED || fresh.Breaking {
+		t.Fatalf("Unexpected diff for condition fresh: %+v", fresh)
+	}
+	if unused.Name != "unused" || unused.Change != MODEL_CHANGE_REMOVED || !unused.Breaking {
+		t.Fatalf("Unexpected diff for condition unused: %+v", unused)
+	}
+	if inRange.Name != "in_range" || inRange.Change != MODEL_CHANGE_CHANGED || !inRange.Breaking || len(inRange.BreakingReasons) != 2 {
+		t.Fatalf("Unexpected diff for condition in_range: %+v", inRange)
+	}
+	if inRange.Before != "condition in_range(x: int, y: string)" || inRange.After != "condition in_range(x: string, z: int)" ||
+		inRange.BeforeExpression != "x < 100" || inRange.AfterExpression != `x == "a"` {
+		t.Fatalf("Unexpected rendering for condition in_range: %+v", inRange)
+	}
+
+	rendered := diff.String()
+	for _, expected := range []string{
+		"+ type team\n",
+		"- type legacy (breaking)\n",
+		"+ define team#member: [user]\n",
+		"- define document#archived_by: [user] (breaking)\n",
+		"~ define folder#viewer (breaking)\n    - [user, user:*]\n    + [team#member, user]\n    ! user type 'user:*'",
+		"~ condition in_range (breaking)\n",
+		"    - { x < 100 }\n    + { x == \"a\" }\n",
+	} {
+		if !strings.Contains(rendered, expected) {
+			t.Fatalf("Expected String() to contain %q, got\n%s", expected, rendered)
+		}
+	}
+}
+
+func TestDiffAuthorizationModelsIgnoresExpressionFormatting(t *testing.T) {
+	oldModel := mustParseModel(t, "model\n  schema 1.1\ntype user\ncondition valid(x: int) {\n  x > 1 &&\n  x < 10\n}\n")
+	newModel := mustParseModel(t, "model\n  schema 1.1\ntype user\ncondition valid(x: int) {\n  x > 1 && x < 10\n}\n")
+
+	if diff := DiffAuthorizationModels(oldModel, newModel); diff.HasChanges() {
+		t.Fatalf("Expected no changes, got %s", diff)
+	}
+}
@@ -0,0 +1,45 @@
+// Package language transforms authorization models between the OpenFGA DSL and the JSON structures accepted by the API.
+//
+//	model
+//	  schema 1.1
+//
+//	type user
+//
+//	type document
+//	  relations
+//	    define owner: [user]
+//	    define viewer: ([user, user:*, group#member with non_expired] or owner or viewer from parent) but not blocked
+//
+//	condition non_expired(current_time: timestamp, expires_at: timestamp) {
+//	  current_time < expires_at
+//	}
+//
+// Modular models (module and extend type) are not supported.
+package language
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxError describes a problem found while parsing a DSL document. Line and Column are 1-based.
+type SyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// SyntaxErrors is returned when a DSL document has one or more syntax errors, in the order they appear in the document
+type SyntaxErrors []*SyntaxError
+
+func (e SyntaxErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
@@ -0,0 +1,194 @@
+package language
+
+import (
+	"fmt"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenLeftBracket
+	tokenRightBracket
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+	tokenColon
+	tokenHash
+	tokenStar
+	tokenLeftAngle
+	tokenRightAngle
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of line"
+	case tokenIdentifier:
+		return "identifier"
+	case tokenLeftBracket:
+		return "'['"
+	case tokenRightBracket:
+		return "']'"
+	case tokenLeftParen:
+		return "'('"
+	case tokenRightParen:
+		return "')'"
+	case tokenComma:
+		return "','"
+	case tokenColon:
+		return "':'"
+	case tokenHash:
+		return "'#'"
+	case tokenStar:
+		return "'*'"
+	case tokenLeftAngle:
+		return "'<'"
+	case tokenRightAngle:
+		return "'>'"
+	}
+	return "unknown token"
+}
+
+type token struct {
+	kind   tokenKind
+	value  string
+	line   int
+	column int
+}
+
+func (t token) String() string {
+	if t.kind == tokenIdentifier {
+		return fmt.Sprintf("'%s'", t.value)
+	}
+	return t.kind.String()
+}
+
+func isIdentifierChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-' || c == '/' || c == '.'
+}
+
+// tokenize splits a single line of DSL, starting at the given column, into tokens. Comments must have been stripped already.
+func tokenize(text string, line int, column int) ([]token, error) {
+	var tokens []token
+
+	for index := 0; index < len(text); {
+		c := text[index]
+		tokenColumn := column + index
+
+		if c == ' ' || c == '\t' {
+			index++
+			continue
+		}
+
+		if isIdentifierChar(c) {
+			start := index
+			for index < len(text) && isIdentifierChar(text[index]) {
+				index++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, value: text[start:index], line: line, column: tokenColumn})
+			continue
+		}
+
+		var kind tokenKind
+		switch c {
+		case '[':
+			kind = tokenLeftBracket
+		case ']':
+			kind = tokenRightBracket
+		case '(':
+			kind = tokenLeftParen
+		case ')':
+			kind = tokenRightParen
+		case ',':
+			kind = tokenComma
+		case ':':
+			kind = tokenColon
+		case '#':
+			kind = tokenHash
+		case '*':
+			kind = tokenStar
+		case '<':
+			kind = tokenLeftAngle
+		case '>':
+			kind = tokenRightAngle
+		default:
+			return nil, &SyntaxError{Line: line, Column: tokenColumn, Message: fmt.Sprintf("unexpected character '%c'", c)}
+		}
+		tokens = append(tokens, token{kind: kind, value: string(c), line: line, column: tokenColumn})
+		index++
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, line: line, column: column + len(text)})
+	return tokens, nil
+}
+
+// tokenStream is a cursor over the tokens of a line
+type tokenStream struct {
+	tokens   []token
+	position int
+}
+
+func (s *tokenStream) peek() token {
+	return s.tokens[s.position]
+}
+
+func (s *tokenStream) peekAt(offset int) token {
+	if s.position+offset >= len(s.tokens) {
+		return s.tokens[len(s.tokens)-1]
+	}
+	return s.tokens[s.position+offset]
+}
+
+func (s *tokenStream) next() token {
+	t := s.tokens[s.position]
+	if t.kind != tokenEOF {
+		s.position++
+	}
+	return t
+}
+
+func (s *tokenStream) isKeyword(keyword string) bool {
+	t := s.peek()
+	return t.kind == tokenIdentifier && t.value == keyword
+}
+
+func (s *tokenStream) expect(kind tokenKind) (token, error) {
+	t := s.next()
+	if t.kind != kind {
+		return t, unexpectedToken(t, kind.String())
+	}
+	return t, nil
+}
+
+func (s *tokenStream) expectKeyword(keyword string) error {
+	t := s.next()
+	if t.kind != tokenIdentifier || t.value != keyword {
+		return unexpectedToken(t, "'"+keyword+"'")
+	}
+	return nil
+}
+
+func (s *tokenStream) expectName(what string) (token, error) {
+	t := s.next()
+	if t.kind != tokenIdentifier {
+		return t, unexpectedToken(t, what)
+	}
+	if isReservedKeyword(t.value) {
+		return t, &SyntaxError{Line: t.line, Column: t.column, Message: fmt.Sprintf("'%s' is a reserved keyword and cannot be used as a %s", t.value, what)}
+	}
+	return t, nil
+}
+
+func unexpectedToken(t token, expected string) *SyntaxError {
+	return &SyntaxError{Line: t.line, Column: t.column, Message: fmt.Sprintf("expected %s but found %s", expected, t)}
+}
+
+func isReservedKeyword(value string) bool {
+	switch value {
+	case "or", "and", "but", "not", "from", "with", "define", "self", "this":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package language
+
+import (
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens, err := tokenize("define viewer: [user:*, group#member]", 3, 5)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expected := []struct {
+		kind   tokenKind
+		value  string
+		column int
+	}{
+		{tokenIdentifier, "define", 5},
+		{tokenIdentifier, "viewer", 12},
+		{tokenColon, ":", 18},
+		{tokenLeftBracket, "[", 20},
+		{tokenIdentifier, "user", 21},
+		{tokenColon, ":", 25},
+		{tokenStar, "*", 26},
+		{tokenComma, ",", 27},
+		{tokenIdentifier, "group", 29},
+		{tokenHash, "#", 34},
+		{tokenIdentifier, "member", 35},
+		{tokenRightBracket, "]", 41},
+		{tokenEOF, "", 42},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expected), len(tokens), tokens)
+	}
+	for index, want := range expected {
+		got := tokens[index]
+		if got.kind != want.kind || got.value != want.value || got.column != want.column || got.line != 3 {
+			t.Errorf("token %d = %+v, want %+v", index, got, want)
+		}
+	}
+}
+
+func TestTokenizeUnexpectedCharacter(t *testing.T) {
+	_, err := tokenize("define viewer: [user] | owner", 1, 1)
+
+	syntaxError, ok := err.(*SyntaxError)
+	if !ok {
+		t.Fatalf("Expected a SyntaxError, got %v", err)
+	}
+	if syntaxError.Line != 1 || syntaxError.Column != 23 {
+		t.Fatalf("Expected error at 1:23, got %d:%d", syntaxError.Line, syntaxError.Column)
+	}
+}
+
+func TestStripComment(t *testing.T) {
+	tests := map[string]string{
+		"# comment":                             "",
+		"  define viewer: [group#member]":       "  define viewer: [group#member]",
+		"  define viewer: [user] # comment":     "  define viewer: [user]",
+		"type user\t# comment":                  "type user",
+		"  define viewer: [group#member] #note": "  define viewer: [group#member]",
+	}
+
+	for line, expected := range tests {
+		if got := stripComment(line); got != expected {
+			t.Errorf("stripComment(%q) = %q, want %q", line, got, expected)
+		}
+	}
+}
@@ -0,0 +1,599 @@
+package language
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+const supportedSchemaVersion = "1.1"
+
+var conditionParamTypeNames = map[string]fgaSdk.TypeName{
+	"any":       fgaSdk.TYPENAME_ANY,
+	"bool":      fgaSdk.TYPENAME_BOOL,
+	"string":    fgaSdk.TYPENAME_STRING,
+	"int":       fgaSdk.TYPENAME_INT,
+	"uint":      fgaSdk.TYPENAME_UINT,
+	"double":    fgaSdk.TYPENAME_DOUBLE,
+	"duration":  fgaSdk.TYPENAME_DURATION,
+	"timestamp": fgaSdk.TYPENAME_TIMESTAMP,
+	"map":       fgaSdk.TYPENAME_MAP,
+	"list":      fgaSdk.TYPENAME_LIST,
+	"ipaddress": fgaSdk.TYPENAME_IPADDRESS,
+}
+
+// TransformDSLToModel parses a model written in the OpenFGA DSL into a request that can be passed to WriteAuthorizationModel.
+// If the document has syntax errors, the returned error is a SyntaxErrors listing all of them.
+func TransformDSLToModel(dsl string) (*fgaSdk.WriteAuthorizationModelRequest, error) {
+	p := &parser{
+		lines:      strings.Split(strings.ReplaceAll(dsl, "\r\n", "\n"), "\n"),
+		typeIndex:  make(map[string]int),
+		conditions: make(map[string]fgaSdk.Condition),
+	}
+	p.parse()
+
+	if len(p.errors) > 0 {
+		return nil, p.errors
+	}
+
+	model := &fgaSdk.WriteAuthorizationModelRequest{
+		SchemaVersion:   p.schemaVersion,
+		TypeDefinitions: p.typeDefinitions,
+	}
+	if model.TypeDefinitions == nil {
+		model.TypeDefinitions = []fgaSdk.TypeDefinition{}
+	}
+	if len(p.conditions) > 0 {
+		model.Conditions = &p.conditions
+	}
+	return model, nil
+}
+
+type parserState int
+
+const (
+	stateModel parserState = iota
+	stateSchema
+	stateBody
+)
+
+type parser struct {
+	lines []string
+	// lineIndex is the 0-based index of the line being parsed
+	lineIndex int
+	state     parserState
+	errors    SyntaxErrors
+
+	schemaVersion   string
+	typeDefinitions []fgaSdk.TypeDefinition
+	typeIndex       map[string]int
+	conditions      map[string]fgaSdk.Condition
+
+	// currentType is the index of the type whose relations are being parsed, or -1
+	currentType int
+	inRelations bool
+}
+
+func (p *parser) addError(err error) {
+	var syntaxError *SyntaxError
+	if errors.As(err, &syntaxError) {
+		p.errors = append(p.errors, syntaxError)
+		return
+	}
+	p.errors = append(p.errors, &SyntaxError{Line: p.lineIndex + 1, Column: 1, Message: err.Error()})
+}
+
+func (p *parser) errorf(column int, format string, args ...interface{}) {
+	p.errors = append(p.errors, &SyntaxError{Line: p.lineIndex + 1, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) parse() {
+	p.currentType = -1
+
+	for p.lineIndex = 0; p.lineIndex < len(p.lines); p.lineIndex++ {
+		text := stripComment(p.lines[p.lineIndex])
+		trimmed := strings.TrimLeft(text, " \t")
+		if strings.TrimSpace(trimmed) == "" {
+			continue
+		}
+		column := len(text) - len(trimmed) + 1
+
+		if strings.HasPrefix(trimmed, "condition") && (len(trimmed) == len("condition") || trimmed[len("condition")] == ' ' || trimmed[len("condition")] == '\t') {
+			p.parseCondition(column)
+			continue
+		}
+
+		tokens, err := tokenize(trimmed, p.lineIndex+1, column)
+		if err != nil {
+			p.addError(err)
+			continue
+		}
+		if err := p.parseLine(&tokenStream{tokens: tokens}); err != nil {
+			p.addError(err)
+		}
+	}
+
+	switch p.state {
+	case stateModel:
+		p.errors = append(p.errors, &SyntaxError{Line: 1, Column: 1, Message: "expected 'model' but found end of document"})
+	case stateSchema:
+		p.errors = append(p.errors, &SyntaxError{Line: len(p.lines), Column: 1, Message: "expected 'schema' but found end of document"})
+	}
+}
+
+// stripComment removes a comment that starts at the beginning of the line or after whitespace; a '#' directly following
+// a name (as in group#member) is part of the definition
+func stripComment(line string) string {
+	for index := 0; index < len(line); index++ {
+		if line[index] == '#' && (index == 0 || line[index-1] == ' ' || line[index-1] == '\t') {
+			return strings.TrimRight(line[:index], " \t")
+		}
+	}
+	return strings.TrimRight(line, " \t")
+}
+
+func (p *parser) parseLine(stream *tokenStream) error {
+	first := stream.peek()
+
+	switch p.state {
+	case stateModel:
+		if err := stream.expectKeyword("model"); err != nil {
+			return err
+		}
+		p.state = stateSchema
+		_, err := stream.expect(tokenEOF)
+		return err
+	case stateSchema:
+		if err := stream.expectKeyword("schema"); err != nil {
+			return err
+		}
+		version, err := stream.expect(tokenIdentifier)
+		if err != nil {
+			return err
+		}
+		if version.value != supportedSchemaVersion {
+			return &SyntaxError{Line: version.line, Column: version.column, Message: fmt.Sprintf("unsupported schema version '%s', only %s is supported", version.value, supportedSchemaVersion)}
+		}
+		p.schemaVersion = version.value
+		p.state = stateBody
+		_, err = stream.expect(tokenEOF)
+		return err
+	}
+
+	if first.kind != tokenIdentifier {
+		return unexpectedToken(first, "'type', 'relations', 'define' or 'condition'")
+	}
+
+	switch first.value {
+	case "type":
+		return p.parseType(stream)
+	case "relations":
+		stream.next()
+		if p.currentType < 0 {
+			return &SyntaxError{Line: first.line, Column: first.column, Message: "'relations' must follow a type definition"}
+		}
+		if p.inRelations {
+			return &SyntaxError{Line: first.line, Column: first.column, Message: fmt.Sprintf("type '%s' already has a relations block", p.typeDefinitions[p.currentType].Type)}
+		}
+		p.inRelations = true
+		_, err := stream.expect(tokenEOF)
+		return err
+	case "define":
+		if !p.inRelations {
+			return &SyntaxError{Line: first.line, Column: first.column, Message: "'define' must be inside the relations block of a type"}
+		}
+		return p.parseDefine(stream)
+	case "module", "extend":
+		return &SyntaxError{Line: first.line, Column: first.column, Message: "modular models are not supported"}
+	case "model", "schema":
+		return &SyntaxError{Line: first.line, Column: first.column, Message: fmt.Sprintf("'%s' can only appear once, at the start of the model", first.value)}
+	}
+
+	return unexpectedToken(first, "'type', 'relations', 'define' or 'condition'")
+}
+
+func (p *parser) parseType(stream *tokenStream) error {
+	stream.next()
+	name, err := stream.expectName("type name")
+	if err != nil {
+		return err
+	}
+	if _, err := stream.expect(tokenEOF); err != nil {
+		return err
+	}
+
+	p.currentType = -1
+	p.inRelations = false
+	if _, exists := p.typeIndex[name.value]; exists {
+		return &SyntaxError{Line: name.line, Column: name.column, Message: fmt.Sprintf("type '%s' is already defined", name.value)}
+	}
+
+	relations := make(map[string]fgaSdk.Userset)
+	p.typeIndex[name.value] = len(p.typeDefinitions)
+	p.currentType = len(p.typeDefinitions)
+	p.typeDefinitions = append(p.typeDefinitions, fgaSdk.TypeDefinition{
+		Type:      name.value,
+		Relations: &relations,
+	})
+	return nil
+}
+
+func (p *parser) parseDefine(stream *tokenStream) error {
+	stream.next()
+	name, err := stream.expectName("relation name")
+	if err != nil {
+		return err
+	}
+	if _, err := stream.expect(tokenColon); err != nil {
+		return err
+	}
+
+	typeDefinition := &p.typeDefinitions[p.currentType]
+	if _, exists := (*typeDefinition.Relations)[name.value]; exists {
+		return &SyntaxError{Line: name.line, Column: name.column, Message: fmt.Sprintf("relation '%s' is already defined in type '%s'", name.value, typeDefinition.Type)}
+	}
+
+	rp := &relationParser{stream: stream, directlyRelatedUserTypes: []fgaSdk.RelationReference{}}
+	userset, err := rp.parseExpression()
+	if err != nil {
+		return err
+	}
+	if _, err := stream.expect(tokenEOF); err != nil {
+		return err
+	}
+
+	(*typeDefinition.Relations)[name.value] = userset
+	if typeDefinition.Metadata == nil {
+		relationMetadata := make(map[string]fgaSdk.RelationMetadata)
+		typeDefinition.Metadata = &fgaSdk.Metadata{Relations: &relationMetadata}
+	}
+	directlyRelatedUserTypes := rp.directlyRelatedUserTypes
+	(*typeDefinition.Metadata.Relations)[name.value] = fgaSdk.RelationMetadata{DirectlyRelatedUserTypes: &directlyRelatedUserTypes}
+	return nil
+}
+
+type relationParser struct {
+	stream                   *tokenStream
+	hasDirectAssignment      bool
+	directlyRelatedUserTypes []fgaSdk.RelationReference
+}
+
+// parseExpression parses terms joined by a single kind of operator; mixing operators requires parentheses
+func (rp *relationParser) parseExpression() (fgaSdk.Userset, error) {
+	first, err := rp.parseTerm()
+	if err != nil {
+		return fgaSdk.Userset{}, err
+	}
+
+	operator := ""
+	operands := []fgaSdk.Userset{first}
+	for {
+		t := rp.stream.peek()
+		if t.kind != tokenIdentifier || (t.value != "or" && t.value != "and" && t.value != "but") {
+			break
+		}
+		rp.stream.next()
+
+		current := t.value
+		if current == "but" {
+			if err := rp.stream.expectKeyword("not"); err != nil {
+				return fgaSdk.Userset{}, err
+			}
+			current = "but not"
+		}
+		if operator == "but not" && current == "but not" {
+			return fgaSdk.Userset{}, &SyntaxError{Line: t.line, Column: t.column, Message: "'but not' can only be used once per expression, use parentheses to chain exclusions"}
+		}
+		if operator != "" && operator != current {
+			return fgaSdk.Userset{}, &SyntaxError{Line: t.line, Column: t.column, Message: fmt.Sprintf("cannot mix '%s' and '%s' without parentheses", operator, current)}
+		}
+		operator = current
+
+		operand, err := rp.parseTerm()
+		if err != nil {
+			return fgaSdk.Userset{}, err
+		}
+		operands = append(operands, operand)
+	}
+
+	switch operator {
+	case "or":
+		return fgaSdk.Userset{Union: &fgaSdk.Usersets{Child: operands}}, nil
+	case "and":
+		return fgaSdk.Userset{Intersection: &fgaSdk.Usersets{Child: operands}}, nil
+	case "but not":
+		return fgaSdk.Userset{Difference: &fgaSdk.Difference{Base: operands[0], Subtract: operands[1]}}, nil
+	}
+	return first, nil
+}
+
+func (rp *relationParser) parseTerm() (fgaSdk.Userset, error) {
+	t := rp.stream.peek()
+
+	switch t.kind {
+	case tokenLeftBracket:
+		if rp.hasDirectAssignment {
+			return fgaSdk.Userset{}, &SyntaxError{Line: t.line, Column: t.column, Message: "a relation can only have one list of directly related user types"}
+		}
+		rp.hasDirectAssignment = true
+		if err := rp.parseDirectlyRelatedUserTypes(); err != nil {
+			return fgaSdk.Userset{}, err
+		}
+		return fgaSdk.Userset{This: &map[string]interface{}{}}, nil
+	case tokenLeftParen:
+		rp.stream.next()
+		userset, err := rp.parseExpression()
+		if err != nil {
+			return fgaSdk.Userset{}, err
+		}
+		if _, err := rp.stream.expect(tokenRightParen); err != nil {
+			return fgaSdk.Userset{}, err
+		}
+		return userset, nil
+	case tokenIdentifier:
+		relation, err := rp.stream.expectName("relation name")
+		if err != nil {
+			return fgaSdk.Userset{}, err
+		}
+		if !rp.stream.isKeyword("from") {
+			return fgaSdk.Userset{ComputedUserset: &fgaSdk.ObjectRelation{Relation: fgaSdk.ToPtr(relation.value)}}, nil
+		}
+		rp.stream.next()
+		tupleset, err := rp.stream.expectName("relation name")
+		if err != nil {
+			return fgaSdk.Userset{}, err
+		}
+		return fgaSdk.Userset{TupleToUserset: &fgaSdk.TupleToUserset{
+			Tupleset:        fgaSdk.ObjectRelation{Relation: fgaSdk.ToPtr(tupleset.value)},
+			ComputedUserset: fgaSdk.ObjectRelation{Relation: fgaSdk.ToPtr(relation.value)},
+		}}, nil
+	}
+
+	return fgaSdk.Userset{}, unexpectedToken(t, "'[', '(' or a relation name")
+}
+
+// parseDirectlyRelatedUserTypes parses [user, user:*, group#member with condition]
+func (rp *relationParser) parseDirectlyRelatedUserTypes() error {
+	rp.stream.next()
+
+	for {
+		typeName, err := rp.stream.expectName("type name")
+		if err != nil {
+			return err
+		}
+		reference := fgaSdk.RelationReference{Type: typeName.value}
+
+		switch rp.stream.peek().kind {
+		case tokenColon:
+			rp.stream.next()
+			if _, err := rp.stream.expect(tokenStar); err != nil {
+				return err
+			}
+			reference.Wildcard = &map[string]interface{}{}
+		case tokenHash:
+			rp.stream.next()
+			relation, err := rp.stream.expectName("relation name")
+			if err != nil {
+				return err
+			}
+			reference.Relation = fgaSdk.ToPtr(relation.value)
+		}
+
+		if rp.stream.isKeyword("with") {
+			rp.stream.next()
+			condition, err := rp.stream.expectName("condition name")
+			if err != nil {
+				return err
+			}
+			reference.Condition = fgaSdk.ToPtr(condition.value)
+		}
+
+		rp.directlyRelatedUserTypes = append(rp.directlyRelatedUserTypes, reference)
+
+		separator := rp.stream.next()
+		switch separator.kind {
+		case tokenComma:
+			continue
+		case tokenRightBracket:
+			return nil
+		}
+		return unexpectedToken(separator, "',' or ']'")
+	}
+}
+
+// parseCondition parses a condition, whose CEL body may span several lines, and leaves lineIndex on its last line
+func (p *parser) parseCondition(column int) {
+	if p.state != stateBody {
+		p.errorf(column, "conditions must come after the model and schema declarations")
+	}
+	p.currentType = -1
+	p.inRelations = false
+
+	startLine := p.lineIndex
+	line := p.lines[p.lineIndex]
+	headerStart := column - 1
+	braceIndex := strings.IndexByte(line[headerStart:], '{')
+	if braceIndex < 0 {
+		p.errorf(len(strings.TrimRight(line, " \t"))+1, "expected '{' to start the condition expression")
+		return
+	}
+	braceIndex += headerStart
+
+	tokens, err := tokenize(line[headerStart:braceIndex], p.lineIndex+1, column)
+	if err != nil {
+		p.addError(err)
+		p.skipConditionBody(braceIndex)
+		return
+	}
+
+	condition, err := parseConditionHeader(&tokenStream{tokens: tokens})
+	if err != nil {
+		p.addError(err)
+		p.skipConditionBody(braceIndex)
+		return
+	}
+
+	expression, ok := p.skipConditionBody(braceIndex)
+	if !ok {
+		p.errors = append(p.errors, &SyntaxError{Line: startLine + 1, Column: braceIndex + 1, Message: fmt.Sprintf("condition '%s' is missing a closing '}'", condition.Name)})
+		return
+	}
+	if expression == "" {
+		p.errors = append(p.errors, &SyntaxError{Line: startLine + 1, Column: braceIndex + 1, Message: fmt.Sprintf("condition '%s' has an empty expression", condition.Name)})
+		return
+	}
+	if _, exists := p.conditions[condition.Name]; exists {
+		p.errors = append(p.errors, &SyntaxError{Line: startLine + 1, Column: column, Message: fmt.Sprintf("condition '%s' is already defined", condition.Name)})
+		return
+	}
+
+	condition.Expression = expression
+	p.conditions[condition.Name] = condition
+}
+
+// skipConditionBody reads the expression between the '{' at braceIndex on the current line and its matching '}', skipping
+// braces inside string literals, and returns it with each line trimmed
+func (p *parser) skipConditionBody(braceIndex int) (string, bool) {
+	depth := 0
+	var quote byte
+	var body []string
+	var current strings.Builder
+
+	for ; p.lineIndex < len(p.lines); p.lineIndex++ {
+		line := p.lines[p.lineIndex]
+		start := 0
+		if len(body) == 0 && current.Len() == 0 && depth == 0 {
+			start = braceIndex
+		}
+
+		for index := start; index < len(line); index++ {
+			c := line[index]
+			switch {
+			case quote != 0:
+				if c == '\\' && index+1 < len(line) {
+					current.WriteByte(c)
+					index++
+					c = line[index]
+				} else if c == quote {
+					quote = 0
+				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '{':
+				depth++
+				if depth == 1 {
+					continue
+				}
+			case c == '}':
+				depth--
+				if depth == 0 {
+					body = append(body, strings.TrimSpace(current.String()))
+					if rest := strings.TrimSpace(stripComment(line[index+1:])); rest != "" {
+						p.errorf(index+2, "unexpected '%s' after the condition expression", rest)
+					}
+					return joinNonEmpty(body), true
+				}
+			}
+			current.WriteByte(c)
+		}
+
+		body = append(body, strings.TrimSpace(current.String()))
+		current.Reset()
+	}
+
+	p.lineIndex = len(p.lines) - 1
+	return "", false
+}
+
+func joinNonEmpty(lines []string) string {
+	nonEmpty := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line != "" {
+			nonEmpty = append(nonEmpty, line)
+		}
+	}
+	return strings.Join(nonEmpty, "\n")
+}
+
+// parseConditionHeader parses condition name(param: type, ...)
+func parseConditionHeader(stream *tokenStream) (fgaSdk.Condition, error) {
+	if err := stream.expectKeyword("condition"); err != nil {
+		return fgaSdk.Condition{}, err
+	}
+	name, err := stream.expectName("condition name")
+	if err != nil {
+		return fgaSdk.Condition{}, err
+	}
+	if _, err := stream.expect(tokenLeftParen); err != nil {
+		return fgaSdk.Condition{}, err
+	}
+
+	parameters := make(map[string]fgaSdk.ConditionParamTypeRef)
+	if stream.peek().kind != tokenRightParen {
+		for {
+			parameter, err := stream.expectName("parameter name")
+			if err != nil {
+				return fgaSdk.Condition{}, err
+			}
+			if _, exists := parameters[parameter.value]; exists {
+				return fgaSdk.Condition{}, &SyntaxError{Line: parameter.line, Column: parameter.column, Message: fmt.Sprintf("parameter '%s' is already defined", parameter.value)}
+			}
+			if _, err := stream.expect(tokenColon); err != nil {
+				return fgaSdk.Condition{}, err
+			}
+			typeRef, err := parseConditionParamType(stream)
+			if err != nil {
+				return fgaSdk.Condition{}, err
+			}
+			parameters[parameter.value] = typeRef
+
+			if stream.peek().kind != tokenComma {
+				break
+			}
+			stream.next()
+		}
+	}
+	if _, err := stream.expect(tokenRightParen); err != nil {
+		return fgaSdk.Condition{}, err
+	}
+	if _, err := stream.expect(tokenEOF); err != nil {
+		return fgaSdk.Condition{}, err
+	}
+
+	condition := fgaSdk.Condition{Name: name.value}
+	if len(parameters) > 0 {
+		condition.Parameters = &parameters
+	}
+	return condition, nil
+}
+
+func parseConditionParamType(stream *tokenStream) (fgaSdk.ConditionParamTypeRef, error) {
+	name, err := stream.expect(tokenIdentifier)
+	if err != nil {
+		return fgaSdk.ConditionParamTypeRef{}, err
+	}
+	typeName, ok := conditionParamTypeNames[name.value]
+	if !ok {
+		return fgaSdk.ConditionParamTypeRef{}, &SyntaxError{Line: name.line, Column: name.column, Message: fmt.Sprintf("unknown parameter type '%s'", name.value)}
+	}
+
+	typeRef := fgaSdk.ConditionParamTypeRef{TypeName: typeName}
+	if typeName != fgaSdk.TYPENAME_MAP && typeName != fgaSdk.TYPENAME_LIST {
+		return typeRef, nil
+	}
+
+	if _, err := stream.expect(tokenLeftAngle); err != nil {
+		return fgaSdk.ConditionParamTypeRef{}, err
+	}
+	generic, err := parseConditionParamType(stream)
+	if err != nil {
+		return fgaSdk.ConditionParamTypeRef{}, err
+	}
+	if _, err := stream.expect(tokenRightAngle); err != nil {
+		return fgaSdk.ConditionParamTypeRef{}, err
+	}
+	typeRef.GenericTypes = &[]fgaSdk.ConditionParamTypeRef{generic}
+	return typeRef, nil
+}
@@ -0,0 +1,244 @@
+package language
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testModelDSL = `model
+  schema 1.1
+
+# users and groups
+type user
+
+type group
+  relations
+    define member: [user, group#member]
+
+type folder
+  relations
+    define viewer: [user, user:*]
+
+type document
+  relations
+    define blocked: [user]
+    define editor: [user with non_expired_grant] # editors can expire
+    define owner: [user]
+    define parent: [folder]
+    define viewer: ([user, group#member] or editor or viewer from parent) but not blocked
+    define can_delete: owner and editor
+
+condition non_expired_grant(current_time: timestamp, grant_time: timestamp, grant_duration: duration, tags: map<string>) {
+  current_time < grant_time + grant_duration &&
+    !("blocked" in tags)
+}
+`
+
+const testModelJSON = `{
+  "schema_version": "1.1",
+  "type_definitions": [
+    {"type": "user", "relations": {}},
+    {
+      "type": "group",
+      "relations": {"member": {"this": {}}},
+      "metadata": {"relations": {"member": {"directly_related_user_types": [{"type": "user"}, {"type": "group", "relation": "member"}]}}}
+    },
+    {
+      "type": "folder",
+      "relations": {"viewer": {"this": {}}},
+      "metadata": {"relations": {"viewer": {"directly_related_user_types": [{"type": "user"}, {"type": "user", "wildcard": {}}]}}}
+    },
+    {
+      "type": "document",
+      "relations": {
+        "blocked": {"this": {}},
+        "editor": {"this": {}},
+        "owner": {"this": {}},
+        "parent": {"this": {}},
+        "viewer": {"difference": {
+          "base": {"union": {"child": [
+            {"this": {}},
+            {"computedUserset": {"relation": "editor"}},
+            {"tupleToUserset": {"tupleset": {"relation": "parent"}, "computedUserset": {"relation": "viewer"}}}
+          ]}},
+          "subtract": {"computedUserset": {"relation": "blocked"}}
+        }},
+        "can_delete": {"intersection": {"child": [
+          {"computedUserset": {"relation": "owner"}},
+          {"computedUserset": {"relation": "editor"}}
+        ]}}
+      },
+      "metadata": {"relations": {
+        "blocked": {"directly_related_user_types": [{"type": "user"}]},
+        "editor": {"directly_related_user_types": [{"type": "user", "condition": "non_expired_grant"}]},
+        "owner": {"directly_related_user_types": [{"type": "user"}]},
+        "parent": {"directly_related_user_types": [{"type": "folder"}]},
+        "viewer": {"directly_related_user_types": [{"type": "user"}, {"type": "group", "relation": "member"}]},
+        "can_delete": {"directly_related_user_types": []}
+      }}
+    }
+  ],
+  "conditions": {
+    "non_expired_grant": {
+      "name": "non_expired_grant",
+      "expression": "current_time < grant_time + grant_duration &&\n!(\"blocked\" in tags)",
+      "parameters": {
+        "current_time": {"type_name": "TYPE_NAME_TIMESTAMP"},
+        "grant_time": {"type_name": "TYPE_NAME_TIMESTAMP"},
+        "grant_duration": {"type_name": "TYPE_NAME_DURATION"},
+        "tags": {"type_name": "TYPE_NAME_MAP", "generic_types": [{"type_name": "TYPE_NAME_STRING"}]}
+      }
+    }
+  }
+}`
+
+func normalizeJSON(t *testing.T, value interface{}) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		t.Fatalf("%v", err)
+	}
+	normalized, err := json.MarshalIndent(generic, "", "  ")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return string(normalized)
+}
+
+func TestTransformDSLToModel(t *testing.T) {
+	model, err := TransformDSLToModel(testModelDSL)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var expected interface{}
+	if err := json.Unmarshal([]byte(testModelJSON), &expected); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	got := normalizeJSON(t, model)
+	want := normalizeJSON(t, expected)
+	if got != want {
+		t.Fatalf("TransformDSLToModel() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTransformDSLToModelSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		dsl     string
+		line    int
+		column  int
+		message string
+	}{
+		{
+			name:    "missing model",
+			dsl:     "type user\n",
+			line:    1,
+			column:  1,
+			message: "expected 'model'",
+		},
+		{
+			name:    "unsupported schema",
+			dsl:     "model\n  schema 1.0\n",
+			line:    2,
+			column:  10,
+			message: "unsupported schema version '1.0'",
+		},
+		{
+			name:    "define outside relations",
+			dsl:     "model\n  schema 1.1\ntype document\n  define viewer: [user]\n",
+			line:    4,
+			column:  3,
+			message: "'define' must be inside the relations block",
+		},
+		{
+			name:    "mixed operators",
+			dsl:     "model\n  schema 1.1\ntype document\n  relations\n    define viewer: [user] or editor and owner\n",
+			line:    5,
+			column:  37,
+			message: "cannot mix 'or' and 'and'",
+		},
+		{
+			name:    "unclosed type list",
+			dsl:     "model\n  schema 1.1\ntype document\n  relations\n    define viewer: [user, group#member\n",
+			line:    5,
+			column:  39,
+			message: "expected ',' or ']'",
+		},
+		{
+			name:    "missing parenthesis",
+			dsl:     "model\n  schema 1.1\ntype document\n  relations\n    define viewer: (editor or owner\n",
+			line:    5,
+			column:  36,
+			message: "expected ')'",
+		},
+		{
+			name:    "duplicate relation",
+			dsl:     "model\n  schema 1.1\ntype document\n  relations\n    define viewer: [user]\n    define viewer: [user]\n",
+			line:    6,
+			column:  12,
+			message: "relation 'viewer' is already defined",
+		},
+		{
+			name:    "unknown condition parameter type",
+			dsl:     "model\n  schema 1.1\ncondition valid(x: float) {\n  x > 1.0\n}\n",
+			line:    3,
+			column:  20,
+			message: "unknown parameter type 'float'",
+		},
+		{
+			name:    "unclosed condition",
+			dsl:     "model\n  schema 1.1\ncondition valid(x: int) {\n  x > 1\n",
+			line:    3,
+			column:  25,
+			message: "missing a closing '}'",
+		},
+		{
+			name:    "reserved keyword",
+			dsl:     "model\n  schema 1.1\ntype document\n  relations\n    define viewer: from\n",
+			line:    5,
+			column:  20,
+			message: "reserved keyword",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := TransformDSLToModel(test.dsl)
+
+			var syntaxErrors SyntaxErrors
+			if !errors.As(err, &syntaxErrors) || len(syntaxErrors) == 0 {
+				t.Fatalf("Expected SyntaxErrors, got %v", err)
+			}
+			first := syntaxErrors[0]
+			if first.Line != test.line || first.Column != test.column {
+				t.Errorf("Expected error at %d:%d, got %d:%d (%s)", test.line, test.column, first.Line, first.Column, first.Message)
+			}
+			if !strings.Contains(first.Message, test.message) {
+				t.Errorf("Expected message to contain %q, got %q", test.message, first.Message)
+			}
+		})
+	}
+}
+
+func TestTransformDSLToModelReportsAllErrors(t *testing.T) {
+	dsl := "model\n  schema 1.1\ntype document\n  relations\n    define viewer: [user\n    define editor: or\n"
+
+	_, err := TransformDSLToModel(dsl)
+
+	var syntaxErrors SyntaxErrors
+	if !errors.As(err, &syntaxErrors) {
+		t.Fatalf("Expected SyntaxErrors, got %v", err)
+	}
+	if len(syntaxErrors) != 2 || syntaxErrors[0].Line != 5 || syntaxErrors[1].Line != 6 {
+		t.Fatalf("Expected errors on lines 5 and 6, got %v", syntaxErrors)
+	}
+}
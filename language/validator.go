@@ -0,0 +1,459 @@
+package language
+
+import (
+	"fmt"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// ModelIssueSeverity indicates whether an issue makes the model invalid or is only suspicious
+type ModelIssueSeverity string
+
+// List of ModelIssueSeverity
+const (
+	// MODEL_ISSUE_SEVERITY_ERROR issues make the server reject the model or make relations impossible to satisfy
+	MODEL_ISSUE_SEVERITY_ERROR ModelIssueSeverity = "error"
+	// MODEL_ISSUE_SEVERITY_WARNING issues do not prevent the model from being written but are likely mistakes
+	MODEL_ISSUE_SEVERITY_WARNING ModelIssueSeverity = "warning"
+)
+
+// ModelIssueCode is a machine-readable identifier of the kind of issue found in a model
+type ModelIssueCode string
+
+// List of ModelIssueCode
+const (
+	MODEL_ISSUE_UNSUPPORTED_SCHEMA_VERSION       ModelIssueCode = "unsupported_schema_version"
+	MODEL_ISSUE_DUPLICATE_TYPE                   ModelIssueCode = "duplicate_type"
+	MODEL_ISSUE_DUPLICATE_RELATION               ModelIssueCode = "duplicate_relation"
+	MODEL_ISSUE_UNDEFINED_TYPE                   ModelIssueCode = "undefined_type"
+	MODEL_ISSUE_UNDEFINED_RELATION               ModelIssueCode = "undefined_relation"
+	MODEL_ISSUE_INVALID_USERSET                  ModelIssueCode = "invalid_userset"
+	MODEL_ISSUE_MISSING_DIRECT_USER_TYPES        ModelIssueCode = "missing_direct_user_types"
+	MODEL_ISSUE_UNEXPECTED_DIRECT_USER_TYPES     ModelIssueCode = "unexpected_direct_user_types"
+	MODEL_ISSUE_TUPLESET_NOT_DIRECT              ModelIssueCode = "tupleset_not_direct"
+	MODEL_ISSUE_NO_ENTRY_POINT                   ModelIssueCode = "no_entry_point"
+	MODEL_ISSUE_UNDEFINED_CONDITION              ModelIssueCode = "undefined_condition"
+	MODEL_ISSUE_UNUSED_CONDITION                 ModelIssueCode = "unused_condition"
+	MODEL_ISSUE_INVALID_CONDITION_PARAMETER_TYPE ModelIssueCode = "invalid_condition_parameter_type"
+	MODEL_ISSUE_CONDITION_NAME_MISMATCH          ModelIssueCode = "condition_name_mismatch"
+	MODEL_ISSUE_CONDITION_MISSING_EXPRESSION     ModelIssueCode = "condition_missing_expression"
+)
+
+// ModelIssue is a problem found by ValidateAuthorizationModel. Type and Relation are empty when the issue is not about a
+// specific type or relation; Condition is only set for issues about conditions.
+type ModelIssue struct {
+	Code      ModelIssueCode     `json:"code"`
+	Severity  ModelIssueSeverity `json:"severity"`
+	Type      string             `json:"type,omitempty"`
+	Relation  string             `json:"relation,omitempty"`
+	Condition string             `json:"condition,omitempty"`
+	Message   string             `json:"message"`
+}
+
+func (i ModelIssue) String() string {
+	location := ""
+	switch {
+	case i.Type != "" && i.Relation != "":
+		location = i.Type + "#" + i.Relation + ": "
+	case i.Type != "":
+		location = i.Type + ": "
+	case i.Condition != "":
+		location = "condition " + i.Condition + ": "
+	}
+	return fmt.Sprintf("%s [%s] %s%s", i.Severity, i.Code, location, i.Message)
+}
+
+// HasErrors reports whether any of the issues has MODEL_ISSUE_SEVERITY_ERROR
+func HasErrors(issues []ModelIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == MODEL_ISSUE_SEVERITY_ERROR {
+			return true
+		}
+	}
+	return false
+}
+
+type relationKey struct {
+	typeName string
+	relation string
+}
+
+type modelValidator struct {
+	issues     []ModelIssue
+	types      map[string]fgaSdk.TypeDefinition
+	conditions map[string]fgaSdk.Condition
+	// usedConditions records the conditions referenced by directly related user types
+	usedConditions map[string]bool
+}
+
+// ValidateAuthorizationModel checks a model locally, without a server, and returns the issues found, ordered by type
+// definition. A model without issues of MODEL_ISSUE_SEVERITY_ERROR is expected to be accepted by WriteAuthorizationModel.
+func ValidateAuthorizationModel(model fgaSdk.WriteAuthorizationModelRequest) []ModelIssue {
+	v := &modelValidator{
+		types:          make(map[string]fgaSdk.TypeDefinition),
+		conditions:     model.GetConditions(),
+		usedConditions: make(map[string]bool),
+	}
+
+	if model.SchemaVersion != supportedSchemaVersion {
+		v.add(ModelIssue{Code: MODEL_ISSUE_UNSUPPORTED_SCHEMA_VERSION, Severity: MODEL_ISSUE_SEVERITY_ERROR,
+			Message: fmt.Sprintf("schema version '%s' is not supported, only %s is", model.SchemaVersion, supportedSchemaVersion)})
+	}
+
+	v.collectTypes(model.TypeDefinitions)
+
+	for _, typeDefinition := range uniqueTypeDefinitions(model.TypeDefinitions) {
+		relations := typeDefinition.GetRelations()
+		for _, relation := range sortedKeys(relations) {
+			v.validateRelation(typeDefinition, relation)
+		}
+		v.validateMetadata(typeDefinition)
+	}
+
+	v.validateEntryPoints(model.TypeDefinitions)
+	v.validateConditions()
+
+	return v.issues
+}
+
+// uniqueTypeDefinitions returns the first definition of every type, in order; duplicates are reported by collectTypes
+func uniqueTypeDefinitions(typeDefinitions []fgaSdk.TypeDefinition) []fgaSdk.TypeDefinition {
+	seen := make(map[string]bool, len(typeDefinitions))
+	unique := make([]fgaSdk.TypeDefinition, 0, len(typeDefinitions))
+	for _, typeDefinition := range typeDefinitions {
+		if seen[typeDefinition.Type] {
+			continue
+		}
+		seen[typeDefinition.Type] = true
+		unique = append(unique, typeDefinition)
+	}
+	return unique
+}
+
+func (v *modelValidator) add(issue ModelIssue) {
+	v.issues = append(v.issues, issue)
+}
+
+func (v *modelValidator) addRelationIssue(code ModelIssueCode, severity ModelIssueSeverity, typeName string, relation string, format string, args ...interface{}) {
+	v.add(ModelIssue{Code: code, Severity: severity, Type: typeName, Relation: relation, Message: fmt.Sprintf(format, args...)})
+}
+
+// collectTypes indexes the type definitions by name, keeping the first definition of a type and reporting the others
+func (v *modelValidator) collectTypes(typeDefinitions []fgaSdk.TypeDefinition) {
+	for _, typeDefinition := range typeDefinitions {
+		existing, exists := v.types[typeDefinition.Type]
+		if !exists {
+			v.types[typeDefinition.Type] = typeDefinition
+			continue
+		}
+
+		v.add(ModelIssue{Code: MODEL_ISSUE_DUPLICATE_TYPE, Severity: MODEL_ISSUE_SEVERITY_ERROR, Type: typeDefinition.Type,
+			Message: fmt.Sprintf("type '%s' is defined more than once", typeDefinition.Type)})
+
+		existingRelations := existing.GetRelations()
+		relations := typeDefinition.GetRelations()
+		for _, relation := range sortedKeys(relations) {
+			if _, defined := existingRelations[relation]; defined {
+				v.addRelationIssue(MODEL_ISSUE_DUPLICATE_RELATION, MODEL_ISSUE_SEVERITY_ERROR, typeDefinition.Type, relation,
+					"relation '%s' is defined more than once in type '%s'", relation, typeDefinition.Type)
+			}
+		}
+	}
+}
+
+func (v *modelValidator) relation(typeName string, relation string) (fgaSdk.Userset, bool) {
+	typeDefinition, ok := v.types[typeName]
+	if !ok {
+		return fgaSdk.Userset{}, false
+	}
+	userset, ok := typeDefinition.GetRelations()[relation]
+	return userset, ok
+}
+
+func (v *modelValidator) hasRelation(typeName string, relation string) bool {
+	_, ok := v.relation(typeName, relation)
+	return ok
+}
+
+func directlyRelatedUserTypes(typeDefinition fgaSdk.TypeDefinition, relation string) ([]fgaSdk.RelationReference, bool) {
+	if typeDefinition.Metadata == nil {
+		return nil, false
+	}
+	metadata, ok := typeDefinition.Metadata.GetRelations()[relation]
+	if !ok || metadata.DirectlyRelatedUserTypes == nil {
+		return nil, false
+	}
+	return *metadata.DirectlyRelatedUserTypes, true
+}
+
+func (v *modelValidator) validateRelation(typeDefinition fgaSdk.TypeDefinition, relation string) {
+	userset := typeDefinition.GetRelations()[relation]
+	references, _ := directlyRelatedUserTypes(typeDefinition, relation)
+
+	if usesThis(userset) {
+		if len(references) == 0 {
+			v.addRelationIssue(MODEL_ISSUE_MISSING_DIRECT_USER_TYPES, MODEL_ISSUE_SEVERITY_ERROR, typeDefinition.Type, relation,
+				"relation '%s' is directly assignable but does not list any directly related user types", relation)
+		}
+	} else if len(references) > 0 {
+		v.addRelationIssue(MODEL_ISSUE_UNEXPECTED_DIRECT_USER_TYPES, MODEL_ISSUE_SEVERITY_ERROR, typeDefinition.Type, relation,
+			"relation '%s' lists directly related user types but is not directly assignable", relation)
+	}
+
+	for _, reference := range references {
+		v.validateRelationReference(typeDefinition.Type, relation, reference)
+	}
+
+	v.validateUserset(typeDefinition, relation, userset)
+}
+
+func (v *modelValidator) validateRelationReference(typeName string, relation string, reference fgaSdk.RelationReference) {
+	rendered := RenderRelationReference(reference)
+
+	if _, ok := v.types[reference.Type]; !ok {
+		v.addRelationIssue(MODEL_ISSUE_UNDEFINED_TYPE, MODEL_ISSUE_SEVERITY_ERROR, typeName, relation,
+			"directly related user type '%s' references undefined type '%s'", rendered, reference.Type)
+	} else if reference.GetRelation() != "" && !v.hasRelation(reference.Type, reference.GetRelation()) {
+		v.addRelationIssue(MODEL_ISSUE_UNDEFINED_RELATION, MODEL_ISSUE_SEVERITY_ERROR, typeName, relation,
+			"directly related user type '%s' references undefined relation '%s' of type '%s'", rendered, reference.GetRelation(), reference.Type)
+	}
+
+	if condition := reference.GetCondition(); condition != "" {
+		v.usedConditions[condition] = true
+		if _, ok := v.conditions[condition]; !ok {
+			v.add(ModelIssue{Code: MODEL_ISSUE_UNDEFINED_CONDITION, Severity: MODEL_ISSUE_SEVERITY_ERROR, Type: typeName, Relation: relation, Condition: condition,
+				Message: fmt.Sprintf("directly related user type '%s' references undefined condition '%s'", rendered, condition)})
+		}
+	}
+}
+
+func usesThis(userset fgaSdk.Userset) bool {
+	switch {
+	case userset.This != nil:
+		return true
+	case userset.Union != nil:
+		return anyUsesThis(userset.Union.Child)
+	case userset.Intersection != nil:
+		return anyUsesThis(userset.Intersection.Child)
+	case userset.Difference != nil:
+		return usesThis(userset.Difference.Base) || usesThis(userset.Difference.Subtract)
+	}
+	return false
+}
+
+func anyUsesThis(children []fgaSdk.Userset) bool {
+	for _, child := range children {
+		if usesThis(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *modelValidator) validateUserset(typeDefinition fgaSdk.TypeDefinition, relation string, userset fgaSdk.Userset) {
+	typeName := typeDefinition.Type
+
+	switch {
+	case userset.This != nil:
+	case userset.ComputedUserset != nil:
+		computed := userset.ComputedUserset.GetRelation()
+		if !v.hasRelation(typeName, computed) {
+			v.addRelationIssue(MODEL_ISSUE_UNDEFINED_RELATION, MODEL_ISSUE_SEVERITY_ERROR, typeName, relation,
+				"relation '%s' references undefined relation '%s'", relation, computed)
+		}
+	case userset.TupleToUserset != nil:
+		v.validateTupleToUserset(typeDefinition, relation, *userset.TupleToUserset)
+	case userset.Union != nil:
+		v.validateChildren(typeDefinition, relation, userset.Union.Child)
+	case userset.Intersection != nil:
+		v.validateChildren(typeDefinition, relation, userset.Intersection.Child)
+	case userset.Difference != nil:
+		v.validateUserset(typeDefinition, relation, userset.Difference.Base)
+		v.validateUserset(typeDefinition, relation, userset.Difference.Subtract)
+	default:
+		v.addRelationIssue(MODEL_ISSUE_INVALID_USERSET, MODEL_ISSUE_SEVERITY_ERROR, typeName, relation,
+			"relation '%s' has an empty rewrite", relation)
+	}
+}
+
+func (v *modelValidator) validateChildren(typeDefinition fgaSdk.TypeDefinition, relation string, children []fgaSdk.Userset) {
+	if len(children) == 0 {
+		v.addRelationIssue(MODEL_ISSUE_INVALID_USERSET, MODEL_ISSUE_SEVERITY_ERROR, typeDefinition.Type, relation,
+			"relation '%s' has a union or intersection without children", relation)
+	}
+	for _, child := range children {
+		v.validateUserset(typeDefinition, relation, child)
+	}
+}
+
+func (v *modelValidator) validateTupleToUserset(typeDefinition fgaSdk.TypeDefinition, relation string, tupleToUserset fgaSdk.TupleToUserset) {
+	typeName := typeDefinition.Type
+	tupleset := tupleToUserset.Tupleset.GetRelation()
+	computed := tupleToUserset.ComputedUserset.GetRelation()
+
+	tuplesetUserset, ok := v.relation(typeName, tupleset)
+	if !ok {
+		v.addRelationIssue(MODEL_ISSUE_UNDEFINED_RELATION, MODEL_ISSUE_SEVERITY_ERROR, typeName, relation,
+			"'%s from %s' references undefined relation '%s'", computed, tupleset, tupleset)
+		return
+	}
+
+	if tuplesetUserset.This == nil {
+		v.addRelationIssue(MODEL_ISSUE_TUPLESET_NOT_DIRECT, MODEL_ISSUE_SEVERITY_ERROR, typeName, relation,
+			"'%s from %s' requires '%s' to only be directly assignable", computed, tupleset, tupleset)
+		return
+	}
+
+	references, _ := directlyRelatedUserTypes(typeDefinition, tupleset)
+	found := false
+	for _, reference := range references {
+		if reference.GetRelation() != "" || reference.Wildcard != nil {
+			v.addRelationIssue(MODEL_ISSUE_TUPLESET_NOT_DIRECT, MODEL_ISSUE_SEVERITY_ERROR, typeName, relation,
+				"'%s from %s' requires '%s' to only relate objects, but it allows '%s'", computed, tupleset, tupleset, RenderRelationReference(reference))
+			continue
+		}
+		if v.hasRelation(reference.Type, computed) {
+			found = true
+		}
+	}
+	if !found && len(references) > 0 {
+		v.addRelationIssue(MODEL_ISSUE_UNDEFINED_RELATION, MODEL_ISSUE_SEVERITY_ERROR, typeName, relation,
+			"'%s from %s' references relation '%s', which is not defined on any type related through '%s'", computed, tupleset, computed, tupleset)
+	}
+}
+
+// validateMetadata reports relation metadata for relations that are not defined
+func (v *modelValidator) validateMetadata(typeDefinition fgaSdk.TypeDefinition) {
+	if typeDefinition.Metadata == nil {
+		return
+	}
+	relations := typeDefinition.GetRelations()
+	metadata := typeDefinition.Metadata.GetRelations()
+	for _, relation := range sortedKeys(metadata) {
+		if _, ok := relations[relation]; !ok {
+			v.addRelationIssue(MODEL_ISSUE_UNDEFINED_RELATION, MODEL_ISSUE_SEVERITY_ERROR, typeDefinition.Type, relation,
+				"metadata is defined for undefined relation '%s'", relation)
+		}
+	}
+}
+
+// validateEntryPoints reports relations that no tuple can ever satisfy, e.g. a relation that only refers to itself. A
+// relation has an entry point when it can be reached from a directly assignable user type, which is computed as a fixpoint.
+func (v *modelValidator) validateEntryPoints(typeDefinitions []fgaSdk.TypeDefinition) {
+	satisfiable := make(map[relationKey]bool)
+
+	for changed := true; changed; {
+		changed = false
+		for typeName, typeDefinition := range v.types {
+			for relation, userset := range typeDefinition.GetRelations() {
+				key := relationKey{typeName: typeName, relation: relation}
+				if satisfiable[key] {
+					continue
+				}
+				if v.hasEntryPoint(typeDefinition, relation, userset, satisfiable) {
+					satisfiable[key] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	for _, typeDefinition := range uniqueTypeDefinitions(typeDefinitions) {
+		relations := typeDefinition.GetRelations()
+		for _, relation := range sortedKeys(relations) {
+			if !satisfiable[relationKey{typeName: typeDefinition.Type, relation: relation}] {
+				v.addRelationIssue(MODEL_ISSUE_NO_ENTRY_POINT, MODEL_ISSUE_SEVERITY_ERROR, typeDefinition.Type, relation,
+					"relation '%s' has no entry point: no user can ever be related to it, e.g. because it is only defined in terms of itself", relation)
+			}
+		}
+	}
+}
+
+func (v *modelValidator) hasEntryPoint(typeDefinition fgaSdk.TypeDefinition, relation string, userset fgaSdk.Userset, satisfiable map[relationKey]bool) bool {
+	switch {
+	case userset.This != nil:
+		references, _ := directlyRelatedUserTypes(typeDefinition, relation)
+		for _, reference := range references {
+			if _, ok := v.types[reference.Type]; !ok {
+				continue
+			}
+			if reference.GetRelation() == "" || satisfiable[relationKey{typeName: reference.Type, relation: reference.GetRelation()}] {
+				return true
+			}
+		}
+		return false
+	case userset.ComputedUserset != nil:
+		return satisfiable[relationKey{typeName: typeDefinition.Type, relation: userset.ComputedUserset.GetRelation()}]
+	case userset.TupleToUserset != nil:
+		references, _ := directlyRelatedUserTypes(typeDefinition, userset.TupleToUserset.Tupleset.GetRelation())
+		for _, reference := range references {
+			if satisfiable[relationKey{typeName: reference.Type, relation: userset.TupleToUserset.ComputedUserset.GetRelation()}] {
+				return true
+			}
+		}
+		return false
+	case userset.Union != nil:
+		for _, child := range userset.Union.Child {
+			if v.hasEntryPoint(typeDefinition, relation, child, satisfiable) {
+				return true
+			}
+		}
+		return false
+	case userset.Intersection != nil:
+		for _, child := range userset.Intersection.Child {
+			if !v.hasEntryPoint(typeDefinition, relation, child, satisfiable) {
+				return false
+			}
+		}
+		return len(userset.Intersection.Child) > 0
+	case userset.Difference != nil:
+		return v.hasEntryPoint(typeDefinition, relation, userset.Difference.Base, satisfiable)
+	}
+	return false
+}
+
+func (v *modelValidator) validateConditions() {
+	for _, name := range sortedKeys(v.conditions) {
+		condition := v.conditions[name]
+
+		if condition.Name != name {
+			v.add(ModelIssue{Code: MODEL_ISSUE_CONDITION_NAME_MISMATCH, Severity: MODEL_ISSUE_SEVERITY_ERROR, Condition: name,
+				Message: fmt.Sprintf("condition is stored under '%s' but named '%s'", name, condition.Name)})
+		}
+		if condition.Expression == "" {
+			v.add(ModelIssue{Code: MODEL_ISSUE_CONDITION_MISSING_EXPRESSION, Severity: MODEL_ISSUE_SEVERITY_ERROR, Condition: name,
+				Message: "condition has no expression"})
+		}
+
+		parameters := condition.GetParameters()
+		for _, parameter := range sortedKeys(parameters) {
+			if err := validateConditionParamType(parameters[parameter]); err != nil {
+				v.add(ModelIssue{Code: MODEL_ISSUE_INVALID_CONDITION_PARAMETER_TYPE, Severity: MODEL_ISSUE_SEVERITY_ERROR, Condition: name,
+					Message: fmt.Sprintf("parameter '%s' %s", parameter, err)})
+			}
+		}
+
+		if !v.usedConditions[name] {
+			v.add(ModelIssue{Code: MODEL_ISSUE_UNUSED_CONDITION, Severity: MODEL_ISSUE_SEVERITY_WARNING, Condition: name,
+				Message: "condition is not used by any directly related user type"})
+		}
+	}
+}
+
+func validateConditionParamType(typeRef fgaSdk.ConditionParamTypeRef) error {
+	if !typeRef.TypeName.IsValid() || typeRef.TypeName == fgaSdk.TYPENAME_UNSPECIFIED {
+		return fmt.Errorf("has invalid type '%s'", typeRef.TypeName)
+	}
+
+	generics := typeRef.GetGenericTypes()
+	if typeRef.TypeName == fgaSdk.TYPENAME_MAP || typeRef.TypeName == fgaSdk.TYPENAME_LIST {
+		if len(generics) != 1 {
+			return fmt.Errorf("of type '%s' must have exactly one generic type", typeRef.TypeName)
+		}
+		return validateConditionParamType(generics[0])
+	}
+	if len(generics) > 0 {
+		return fmt.Errorf("of type '%s' cannot have generic types", typeRef.TypeName)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package language
+
+import (
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+func mustParse(t *testing.T, dsl string) fgaSdk.WriteAuthorizationModelRequest {
+	t.Helper()
+
+	model, err := TransformDSLToModel(dsl)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return *model
+}
+
+func findIssue(issues []ModelIssue, code ModelIssueCode, typeName string, relation string) *ModelIssue {
+	for index := range issues {
+		if issues[index].Code == code && issues[index].Type == typeName && issues[index].Relation == relation {
+			return &issues[index]
+		}
+	}
+	return nil
+}
+
+func TestValidateAuthorizationModelValid(t *testing.T) {
+	issues := ValidateAuthorizationModel(mustParse(t, testModelDSL))
+
+	if len(issues) != 0 {
+		t.Fatalf("Expected no issues, got %v", issues)
+	}
+}
+
+func TestValidateAuthorizationModelIssues(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsl      string
+		code     ModelIssueCode
+		severity ModelIssueSeverity
+		typeName string
+		relation string
+	}{
+		{
+			name:     "undefined directly related type",
+			dsl:      "model\n  schema 1.1\ntype document\n  relations\n    define viewer: [user]\n",
+			code:     MODEL_ISSUE_UNDEFINED_TYPE,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "undefined userset relation",
+			dsl:      "model\n  schema 1.1\ntype user\ntype group\ntype document\n  relations\n    define viewer: [group#member]\n",
+			code:     MODEL_ISSUE_UNDEFINED_RELATION,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "undefined computed relation",
+			dsl:      "model\n  schema 1.1\ntype user\ntype document\n  relations\n    define viewer: [user] or editor\n",
+			code:     MODEL_ISSUE_UNDEFINED_RELATION,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "undefined tupleset",
+			dsl:      "model\n  schema 1.1\ntype user\ntype document\n  relations\n    define viewer: [user] or viewer from parent\n",
+			code:     MODEL_ISSUE_UNDEFINED_RELATION,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "computed relation missing on related types",
+			dsl:      "model\n  schema 1.1\ntype user\ntype folder\ntype document\n  relations\n    define parent: [folder]\n    define viewer: [user] or viewer from parent\n",
+			code:     MODEL_ISSUE_UNDEFINED_RELATION,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "tupleset not directly assignable",
+			dsl:      "model\n  schema 1.1\ntype user\ntype folder\n  relations\n    define viewer: [user]\ntype document\n  relations\n    define owner: [folder]\n    define parent: owner\n    define viewer: viewer from parent\n",
+			code:     MODEL_ISSUE_TUPLESET_NOT_DIRECT,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "tupleset allowing usersets",
+			dsl:      "model\n  schema 1.1\ntype user\ntype folder\n  relations\n    define member: [user]\n    define viewer: [user]\ntype document\n  relations\n    define parent: [folder#member]\n    define viewer: viewer from parent\n",
+			code:     MODEL_ISSUE_TUPLESET_NOT_DIRECT,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "cycle without entry point",
+			dsl:      "model\n  schema 1.1\ntype user\ntype document\n  relations\n    define editor: viewer\n    define viewer: editor\n",
+			code:     MODEL_ISSUE_NO_ENTRY_POINT,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "intersection with an unsatisfiable side",
+			dsl:      "model\n  schema 1.1\ntype user\ntype document\n  relations\n    define owner: [user]\n    define looped: looped\n    define viewer: owner and looped\n",
+			code:     MODEL_ISSUE_NO_ENTRY_POINT,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "undefined condition",
+			dsl:      "model\n  schema 1.1\ntype user\ntype document\n  relations\n    define viewer: [user with missing]\n",
+			code:     MODEL_ISSUE_UNDEFINED_CONDITION,
+			severity: MODEL_ISSUE_SEVERITY_ERROR,
+			typeName: "document",
+			relation: "viewer",
+		},
+		{
+			name:     "unused condition",
+			dsl:      "model\n  schema 1.1\ntype user\ncondition unused(x: int) {\n  x > 1\n}\n",
+			code:     MODEL_ISSUE_UNUSED_CONDITION,
+			severity: MODEL_ISSUE_SEVERITY_WARNING,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issues := ValidateAuthorizationModel(mustParse(t, test.dsl))
+
+			issue := findIssue(issues, test.code, test.typeName, test.relation)
+			if issue == nil {
+				t.Fatalf("Expected a %s issue on %s#%s, got %v", test.code, test.typeName, test.relation, issues)
+			}
+			if issue.Severity != test.severity {
+				t.Fatalf("Expected severity %s, got %s", test.severity, issue.Severity)
+			}
+			if issue.Message == "" {
+				t.Fatalf("Expected the issue to have a message")
+			}
+		})
+	}
+}
+
+func TestValidateAuthorizationModelStructuralIssues(t *testing.T) {
+	user := fgaSdk.TypeDefinition{Type: "user"}
+	document := func() fgaSdk.TypeDefinition {
+		return fgaSdk.TypeDefinition{
+			Type: "document",
+			Relations: &map[string]fgaSdk.Userset{
+				"viewer": {This: &map[string]interface{}{}},
+				"owner":  {ComputedUserset: &fgaSdk.ObjectRelation{Relation: fgaSdk.ToPtr("viewer")}},
+			},
+			Metadata: &fgaSdk.Metadata{Relations: &map[string]fgaSdk.RelationMetadata{
+				"viewer": {DirectlyRelatedUserTypes: &[]fgaSdk.RelationReference{{Type: "user"}}},
+			}},
+		}
+	}
+
+	t.Run("duplicate types and relations", func(t *testing.T) {
+		issues := ValidateAuthorizationModel(fgaSdk.WriteAuthorizationModelRequest{
+			SchemaVersion:   "1.1",
+			TypeDefinitions: []fgaSdk.TypeDefinition{user, document(), document()},
+		})
+
+		if findIssue(issues, MODEL_ISSUE_DUPLICATE_TYPE, "document", "") == nil {
+			t.Fatalf("Expected a duplicate type issue, got %v", issues)
+		}
+		if findIssue(issues, MODEL_ISSUE_DUPLICATE_RELATION, "document", "viewer") == nil {
+			t.Fatalf("Expected a duplicate relation issue, got %v", issues)
+		}
+	})
+
+	t.Run("directly assignable relation without user types", func(t *testing.T) {
+		broken := document()
+		(*broken.Metadata.Relations)["viewer"] = fgaSdk.RelationMetadata{DirectlyRelatedUserTypes: &[]fgaSdk.RelationReference{}}
+		(*broken.Metadata.Relations)["owner"] = fgaSdk.RelationMetadata{DirectlyRelatedUserTypes: &[]fgaSdk.RelationReference{{Type: "user"}}}
+
+		issues := ValidateAuthorizationModel(fgaSdk.WriteAuthorizationModelRequest{
+			SchemaVersion:   "1.1",
+			TypeDefinitions: []fgaSdk.TypeDefinition{user, broken},
+		})
+
+		if findIssue(issues, MODEL_ISSUE_MISSING_DIRECT_USER_TYPES, "document", "viewer") == nil {
+			t.Fatalf("Expected a missing direct user types issue, got %v", issues)
+		}
+		if findIssue(issues, MODEL_ISSUE_UNEXPECTED_DIRECT_USER_TYPES, "document", "owner") == nil {
+			t.Fatalf("Expected an unexpected direct user types issue, got %v", issues)
+		}
+	})
+
+	t.Run("invalid condition parameter types", func(t *testing.T) {
+		withCondition := document()
+		(*withCondition.Metadata.Relations)["viewer"] = fgaSdk.RelationMetadata{DirectlyRelatedUserTypes: &[]fgaSdk.RelationReference{{Type: "user", Condition: fgaSdk.ToPtr("valid")}}}
+
+		issues := ValidateAuthorizationModel(fgaSdk.WriteAuthorizationModelRequest{
+			SchemaVersion:   "1.0",
+			TypeDefinitions: []fgaSdk.TypeDefinition{user, withCondition},
+			Conditions: &map[string]fgaSdk.Condition{
+				"valid": {
+					Name:       "valid",
+					Expression: "x",
+					Parameters: &map[string]fgaSdk.ConditionParamTypeRef{
+						"a": {TypeName: "TYPE_NAME_FLOAT"},
+						"b": {TypeName: fgaSdk.TYPENAME_MAP},
+						"c": {TypeName: fgaSdk.TYPENAME_LIST, GenericTypes: &[]fgaSdk.ConditionParamTypeRef{{TypeName: fgaSdk.TYPENAME_STRING}}},
+					},
+				},
+			},
+		})
+
+		if findIssue(issues, MODEL_ISSUE_UNSUPPORTED_SCHEMA_VERSION, "", "") == nil {
+			t.Fatalf("Expected an unsupported schema version issue, got %v", issues)
+		}
+
+		count := 0
+		for _, issue := range issues {
+			if issue.Code == MODEL_ISSUE_INVALID_CONDITION_PARAMETER_TYPE {
+				count++
+				if issue.Condition != "valid" {
+					t.Fatalf("Expected the issue to reference the condition, got %v", issue)
+				}
+			}
+		}
+		if count != 2 {
+			t.Fatalf("Expected 2 invalid parameter type issues, got %v", issues)
+		}
+		if HasErrors(nil) || !HasErrors(issues) {
+			t.Fatalf("HasErrors() returned an unexpected result")
+		}
+	})
+}
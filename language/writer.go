@@ -0,0 +1,214 @@
+package language
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// TransformModelToDSL renders an authorization model in canonical DSL: types keep their order, while relations, conditions
+// and condition parameters are sorted by name.
+func TransformModelToDSL(model fgaSdk.AuthorizationModel) (string, error) {
+	schemaVersion := model.SchemaVersion
+	if schemaVersion == "" {
+		schemaVersion = supportedSchemaVersion
+	}
+
+	var builder strings.Builder
+	builder.WriteString("model\n  schema " + schemaVersion + "\n")
+
+	for _, typeDefinition := range model.TypeDefinitions {
+		builder.WriteString("\ntype " + typeDefinition.Type + "\n")
+
+		relations := typeDefinition.GetRelations()
+		if len(relations) == 0 {
+			continue
+		}
+
+		builder.WriteString("  relations\n")
+		for _, name := range sortedKeys(relations) {
+			rewrite, err := RenderRelation(typeDefinition, name)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString("    define " + name + ": " + rewrite + "\n")
+		}
+	}
+
+	conditions := model.GetConditions()
+	for _, name := range sortedKeys(conditions) {
+		condition := conditions[name]
+		header, err := renderConditionHeader(condition)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString("\n" + header + " {\n")
+		for _, line := range strings.Split(strings.TrimSpace(condition.Expression), "\n") {
+			builder.WriteString("  " + strings.TrimSpace(line) + "\n")
+		}
+		builder.WriteString("}\n")
+	}
+
+	return builder.String(), nil
+}
+
+// RenderRelation renders the rewrite of a relation of a type as it appears after "define <relation>:" in the DSL
+func RenderRelation(typeDefinition fgaSdk.TypeDefinition, relation string) (string, error) {
+	userset, ok := typeDefinition.GetRelations()[relation]
+	if !ok {
+		return "", fmt.Errorf("relation '%s' is not defined in type '%s'", relation, typeDefinition.Type)
+	}
+
+	var directlyRelatedUserTypes []fgaSdk.RelationReference
+	if typeDefinition.Metadata != nil {
+		if metadata, ok := typeDefinition.Metadata.GetRelations()[relation]; ok {
+			directlyRelatedUserTypes = metadata.GetDirectlyRelatedUserTypes()
+		}
+	}
+
+	return renderRewrite(typeDefinition.Type, relation, userset, directlyRelatedUserTypes)
+}
+
+func renderRewrite(typeName string, relation string, userset fgaSdk.Userset, directlyRelatedUserTypes []fgaSdk.RelationReference) (string, error) {
+	w := &usersetWriter{typeName: typeName, relation: relation, directlyRelatedUserTypes: directlyRelatedUserTypes}
+	return w.render(userset, false)
+}
+
+type usersetWriter struct {
+	typeName                 string
+	relation                 string
+	directlyRelatedUserTypes []fgaSdk.RelationReference
+}
+
+func (w *usersetWriter) render(userset fgaSdk.Userset, nested bool) (string, error) {
+	switch {
+	case userset.This != nil:
+		return RenderRelationReferences(w.directlyRelatedUserTypes), nil
+	case userset.ComputedUserset != nil:
+		if userset.ComputedUserset.GetRelation() == "" {
+			return "", w.invalid("computed userset without a relation")
+		}
+		return userset.ComputedUserset.GetRelation(), nil
+	case userset.TupleToUserset != nil:
+		computed := userset.TupleToUserset.ComputedUserset.GetRelation()
+		tupleset := userset.TupleToUserset.Tupleset.GetRelation()
+		if computed == "" || tupleset == "" {
+			return "", w.invalid("tuple to userset without a relation")
+		}
+		return computed + " from " + tupleset, nil
+	case userset.Union != nil:
+		return w.renderChildren(userset.Union.Child, " or ", nested)
+	case userset.Intersection != nil:
+		return w.renderChildren(userset.Intersection.Child, " and ", nested)
+	case userset.Difference != nil:
+		base, err := w.render(userset.Difference.Base, true)
+		if err != nil {
+			return "", err
+		}
+		subtract, err := w.render(userset.Difference.Subtract, true)
+		if err != nil {
+			return "", err
+		}
+		return wrap(base+" but not "+subtract, nested), nil
+	}
+	return "", w.invalid("empty userset")
+}
+
+func (w *usersetWriter) renderChildren(children []fgaSdk.Userset, operator string, nested bool) (string, error) {
+	if len(children) == 0 {
+		return "", w.invalid("operator without children")
+	}
+
+	rendered := make([]string, 0, len(children))
+	for _, child := range children {
+		text, err := w.render(child, true)
+		if err != nil {
+			return "", err
+		}
+		rendered = append(rendered, text)
+	}
+	if len(rendered) == 1 {
+		return rendered[0], nil
+	}
+	return wrap(strings.Join(rendered, operator), nested), nil
+}
+
+func (w *usersetWriter) invalid(reason string) error {
+	return fmt.Errorf("relation '%s' of type '%s' cannot be rendered: %s", w.relation, w.typeName, reason)
+}
+
+func wrap(text string, nested bool) string {
+	if nested {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+// RenderRelationReferences renders a list of directly related user types, e.g. [user, user:*, group#member with condition]
+func RenderRelationReferences(references []fgaSdk.RelationReference) string {
+	rendered := make([]string, 0, len(references))
+	for _, reference := range references {
+		rendered = append(rendered, RenderRelationReference(reference))
+	}
+	return "[" + strings.Join(rendered, ", ") + "]"
+}
+
+// RenderRelationReference renders a single directly related user type, e.g. group#member with condition
+func RenderRelationReference(reference fgaSdk.RelationReference) string {
+	text := reference.Type
+	if reference.Wildcard != nil {
+		text += ":*"
+	} else if reference.GetRelation() != "" {
+		text += "#" + reference.GetRelation()
+	}
+	if reference.GetCondition() != "" {
+		text += " with " + reference.GetCondition()
+	}
+	return text
+}
+
+func renderConditionHeader(condition fgaSdk.Condition) (string, error) {
+	parameters := condition.GetParameters()
+	rendered := make([]string, 0, len(parameters))
+	for _, name := range sortedKeys(parameters) {
+		typeName, err := RenderConditionParamType(parameters[name])
+		if err != nil {
+			return "", fmt.Errorf("condition '%s' cannot be rendered: %w", condition.Name, err)
+		}
+		rendered = append(rendered, name+": "+typeName)
+	}
+	return "condition " + condition.Name + "(" + strings.Join(rendered, ", ") + ")", nil
+}
+
+// RenderConditionParamType renders the type of a condition parameter, e.g. map<string>
+func RenderConditionParamType(typeRef fgaSdk.ConditionParamTypeRef) (string, error) {
+	for name, typeName := range conditionParamTypeNames {
+		if typeName != typeRef.TypeName {
+			continue
+		}
+		if typeName != fgaSdk.TYPENAME_MAP && typeName != fgaSdk.TYPENAME_LIST {
+			return name, nil
+		}
+		generics := typeRef.GetGenericTypes()
+		if len(generics) != 1 {
+			return "", fmt.Errorf("%s parameters must have exactly one generic type", name)
+		}
+		generic, err := RenderConditionParamType(generics[0])
+		if err != nil {
+			return "", err
+		}
+		return name + "<" + generic + ">", nil
+	}
+	return "", fmt.Errorf("unsupported parameter type '%s'", typeRef.TypeName)
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
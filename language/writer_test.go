@@ -0,0 +1,94 @@
+package language
+
+import (
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+const testCanonicalDSL = `model
+  schema 1.1
+
+type user
+
+type group
+  relations
+    define member: [user, group#member]
+
+type folder
+  relations
+    define viewer: [user, user:*]
+
+type document
+  relations
+    define blocked: [user]
+    define can_delete: owner and editor
+    define editor: [user with non_expired_grant]
+    define owner: [user]
+    define parent: [folder]
+    define viewer: ([user, group#member] or editor or viewer from parent) but not blocked
+
+condition non_expired_grant(current_time: timestamp, grant_duration: duration, grant_time: timestamp, tags: map<string>) {
+  current_time < grant_time + grant_duration &&
+  !("blocked" in tags)
+}
+`
+
+func TestTransformModelToDSL(t *testing.T) {
+	request, err := TransformDSLToModel(testModelDSL)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	dsl, err := TransformModelToDSL(fgaSdk.AuthorizationModel{
+		SchemaVersion:   request.SchemaVersion,
+		TypeDefinitions: request.TypeDefinitions,
+		Conditions:      request.Conditions,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if dsl != testCanonicalDSL {
+		t.Fatalf("TransformModelToDSL() =\n%s\nwant\n%s", dsl, testCanonicalDSL)
+	}
+
+	// the canonical form is stable
+	roundTrip, err := TransformDSLToModel(dsl)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if normalizeJSON(t, roundTrip) != normalizeJSON(t, request) {
+		t.Fatalf("Expected the rendered DSL to parse back into the same model")
+	}
+}
+
+func TestRenderRelationNesting(t *testing.T) {
+	typeDefinition := fgaSdk.TypeDefinition{
+		Type: "document",
+		Relations: &map[string]fgaSdk.Userset{
+			"viewer": {Union: &fgaSdk.Usersets{Child: []fgaSdk.Userset{
+				{ComputedUserset: &fgaSdk.ObjectRelation{Relation: fgaSdk.ToPtr("owner")}},
+				{Intersection: &fgaSdk.Usersets{Child: []fgaSdk.Userset{
+					{ComputedUserset: &fgaSdk.ObjectRelation{Relation: fgaSdk.ToPtr("editor")}},
+					{ComputedUserset: &fgaSdk.ObjectRelation{Relation: fgaSdk.ToPtr("member")}},
+				}}},
+			}}},
+			"broken": {},
+		},
+	}
+
+	rendered, err := RenderRelation(typeDefinition, "viewer")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if rendered != "owner or (editor and member)" {
+		t.Fatalf("RenderRelation() = %q", rendered)
+	}
+
+	if _, err := RenderRelation(typeDefinition, "broken"); err == nil {
+		t.Fatalf("Expected an error for an empty userset")
+	}
+	if _, err := RenderRelation(typeDefinition, "missing"); err == nil {
+		t.Fatalf("Expected an error for an undefined relation")
+	}
+}
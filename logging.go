@@ -0,0 +1,142 @@
+package openfga
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/openfga/go-sdk/internal/utils/logutils"
+)
+
+// Reasons of the retries of a request, reported in the "retry scheduled" events
+const (
+	retryReasonNetwork     = "network"
+	retryReasonRateLimit   = "rate_limit"
+	retryReasonServerError = "server_error"
+	retryReasonApiError    = "api_error"
+	retryReasonDecode      = "decode"
+)
+
+// newLogger returns the logger of an API client, which redacts the secrets of its events, and their users when
+// LogRedactUsers is set
+func newLogger(cfg *Configuration) *slog.Logger {
+	return logutils.New(cfg.Logger, cfg.Debug, cfg.LogRedactUsers)
+}
+
+// retryReason returns the reason of the retry of a failed attempt
+func retryReason(attempt RetryAttempt) string {
+	var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
+	var fgaApiInternalError FgaApiInternalError
+	switch {
+	case attempt.Response == nil:
+		return retryReasonNetwork
+	case errors.As(attempt.Err, &fgaApiRateLimitExceededError):
+		return retryReasonRateLimit
+	case errors.As(attempt.Err, &fgaApiInternalError):
+		return retryReasonServerError
+	case isFgaApiError(attempt.Err):
+		return retryReasonApiError
+	default:
+		return retryReasonDecode
+	}
+}
+
+// requestUser returns the user of the body of a request, empty when it has none
+func requestUser(body interface{}) string {
+	switch body := body.(type) {
+	case *CheckRequest:
+		return body.TupleKey.User
+	case *ListObjectsRequest:
+		return body.User
+	default:
+		return ""
+	}
+}
+
+// logAttrs returns the attributes identifying a request in its events
+func (r apiRequest) logAttrs() []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", r.operationName),
+		slog.Int("attempt", r.attempt),
+	}
+	if r.storeId != "" {
+		attrs = append(attrs, slog.String("store_id", r.storeId))
+	}
+	if user := requestUser(r.body); user != "" {
+		attrs = append(attrs, slog.String("user", user))
+	}
+
+	return attrs
+}
+
+// logRequestStarted logs the start of an attempt of a request
+func (c *APIClient) logRequestStarted(ctx context.Context, r apiRequest, req *http.Request) {
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "request started", append(r.logAttrs(),
+		slog.String("http_method", req.Method),
+		slog.String("url", req.URL.String()),
+	)...)
+}
+
+// logRequestCompleted logs the response of an attempt of a request started at started, or its network error
+func (c *APIClient) logRequestCompleted(ctx context.Context, r apiRequest, httpResponse *http.Response, err error, started time.Time) {
+	attrs := append(r.logAttrs(), slog.Duration("duration", time.Since(started)))
+	if httpResponse != nil {
+		attrs = append(attrs, slog.Int("status_code", httpResponse.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "request completed", attrs...)
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusTooManyRequests {
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "rate limited", append(r.logAttrs(),
+			slog.String("retry_after", httpResponse.Header.Get("Retry-After")),
+		)...)
+	}
+}
+
+// logRetryScheduled logs the retry of a failed attempt of a request after delay
+func (c *APIClient) logRetryScheduled(r apiRequest, attempt RetryAttempt, delay time.Duration) {
+	attrs := append(r.logAttrs(),
+		slog.String("reason", retryReason(attempt)),
+		slog.Duration("delay", delay),
+	)
+	if attempt.Response != nil {
+		attrs = append(attrs, slog.Int("status_code", attempt.Response.StatusCode))
+	}
+	attrs = append(attrs, slog.Any("error", attempt.Err))
+
+	c.logger.LogAttrs(r.ctx, slog.LevelInfo, "retry scheduled", attrs...)
+}
+
+// logRequestFailed logs the error of a request which is not retried
+func (c *APIClient) logRequestFailed(r apiRequest, err error) {
+	c.logger.LogAttrs(r.ctx, slog.LevelWarn, "request failed", append(r.logAttrs(),
+		slog.Duration("duration", time.Since(r.started)),
+		slog.Any("error", err),
+	)...)
+}
+
+// logStreamStarted logs the start of a stream of an operation
+func (c *APIClient) logStreamStarted(ctx context.Context, operationName string, storeId string) {
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "stream started", slog.String("method", operationName), slog.String("store_id", storeId))
+}
+
+// logStreamEnded logs the end of a stream of an operation, with its number of results and the error ending it
+func (c *APIClient) logStreamEnded(ctx context.Context, operationName string, storeId string, results int, duration time.Duration, err error) {
+	attrs := []slog.Attr{
+		slog.String("method", operationName),
+		slog.String("store_id", storeId),
+		slog.Int("results", results),
+		slog.Duration("duration", duration),
+	}
+	level := slog.LevelDebug
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	c.logger.LogAttrs(ctx, level, "stream ended", attrs...)
+}
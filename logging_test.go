@@ -0,0 +1,180 @@
+package openfga
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// logBuffer collects the events of a JSON logger
+type logBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) events(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var events []map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b.buf.Bytes()))
+	for decoder.More() {
+		var event map[string]interface{}
+		if err := decoder.Decode(&event); err != nil {
+			t.Fatalf("failed to decode the event: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func newLoggingTestClient(t *testing.T, handler http.HandlerFunc, redactUsers bool) (*APIClient, *logBuffer) {
+	t.Helper()
+	logs := &logBuffer{}
+	apiClient := newTestAPIClient(t, handler, func(cfg *Configuration) {
+		cfg.DefaultHeaders = map[string]string{"Authorization": "Bearer API_TOKEN"}
+		cfg.Logger = slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		cfg.LogRedactUsers = redactUsers
+	})
+
+	return apiClient, logs
+}
+
+func TestLoggingRequestEvents(t *testing.T) {
+	var attempts int32
+	apiClient, logs := newLoggingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"code":"rate_limit_exceeded","message":"rate limited"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}, false)
+
+	if _, err := checkWith(apiClient); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var messages []string
+	for _, event := range logs.events(t) {
+		messages = append(messages, event["msg"].(string))
+		if event["method"] != "Check" || event["user"] != "user:anne" {
+			t.Errorf("Expected the method and the user of the request on %v", event)
+		}
+		switch event["msg"] {
+		case "rate limited":
+			if event["level"] != slog.LevelWarn.String() {
+				t.Errorf("Expected the rate limit to be a warning, got %v", event)
+			}
+		case "retry scheduled":
+			if event["reason"] != retryReasonRateLimit || event["attempt"] != float64(0) || event["status_code"] != float64(429) {
+				t.Errorf("Expected the retry of the first attempt after a rate limit, got %v", event)
+			}
+		}
+	}
+
+	expected := []string{"request started", "request completed", "rate limited", "retry scheduled", "request started", "request completed"}
+	if len(messages) != len(expected) {
+		t.Fatalf("Expected the events %v, got %v", expected, messages)
+	}
+	for i := range expected {
+		if messages[i] != expected[i] {
+			t.Fatalf("Expected the events %v, got %v", expected, messages)
+		}
+	}
+}
+
+func TestLoggingRequestFailed(t *testing.T) {
+	apiClient, logs := newLoggingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":"validation_error","message":"invalid user"}`))
+	}, true)
+
+	if _, err := checkWith(apiClient); err == nil {
+		t.Fatalf("Expected an error")
+	}
+
+	events := logs.events(t)
+	failed := events[len(events)-1]
+	if failed["msg"] != "request failed" || failed["level"] != slog.LevelWarn.String() || failed["error"] == nil {
+		t.Fatalf("Expected the error of the request to be logged, got %v", failed)
+	}
+	for _, event := range events {
+		if event["user"] != "[REDACTED]" {
+			t.Fatalf("Expected the user to be redacted, got %v", event)
+		}
+	}
+}
+
+func TestLoggingStream(t *testing.T) {
+	apiClient, logs := newLoggingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{\"result\":{\"object\":\"document:1\"}}\n{\"result\":{\"object\":\"document:2\"}}\n"))
+	}, false)
+	request := ListObjectsRequest{Type: "document", Relation: "viewer", User: "user:anne"}
+
+	channel, err := ExecuteStreamedListObjects(apiClient, context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2", request, RequestOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for range channel.Objects {
+	}
+	if err := <-channel.Errors; err != nil {
+		t.Fatalf("Expected the stream to end without error, got %v", err)
+	}
+
+	// the end of the stream is logged once its channels are closed
+	deadline := time.Now().Add(time.Second)
+	for {
+		events := logs.events(t)
+		last := events[len(events)-1]
+		if last["msg"] == "stream ended" {
+			if events[0]["msg"] != "stream started" || last["results"] != float64(2) {
+				t.Fatalf("Expected the stream of 2 results to be logged, got %v", events)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the end of the stream to be logged, got %v", events)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRetryReason(t *testing.T) {
+	response := &http.Response{StatusCode: http.StatusOK}
+	reasons := map[string]RetryAttempt{
+		retryReasonNetwork:     {Err: errors.New("connection refused")},
+		retryReasonRateLimit:   {Err: FgaApiRateLimitExceededError{}, Response: response},
+		retryReasonServerError: {Err: FgaApiInternalError{}, Response: response},
+		retryReasonApiError:    {Err: FgaApiValidationError{}, Response: response},
+		retryReasonDecode:      {Err: io.ErrUnexpectedEOF, Response: response},
+	}
+	for expected, attempt := range reasons {
+		if reason := retryReason(attempt); reason != expected {
+			t.Errorf("Expected the reason of %v to be %s, got %s", attempt.Err, expected, reason)
+		}
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"math"
 	"mime"
 	"net/http"
@@ -20,6 +20,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/openfga/go-sdk/internal/utils/logutils"
 	"github.com/openfga/go-sdk/internal/utils/retryutils"
 	"github.com/openfga/go-sdk/telemetry"
 )
@@ -28,6 +29,10 @@ type RequestConfig struct {
 	RetryParams retryutils.RetryParams
 
 	Debug bool
+
+	// Logger receives the events of the token requests. When nil, they are written to stderr in debug mode, and
+	// discarded otherwise.
+	Logger *slog.Logger
 }
 
 func (rc *RequestConfig) New(config RequestConfig) error {
@@ -38,6 +43,7 @@ func (rc *RequestConfig) New(config RequestConfig) error {
 
 	rc.RetryParams = *retryParams
 	rc.Debug = config.Debug
+	rc.Logger = config.Logger
 
 	return nil
 }
@@ -204,7 +210,23 @@ func cloneURLValues(v url.Values) url.Values {
 	return v2
 }
 
-func RetrieveToken(ctx context.Context, clientID, clientSecret, tokenURL string, v url.Values, authStyle AuthStyle, config RequestConfig) (*Token, error) {
+func RetrieveToken(ctx context.Context, clientID, clientSecret, tokenURL string, v url.Values, authStyle AuthStyle, config RequestConfig) (token *Token, err error) {
+	ctx, span := tracesOf(ctx).StartCredentialsRequest(ctx, map[*telemetry.Attribute]string{
+		telemetry.FGAClientRequestClientID: clientID,
+	})
+	defer func() { span.End(err) }()
+
+	logger := config.logger()
+	defer func() {
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "access token request failed",
+				slog.String("client_id", clientID), slog.String("token_url", tokenURL), slog.Any("error", err))
+			return
+		}
+		logger.LogAttrs(ctx, slog.LevelInfo, "access token refreshed",
+			slog.String("client_id", clientID), slog.String("token_url", tokenURL), slog.Time("expiry", token.Expiry))
+	}()
+
 	needsAuthStyleProbe := authStyle == 0
 	if needsAuthStyleProbe {
 		if style, ok := lookupAuthStyle(tokenURL); ok {
@@ -218,7 +240,7 @@ func RetrieveToken(ctx context.Context, clientID, clientSecret, tokenURL string,
 	if err != nil {
 		return nil, err
 	}
-	token, err := doTokenRoundTrip(ctx, req, config)
+	token, err = doTokenRoundTrip(ctx, req, config, span)
 	if err != nil && needsAuthStyleProbe {
 		// If we get an error, assume the server wants the
 		// clientID & clientSecret in a different form.
@@ -234,7 +256,7 @@ func RetrieveToken(ctx context.Context, clientID, clientSecret, tokenURL string,
 		// So just try both ways.
 		authStyle = AuthStyleInParams // the second way we'll try
 		req, _ = newTokenRequest(tokenURL, clientID, clientSecret, v, authStyle)
-		token, err = doTokenRoundTrip(ctx, req, config)
+		token, err = doTokenRoundTrip(ctx, req, config, span)
 	}
 	if needsAuthStyleProbe && err == nil {
 		setAuthStyle(tokenURL, authStyle)
@@ -303,17 +325,39 @@ func singleTokenRoundTrip(ctx context.Context, req *http.Request) (*Token, error
 	return token, nil
 }
 
-func doTokenRoundTrip(ctx context.Context, req *http.Request, config RequestConfig) (*Token, error) {
+// logger returns the logger of the token requests
+func (rc RequestConfig) logger() *slog.Logger {
+	return logutils.New(rc.Logger, rc.Debug, false)
+}
+
+// tracesOf returns the traces of the telemetry bound to ctx, which start no span when there is none
+func tracesOf(ctx context.Context) *telemetry.Traces {
+	if otel := telemetry.Extract(ctx); otel != nil {
+		return otel.Traces
+	}
+
+	return nil
+}
+
+func doTokenRoundTrip(ctx context.Context, req *http.Request, config RequestConfig, span *telemetry.Span) (*Token, error) {
 	const operationName = "TokenExchange"
 	var token *Token
 	var err error
 
 	maxRetry := config.RetryParams.MaxRetry
 	minWaitInMs := config.RetryParams.MinWaitInMs
-	debug := config.Debug
+	logger := config.logger()
+
+	tracesOf(ctx).Inject(ctx, req.Header)
+	span.SetAttributes(map[*telemetry.Attribute]string{telemetry.HTTPHost: req.URL.Host})
 
 	for i := 0; i <= maxRetry; i++ {
 		token, err = singleTokenRoundTrip(ctx, req)
+		span.SetAttributes(map[*telemetry.Attribute]string{telemetry.HTTPRequestResendCount: strconv.Itoa(i)})
+		var rErr *RetrieveError
+		if errors.As(err, &rErr) {
+			span.SetAttributes(map[*telemetry.Attribute]string{telemetry.HTTPResponseStatusCode: strconv.Itoa(rErr.Response.StatusCode)})
+		}
 		if err == nil {
 			if otel := telemetry.Extract(ctx); otel != nil {
 				attrs := make(map[*telemetry.Attribute]string)
@@ -328,14 +372,16 @@ func doTokenRoundTrip(ctx context.Context, req *http.Request, config RequestConf
 		// We do not want to retry any other error
 		shouldRetry := true
 		responseHeaders := http.Header{}
-		var rErr *RetrieveError
-		errorStyle := "network"
+		reason := "network"
 		if errors.As(err, &rErr) {
 			statusCode := rErr.Response.StatusCode
 			if statusCode == http.StatusTooManyRequests || (statusCode >= http.StatusInternalServerError && statusCode != http.StatusNotImplemented) {
 				shouldRetry = true
 				responseHeaders = rErr.Response.Header
-				errorStyle = "api"
+				reason = "server_error"
+				if statusCode == http.StatusTooManyRequests {
+					reason = "rate_limit"
+				}
 			} else {
 				shouldRetry = false
 			}
@@ -344,8 +390,22 @@ func doTokenRoundTrip(ctx context.Context, req *http.Request, config RequestConf
 		if shouldRetry {
 			timeToWait := retryutils.GetTimeToWait(i, maxRetry, minWaitInMs, responseHeaders, operationName)
 			if timeToWait > 0 {
-				if debug {
-					log.Printf("\nWaiting %v to retry %v (%v %v) due to %s error (error=%v) on attempt %v\n", timeToWait, operationName, req.Method, req.URL, errorStyle, err, i)
+				logger.LogAttrs(ctx, slog.LevelInfo, "retry scheduled",
+					slog.String("method", operationName),
+					slog.String("reason", reason),
+					slog.Duration("delay", timeToWait),
+					slog.Int("attempt", i),
+					slog.Any("error", err),
+				)
+				if otel := telemetry.Extract(ctx); otel != nil {
+					attrs := map[*telemetry.Attribute]string{
+						telemetry.FGAClientRequestMethod:      operationName,
+						telemetry.FGAClientRequestRetryReason: reason,
+					}
+					if rErr != nil {
+						attrs[telemetry.HTTPResponseStatusCode] = strconv.Itoa(rErr.Response.StatusCode)
+					}
+					_, _ = otel.Metrics.RequestRetry(1, attrs)
 				}
 
 				time.Sleep(timeToWait)
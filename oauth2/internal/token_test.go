@@ -5,18 +5,25 @@
 package internal
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"math"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/openfga/go-sdk/internal/utils/retryutils"
+	"github.com/openfga/go-sdk/telemetry"
 )
 
 const (
@@ -268,3 +275,93 @@ func TestExpiresInUpperBound(t *testing.T) {
 		t.Errorf("expiration time = %v; want %v", e, want)
 	}
 }
+
+func TestRetrieveTokenTracing(t *testing.T) {
+	ResetAuthCache()
+	const clientID = "client-id"
+
+	attempts := 0
+	var traceparent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		traceparent = r.Header.Get("traceparent")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"access_token": "ACCESS_TOKEN", "token_type": "bearer"}`)
+	}))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	instance, _ := telemetry.Configure(telemetry.DefaultTelemetryConfiguration())
+	instance.Traces.Tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("openfga-sdk")
+	ctx := telemetry.Bind(context.Background(), instance)
+
+	_, err := RetrieveToken(ctx, clientID, "", ts.URL, url.Values{}, AuthStyleInParams, testTokenRequestConfig)
+	if err != nil {
+		t.Fatalf("RetrieveToken = %v; want no error", err)
+	}
+
+	ended := recorder.Ended()
+	if len(ended) != 1 || ended[0].Name() != telemetry.SPAN_NAME_CREDENTIALS_REQUEST {
+		t.Fatalf("Expected a span for the token request, got %v", ended)
+	}
+	attrs := make(map[string]string)
+	for _, attr := range ended[0].Attributes() {
+		attrs[string(attr.Key)] = attr.Value.AsString()
+	}
+	if attrs[telemetry.ATTR_FGA_CLIENT_REQUEST_CLIENT_ID] != clientID || attrs[telemetry.ATTR_HTTP_REQUEST_RESEND_COUNT] != "1" {
+		t.Fatalf("Expected the client id and the retry number on the span, got %v", attrs)
+	}
+
+	spanContext := ended[0].SpanContext()
+	if traceparent != "00-"+spanContext.TraceID().String()+"-"+spanContext.SpanID().String()+"-01" {
+		t.Fatalf("Expected the trace context of the span to be propagated, got %q", traceparent)
+	}
+}
+
+func TestRetrieveTokenLogging(t *testing.T) {
+	ResetAuthCache()
+
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"access_token": "ACCESS_TOKEN", "token_type": "bearer"}`)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	config := testTokenRequestConfig
+	config.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+
+	_, err := RetrieveToken(context.Background(), "client-id", "CLIENT_SECRET", ts.URL, url.Values{}, AuthStyleInParams, config)
+	if err != nil {
+		t.Fatalf("RetrieveToken = %v; want no error", err)
+	}
+
+	var messages []string
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var event map[string]interface{}
+		if err := decoder.Decode(&event); err != nil {
+			t.Fatalf("failed to decode the event: %v", err)
+		}
+		messages = append(messages, event["msg"].(string))
+		if event["msg"] == "retry scheduled" && event["reason"] != "rate_limit" {
+			t.Fatalf("Expected the retry of a rate limited request, got %v", event)
+		}
+	}
+	if fmt.Sprint(messages) != "[retry scheduled access token refreshed]" {
+		t.Fatalf("Expected the retry and the refresh of the token to be logged, got %v", messages)
+	}
+	if strings.Contains(buf.String(), "ACCESS_TOKEN") || strings.Contains(buf.String(), "CLIENT_SECRET") {
+		t.Fatalf("Expected the secrets not to be logged, got %s", buf.String())
+	}
+}
@@ -0,0 +1,236 @@
+package openfga
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openfga/go-sdk/internal/constants"
+)
+
+// RateLimiterConfiguration configures limits shared by all the requests of an API client, whichever method sends them.
+// Every attempt of a request, including its retries, is counted.
+type RateLimiterConfiguration struct {
+	// RequestsPerSecond is the rate at which requests are sent, unlimited when 0
+	RequestsPerSecond float64 `json:"requests_per_second,omitempty"`
+	// Burst is the number of requests which can be sent at once when no request was sent for a while (default = 1)
+	Burst int `json:"burst,omitempty"`
+	// MaxInFlightRequests is the number of requests waiting for a response at the same time, unlimited when 0
+	MaxInFlightRequests int `json:"max_in_flight_requests,omitempty"`
+	// Adaptive halves the in-flight limit when a request is rate limited by the server, and grows it back by one
+	// request once as many requests as the limit succeeded, up to MaxInFlightRequests. Requires MaxInFlightRequests.
+	Adaptive bool `json:"adaptive,omitempty"`
+	// MinInFlightRequests is the lowest in-flight limit of the adaptive mode (default = 1)
+	MinInFlightRequests int `json:"min_in_flight_requests,omitempty"`
+}
+
+// Validate ensures that the rate limiter configuration is valid
+func (c *RateLimiterConfiguration) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.RequestsPerSecond < 0 || c.Burst < 0 || c.MaxInFlightRequests < 0 || c.MinInFlightRequests < 0 {
+		return reportError("RateLimiter limits cannot be negative")
+	}
+
+	if c.Adaptive && c.MaxInFlightRequests == 0 {
+		return reportError("RateLimiter.Adaptive requires RateLimiter.MaxInFlightRequests")
+	}
+
+	if c.MinInFlightRequests > c.MaxInFlightRequests && c.MaxInFlightRequests > 0 {
+		return reportError("RateLimiter.MinInFlightRequests (%d) cannot be greater than RateLimiter.MaxInFlightRequests (%d)", c.MinInFlightRequests, c.MaxInFlightRequests)
+	}
+
+	return nil
+}
+
+// rateLimiterOutcome is how the result of a request adjusts the adaptive in-flight limit
+type rateLimiterOutcome int
+
+const (
+	rateLimiterSuccess rateLimiterOutcome = iota
+	rateLimiterRateLimited
+	rateLimiterIgnored
+)
+
+// rateLimiterOutcomeOf returns how a response adjusts the adaptive in-flight limit
+func rateLimiterOutcomeOf(statusCode int) rateLimiterOutcome {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return rateLimiterRateLimited
+	case statusCode < http.StatusMultipleChoices:
+		return rateLimiterSuccess
+	default:
+		return rateLimiterIgnored
+	}
+}
+
+// rateLimiter is a token bucket limiting the rate of the requests of an API client, combined with a cap of the
+// requests in flight
+type rateLimiter struct {
+	config RateLimiterConfiguration
+	now    func() time.Time
+
+	lock sync.Mutex
+	// tokens is negative when requests are waiting for their turn
+	tokens    float64
+	refilled  time.Time
+	inFlight  int
+	limit     float64
+	minLimit  float64
+	successes int
+	// epoch changes when the limit is decreased, so that the requests sent before only decrease it once
+	epoch uint64
+	// released is closed and replaced whenever a request completes or the limit grows, to wake up the waiting requests
+	released chan struct{}
+}
+
+func newRateLimiter(cfg *Configuration) *rateLimiter {
+	if cfg.RateLimiter == nil {
+		return nil
+	}
+
+	config := *cfg.RateLimiter
+	if config.Burst == 0 {
+		config.Burst = constants.DefaultRateLimiterBurst
+	}
+	minLimit := config.MinInFlightRequests
+	if minLimit == 0 {
+		minLimit = constants.DefaultRateLimiterMinInFlightRequests
+	}
+
+	return &rateLimiter{
+		config:   config,
+		now:      time.Now,
+		tokens:   float64(config.Burst),
+		refilled: time.Now(),
+		limit:    float64(config.MaxInFlightRequests),
+		minLimit: float64(minLimit),
+		released: make(chan struct{}),
+	}
+}
+
+// acquire waits until a request can be sent, then returns the function to call with its outcome once its response is
+// received. It returns the error of ctx when ctx is done first.
+func (l *rateLimiter) acquire(ctx context.Context) (func(rateLimiterOutcome), error) {
+	if l == nil {
+		return func(rateLimiterOutcome) {}, nil
+	}
+
+	epoch, err := l.acquireSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.waitForToken(ctx); err != nil {
+		l.release(epoch, rateLimiterIgnored)
+		return nil, err
+	}
+
+	var once sync.Once
+	return func(outcome rateLimiterOutcome) {
+		once.Do(func() { l.release(epoch, outcome) })
+	}, nil
+}
+
+// acquireSlot waits until the requests in flight are below the limit
+func (l *rateLimiter) acquireSlot(ctx context.Context) (uint64, error) {
+	for {
+		l.lock.Lock()
+		if l.config.MaxInFlightRequests == 0 || l.inFlight < int(l.limit) {
+			l.inFlight++
+			epoch := l.epoch
+			l.lock.Unlock()
+			return epoch, nil
+		}
+		released := l.released
+		l.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// waitForToken takes a token of the bucket, waiting for it to be refilled when it is empty
+func (l *rateLimiter) waitForToken(ctx context.Context) error {
+	if l.config.RequestsPerSecond == 0 {
+		return nil
+	}
+
+	l.lock.Lock()
+	now := l.now()
+	if elapsed := now.Sub(l.refilled); elapsed > 0 {
+		l.tokens = math.Min(float64(l.config.Burst), l.tokens+elapsed.Seconds()*l.config.RequestsPerSecond)
+	}
+	l.refilled = now
+	// the token is taken right away, so the requests waiting for the bucket to be refilled are sent in turn
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.config.RequestsPerSecond * float64(time.Second))
+	l.lock.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.lock.Lock()
+		l.tokens++
+		l.lock.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// release frees the slot of a request, adjusting the adaptive limit with its outcome
+func (l *rateLimiter) release(epoch uint64, outcome rateLimiterOutcome) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.inFlight--
+	if l.config.Adaptive {
+		switch outcome {
+		case rateLimiterRateLimited:
+			if epoch == l.epoch {
+				l.limit = math.Max(l.minLimit, math.Floor(l.limit/2))
+				l.successes = 0
+				l.epoch++
+			}
+		case rateLimiterSuccess:
+			l.successes++
+			if l.successes >= int(l.limit) && l.limit < float64(l.config.MaxInFlightRequests) {
+				l.limit++
+				l.successes = 0
+			}
+		}
+	}
+
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// InFlightLimit returns the current limit of the requests in flight, or 0 when it is unlimited
+func (l *rateLimiter) InFlightLimit() int {
+	if l == nil {
+		return 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return int(l.limit)
+}
+
+// RateLimiterInFlightLimit returns the current limit of the requests in flight of the rate limiter, which changes over
+// time in adaptive mode, or 0 when it is unlimited
+func (c *APIClient) RateLimiterInFlightLimit() int {
+	return c.rateLimiter.InFlightLimit()
+}
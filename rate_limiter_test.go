@@ -0,0 +1,188 @@
+package openfga
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterConfigurationValidate(t *testing.T) {
+	invalid := map[string]RateLimiterConfiguration{
+		"negative rate":                {RequestsPerSecond: -1},
+		"negative in-flight":           {MaxInFlightRequests: -1},
+		"adaptive without in-flight":   {Adaptive: true},
+		"minimum greater than maximum": {MaxInFlightRequests: 2, MinInFlightRequests: 3, Adaptive: true},
+	}
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080", RateLimiter: &config})
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+		})
+	}
+
+	cfg, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080", RateLimiter: &RateLimiterConfiguration{
+		RequestsPerSecond:   100,
+		MaxInFlightRequests: 8,
+		Adaptive:            true,
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.RateLimiter == nil || cfg.RateLimiter.MaxInFlightRequests != 8 {
+		t.Fatalf("Expected the rate limiter configuration to be kept, got %+v", cfg.RateLimiter)
+	}
+}
+
+func TestRateLimiterRequestsPerSecond(t *testing.T) {
+	limiter := newRateLimiter(&Configuration{RateLimiter: &RateLimiterConfiguration{RequestsPerSecond: 50, Burst: 2}})
+
+	started := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		release(rateLimiterSuccess)
+	}
+	// the burst lets 2 requests through right away, the 3 others are sent every 20ms
+	if elapsed := time.Since(started); elapsed < 55*time.Millisecond {
+		t.Fatalf("Expected the requests to be spread over 60ms, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := limiter.acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the wait to stop with the context, got %v", err)
+	}
+}
+
+func TestRateLimiterInFlight(t *testing.T) {
+	limiter := newRateLimiter(&Configuration{RateLimiter: &RateLimiterConfiguration{MaxInFlightRequests: 2}})
+
+	first, _ := limiter.acquire(context.Background())
+	_, _ = limiter.acquire(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a third request to wait, got %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := limiter.acquire(context.Background())
+		if err == nil {
+			release(rateLimiterSuccess)
+		}
+		close(acquired)
+	}()
+	first(rateLimiterSuccess)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a released slot to let a waiting request through")
+	}
+}
+
+func TestRateLimiterAdaptive(t *testing.T) {
+	limiter := newRateLimiter(&Configuration{RateLimiter: &RateLimiterConfiguration{MaxInFlightRequests: 8, MinInFlightRequests: 2, Adaptive: true}})
+
+	// requests sent before the limit decreased only decrease it once
+	releases := make([]func(rateLimiterOutcome), 3)
+	for index := range releases {
+		releases[index], _ = limiter.acquire(context.Background())
+	}
+	for _, release := range releases {
+		release(rateLimiterRateLimited)
+	}
+	if limit := limiter.InFlightLimit(); limit != 4 {
+		t.Fatalf("Expected the limit to be halved once, got %d", limit)
+	}
+
+	for i := 0; i < 2; i++ {
+		release, _ := limiter.acquire(context.Background())
+		release(rateLimiterRateLimited)
+	}
+	if limit := limiter.InFlightLimit(); limit != 2 {
+		t.Fatalf("Expected the limit not to go below the minimum, got %d", limit)
+	}
+
+	for i := 0; i < 2; i++ {
+		release, _ := limiter.acquire(context.Background())
+		release(rateLimiterSuccess)
+	}
+	if limit := limiter.InFlightLimit(); limit != 3 {
+		t.Fatalf("Expected the limit to grow by one after as many successes, got %d", limit)
+	}
+	for i := 0; i < 100; i++ {
+		release, _ := limiter.acquire(context.Background())
+		release(rateLimiterSuccess)
+	}
+	if limit := limiter.InFlightLimit(); limit != 8 {
+		t.Fatalf("Expected the limit to grow back to the maximum, got %d", limit)
+	}
+}
+
+func TestRateLimiterSharedByRequests(t *testing.T) {
+	var inFlight, maxInFlight, rateLimited int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&rateLimited, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"code":"rate_limit_exceeded","message":"Rate Limit exceeded"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}))
+	defer server.Close()
+
+	cfg, err := NewConfiguration(Configuration{
+		ApiUrl:      server.URL,
+		RetryParams: &RetryParams{MaxRetry: 3, MinWaitInMs: 1},
+		HTTPClient:  &http.Client{},
+		RateLimiter: &RateLimiterConfiguration{MaxInFlightRequests: 4, Adaptive: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to create configuration: %v", err)
+	}
+	apiClient := NewAPIClient(cfg)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := apiClient.OpenFgaApi.Check(context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2").
+				Body(CheckRequest{TupleKey: CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}).
+				Execute()
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxInFlight); got > 4 {
+		t.Fatalf("Expected at most 4 requests in flight, got %d", got)
+	}
+	if limit := apiClient.RateLimiterInFlightLimit(); limit < 2 || limit > 4 {
+		t.Fatalf("Expected the limit to be halved then grow back, got %d", limit)
+	}
+}
@@ -0,0 +1,251 @@
+package openfga
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openfga/go-sdk/internal/constants"
+	"github.com/openfga/go-sdk/internal/utils/retryutils"
+)
+
+// RetryAttempt is a failed attempt of a request
+type RetryAttempt struct {
+	// OperationName is the name of the API operation, e.g. Check or Write
+	OperationName string
+	// Attempt is the number of the attempt, 0 for the first one
+	Attempt int
+	// Err is the error of the attempt: the FgaApi*Error returned by the API, a network error, or the error of reading
+	// the response body
+	Err error
+	// Response is the response of the attempt, nil on a network error
+	Response *http.Response
+}
+
+// RetryPolicy decides whether a failed attempt of a request is retried, and when
+type RetryPolicy interface {
+	// ShouldRetry reports whether the request is sent again after the attempt
+	ShouldRetry(attempt RetryAttempt) bool
+	// RetryDelay returns the time to wait before sending the request again
+	RetryDelay(attempt RetryAttempt) time.Duration
+}
+
+// DefaultRetryPolicy retries network errors, unreadable responses, rate limits and server errors other than 501 up to
+// RetryParams.MaxRetry times, waiting for the delay requested by the API or an exponential backoff with jitter
+type DefaultRetryPolicy struct {
+	RetryParams RetryParams
+}
+
+// ShouldRetry reports whether the request is sent again after the attempt
+func (p DefaultRetryPolicy) ShouldRetry(attempt RetryAttempt) bool {
+	if attempt.Attempt >= p.RetryParams.MaxRetry {
+		return false
+	}
+
+	var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
+	var fgaApiInternalError FgaApiInternalError
+	switch {
+	case errors.As(attempt.Err, &fgaApiRateLimitExceededError):
+		return true
+	case errors.As(attempt.Err, &fgaApiInternalError):
+		return fgaApiInternalError.ShouldRetry()
+	default:
+		return !isFgaApiError(attempt.Err)
+	}
+}
+
+// RetryDelay returns the time to wait before sending the request again
+func (p DefaultRetryPolicy) RetryDelay(attempt RetryAttempt) time.Duration {
+	headers := http.Header{}
+	if attempt.Response != nil {
+		headers = attempt.Response.Header
+	}
+
+	return retryutils.GetTimeToWait(attempt.Attempt, p.RetryParams.MaxRetry, p.RetryParams.MinWaitInMs, headers, attempt.OperationName)
+}
+
+// isFgaApiError reports whether err is an error response of the API
+func isFgaApiError(err error) bool {
+	var fgaApiError FgaApiError
+	var fgaApiAuthenticationError FgaApiAuthenticationError
+	var fgaApiValidationError FgaApiValidationError
+	var fgaApiNotFoundError FgaApiNotFoundError
+	var fgaApiInternalError FgaApiInternalError
+	var fgaApiRateLimitExceededError FgaApiRateLimitExceededError
+
+	return errors.As(err, &fgaApiError) || errors.As(err, &fgaApiAuthenticationError) ||
+		errors.As(err, &fgaApiValidationError) || errors.As(err, &fgaApiNotFoundError) ||
+		errors.As(err, &fgaApiInternalError) || errors.As(err, &fgaApiRateLimitExceededError)
+}
+
+// RetryBudgetConfiguration configures a token bucket shared by all the requests of an API client, which every
+// retry takes a token from. Requests add tokens back, so that retries stay a fraction of the requests when the API
+// keeps failing instead of multiplying the load on it.
+type RetryBudgetConfiguration struct {
+	// MaxTokens is the size of the bucket, full when the client is created (default = 10)
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// TokensPerRequest is the number of tokens a request adds to the bucket (default = 0.1), i.e. the ratio of
+	// retries to requests once the bucket is empty
+	TokensPerRequest float64 `json:"tokens_per_request,omitempty"`
+}
+
+// RetryConfiguration configures the retry policies of an API client
+type RetryConfiguration struct {
+	// Policy decides the retries of the operations without a policy in Operations. When nil, DefaultRetryPolicy
+	// applies with the RetryParams of the configuration.
+	Policy RetryPolicy `json:"-"`
+	// Operations overrides Policy by operation name, e.g. "Check" or "Write"
+	Operations map[string]RetryPolicy `json:"-"`
+	// MaxRetryDurationInMs caps the time from the first attempt of a request after which it is no longer retried,
+	// unlimited when 0
+	MaxRetryDurationInMs int `json:"max_retry_duration_in_ms,omitempty"`
+	// Budget - optional retry budget shared by the requests of the client, disabled when nil
+	Budget *RetryBudgetConfiguration `json:"budget,omitempty"`
+}
+
+// Validate ensures that the retry configuration is valid
+func (c *RetryConfiguration) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.MaxRetryDurationInMs < 0 {
+		return reportError("Retry.MaxRetryDurationInMs cannot be negative")
+	}
+
+	for operationName, policy := range c.Operations {
+		if policy == nil {
+			return reportError("Retry.Operations[%s] cannot be nil", operationName)
+		}
+	}
+
+	if c.Budget != nil && (c.Budget.MaxTokens < 0 || c.Budget.TokensPerRequest < 0) {
+		return reportError("Retry.Budget cannot be negative")
+	}
+
+	return nil
+}
+
+// retryPolicies holds the retry policies of an API client
+type retryPolicies struct {
+	policy      RetryPolicy
+	operations  map[string]RetryPolicy
+	maxDuration time.Duration
+	budget      *retryBudget
+}
+
+func newRetryPolicies(cfg *Configuration) *retryPolicies {
+	policies := &retryPolicies{
+		policy: DefaultRetryPolicy{RetryParams: retryutils.GetRetryParamsOrDefault(cfg.RetryParams)},
+	}
+	if cfg.Retry == nil {
+		return policies
+	}
+
+	if cfg.Retry.Policy != nil {
+		policies.policy = cfg.Retry.Policy
+	}
+	policies.operations = cfg.Retry.Operations
+	policies.maxDuration = time.Duration(cfg.Retry.MaxRetryDurationInMs) * time.Millisecond
+	policies.budget = newRetryBudget(cfg.Retry.Budget)
+
+	return policies
+}
+
+// get returns the retry policy of an operation
+func (p *retryPolicies) get(operationName string) RetryPolicy {
+	if policy, ok := p.operations[operationName]; ok {
+		return policy
+	}
+
+	return p.policy
+}
+
+// retryDelay returns the time to wait before retrying a failed attempt of a request started at started, or false when
+// the request is not retried, including when ctx is done or the wait would end after its deadline
+func (p *retryPolicies) retryDelay(ctx context.Context, attempt RetryAttempt, started time.Time) (time.Duration, bool) {
+	if ctx.Err() != nil {
+		return 0, false
+	}
+
+	policy := p.get(attempt.OperationName)
+	if !policy.ShouldRetry(attempt) {
+		return 0, false
+	}
+
+	delay := max(policy.RetryDelay(attempt), 0)
+	if p.maxDuration > 0 && time.Since(started)+delay > p.maxDuration {
+		return 0, false
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return 0, false
+	}
+
+	if !p.budget.withdraw() {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+// retryBudget is a token bucket which requests fill and retries empty
+type retryBudget struct {
+	lock             sync.Mutex
+	tokens           float64
+	maxTokens        float64
+	tokensPerRequest float64
+}
+
+func newRetryBudget(config *RetryBudgetConfiguration) *retryBudget {
+	if config == nil {
+		return nil
+	}
+
+	maxTokens := config.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = constants.DefaultRetryBudgetMaxTokens
+	}
+	tokensPerRequest := config.TokensPerRequest
+	if tokensPerRequest == 0 {
+		tokensPerRequest = constants.DefaultRetryBudgetTokensPerRequest
+	}
+
+	return &retryBudget{
+		tokens:           float64(maxTokens),
+		maxTokens:        float64(maxTokens),
+		tokensPerRequest: tokensPerRequest,
+	}
+}
+
+// deposit adds the tokens of a request to the bucket
+func (b *retryBudget) deposit() {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.tokens = math.Min(b.maxTokens, b.tokens+b.tokensPerRequest)
+}
+
+// withdraw takes the token of a retry from the bucket, returning false when it is empty
+func (b *retryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+
+	return true
+}
@@ -0,0 +1,212 @@
+package openfga
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fixedRetryPolicy retries every error up to maxRetry times after delay
+type fixedRetryPolicy struct {
+	maxRetry int
+	delay    time.Duration
+}
+
+func (p fixedRetryPolicy) ShouldRetry(attempt RetryAttempt) bool {
+	return attempt.Attempt < p.maxRetry
+}
+
+func (p fixedRetryPolicy) RetryDelay(RetryAttempt) time.Duration {
+	return p.delay
+}
+
+func newTestErrorResponse(statusCode int) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Request:    &http.Request{Method: http.MethodPost, URL: &url.URL{Host: "api.fga.example"}},
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy{RetryParams: RetryParams{MaxRetry: 2, MinWaitInMs: 10}}
+
+	rateLimited := newTestErrorResponse(http.StatusTooManyRequests)
+	unavailable := newTestErrorResponse(http.StatusServiceUnavailable)
+	notImplemented := newTestErrorResponse(http.StatusNotImplemented)
+	badRequest := newTestErrorResponse(http.StatusBadRequest)
+	attempts := map[string]struct {
+		attempt RetryAttempt
+		retried bool
+	}{
+		"network error": {RetryAttempt{Err: errors.New("connection reset")}, true},
+		"rate limit":    {RetryAttempt{Err: NewFgaApiRateLimitExceededError("Check", nil, rateLimited, nil, ""), Response: rateLimited}, true},
+		"server error":  {RetryAttempt{Err: NewFgaApiInternalError("Check", nil, unavailable, nil, ""), Response: unavailable}, true},
+		"501":           {RetryAttempt{Err: NewFgaApiInternalError("Check", nil, notImplemented, nil, ""), Response: notImplemented}, false},
+		"bad request":   {RetryAttempt{Err: NewFgaApiValidationError("Check", nil, badRequest, nil, ""), Response: badRequest}, false},
+		"last attempt":  {RetryAttempt{Attempt: 2, Err: errors.New("connection reset")}, false},
+	}
+	for name, test := range attempts {
+		t.Run(name, func(t *testing.T) {
+			test.attempt.OperationName = "Check"
+			if retried := policy.ShouldRetry(test.attempt); retried != test.retried {
+				t.Fatalf("Expected retried=%v, got %v", test.retried, retried)
+			}
+		})
+	}
+
+	rateLimited.Header.Set("Retry-After", "2")
+	if delay := policy.RetryDelay(RetryAttempt{OperationName: "Check", Response: rateLimited}); delay != 2*time.Second {
+		t.Fatalf("Expected the delay of the Retry-After header, got %v", delay)
+	}
+	if delay := policy.RetryDelay(RetryAttempt{OperationName: "Check", Attempt: 1}); delay < 20*time.Millisecond || delay > 40*time.Millisecond {
+		t.Fatalf("Expected an exponential backoff, got %v", delay)
+	}
+}
+
+func TestRetryConfigurationValidate(t *testing.T) {
+	invalid := map[string]RetryConfiguration{
+		"negative duration": {MaxRetryDurationInMs: -1},
+		"nil policy":        {Operations: map[string]RetryPolicy{"Check": nil}},
+		"negative budget":   {Budget: &RetryBudgetConfiguration{TokensPerRequest: -1}},
+	}
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080", Retry: &config})
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+		})
+	}
+}
+
+// newUnavailableTestClient returns a client of a server failing every request with a 503, and its number of requests
+func newUnavailableTestClient(t *testing.T, retry *RetryConfiguration) (*APIClient, *int32) {
+	t.Helper()
+	var attempts int32
+	apiClient := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"code":"internal_error","message":"unavailable"}`))
+	}, func(cfg *Configuration) {
+		cfg.RetryParams.MaxRetry = 2
+		cfg.Retry = retry
+	})
+
+	return apiClient, &attempts
+}
+
+func TestRetryPolicyPerOperation(t *testing.T) {
+	apiClient, attempts := newUnavailableTestClient(t, &RetryConfiguration{
+		Operations: map[string]RetryPolicy{
+			"Check": fixedRetryPolicy{maxRetry: 5, delay: time.Millisecond},
+			"Write": fixedRetryPolicy{maxRetry: 0},
+		},
+	})
+
+	if _, err := checkWith(apiClient); err == nil {
+		t.Fatalf("Expected an error")
+	}
+	if got := atomic.SwapInt32(attempts, 0); got != 6 {
+		t.Fatalf("Expected the policy of Check to retry 5 times, got %d attempts", got)
+	}
+
+	_, _, err := apiClient.OpenFgaApi.Write(context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2").Body(WriteRequest{}).Execute()
+	var internalErr FgaApiInternalError
+	if !errors.As(err, &internalErr) {
+		t.Fatalf("Expected the error of the API, got %v", err)
+	}
+	if got := atomic.SwapInt32(attempts, 0); got != 1 {
+		t.Fatalf("Expected the policy of Write not to retry, got %d attempts", got)
+	}
+
+	if _, _, err := apiClient.OpenFgaApi.ReadAuthorizationModels(context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2").Execute(); err == nil {
+		t.Fatalf("Expected an error")
+	}
+	if got := atomic.SwapInt32(attempts, 0); got != 3 {
+		t.Fatalf("Expected the default policy to retry twice, got %d attempts", got)
+	}
+}
+
+func TestRetryPolicyMaxRetryDuration(t *testing.T) {
+	apiClient, attempts := newUnavailableTestClient(t, &RetryConfiguration{
+		Policy:               fixedRetryPolicy{maxRetry: 10, delay: 40 * time.Millisecond},
+		MaxRetryDurationInMs: 100,
+	})
+
+	started := time.Now()
+	if _, err := checkWith(apiClient); err == nil {
+		t.Fatalf("Expected an error")
+	}
+	if got := atomic.LoadInt32(attempts); got < 2 || got > 3 {
+		t.Fatalf("Expected the retries to stop within 100ms, got %d attempts", got)
+	}
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Fatalf("Expected the retries to stop within 100ms, took %v", elapsed)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	apiClient, attempts := newUnavailableTestClient(t, &RetryConfiguration{
+		Policy: fixedRetryPolicy{maxRetry: 3},
+		Budget: &RetryBudgetConfiguration{MaxTokens: 4, TokensPerRequest: 0.5},
+	})
+
+	// the bucket starts with 4 tokens, and each request adds half a token
+	expected := []int32{3, 1, 1, 0, 1}
+	for index, retries := range expected {
+		if _, err := checkWith(apiClient); err == nil {
+			t.Fatalf("Expected an error")
+		}
+		if got := atomic.SwapInt32(attempts, 0) - 1; got != retries {
+			t.Fatalf("Expected request %d to be retried %d times, got %d", index, retries, got)
+		}
+	}
+}
+
+// countingTransport counts the attempts sent by a client, including those which fail before reaching the server
+type countingTransport struct {
+	attempts int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.attempts, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRetryStopsWhenContextCancelled(t *testing.T) {
+	transport := &countingTransport{}
+	apiClient := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"code":"internal_error","message":"unavailable"}`))
+	}, func(cfg *Configuration) {
+		cfg.HTTPClient = &http.Client{Transport: transport}
+		// a policy retrying every error, including the network error of a cancelled request
+		cfg.Retry = &RetryConfiguration{Policy: fixedRetryPolicy{maxRetry: 5, delay: time.Minute}}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	started := time.Now()
+	_, _, err := apiClient.OpenFgaApi.Check(ctx, "01GXSB9YR785C4FYS3C0RTG7B2").
+		Body(CheckRequest{TupleKey: CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}).
+		Execute()
+	var internalErr FgaApiInternalError
+	if !errors.As(err, &internalErr) {
+		t.Fatalf("Expected the error of the last attempt, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Expected the retry wait to end with the context, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(&transport.attempts); got != 1 {
+		t.Fatalf("Expected no attempt after the context was cancelled, got %d attempts", got)
+	}
+}
@@ -0,0 +1,436 @@
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/language"
+)
+
+const (
+	// DefaultStoreName is the name of the temporary store when neither the file nor RunOptions name it
+	DefaultStoreName = "storetest"
+	// tuplesPerWrite is the number of tuples written per request, the maximum the server accepts
+	tuplesPerWrite = 100
+	// maxTestTuples is the maximum number of tuples of a test, which are sent as the contextual tuples of its queries
+	maxTestTuples = 100
+)
+
+// RunOptions configures Run
+type RunOptions struct {
+	// StoreName is the name of the temporary store. Defaults to the name of the store file, or DefaultStoreName.
+	StoreName string
+	// KeepStore leaves the temporary store in place after the run, to inspect it
+	KeepStore bool
+}
+
+// Result is the outcome of running a store file
+type Result struct {
+	StoreId              string
+	AuthorizationModelId string
+	Tests                []TestResult
+}
+
+// Passed reports whether every expectation of every test was met
+func (r *Result) Passed() bool {
+	for _, test := range r.Tests {
+		if !test.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a report listing every test with PASS or FAIL, followed by the failed expectations
+func (r *Result) String() string {
+	var report strings.Builder
+	for _, test := range r.Tests {
+		failures := test.Failures()
+		if len(failures) == 0 {
+			fmt.Fprintf(&report, "PASS %s\n", test.Name)
+			continue
+		}
+		fmt.Fprintf(&report, "FAIL %s\n", test.Name)
+		for _, failure := range failures {
+			for _, line := range strings.Split(failure, "\n") {
+				fmt.Fprintf(&report, "    %s\n", line)
+			}
+		}
+	}
+	return report.String()
+}
+
+// TestResult is the outcome of the expectations of a test
+type TestResult struct {
+	Name        string
+	Checks      []CheckResult
+	ListObjects []ListObjectsResult
+	ListUsers   []ListUsersResult
+}
+
+// Passed reports whether every expectation of the test was met
+func (r TestResult) Passed() bool {
+	return len(r.Failures()) == 0
+}
+
+// Failures describes the expectations of the test which were not met
+func (r TestResult) Failures() []string {
+	var failures []string
+	for _, check := range r.Checks {
+		if !check.Passed() {
+			failures = append(failures, check.String())
+		}
+	}
+	for _, listObjects := range r.ListObjects {
+		if !listObjects.Passed() {
+			failures = append(failures, listObjects.String())
+		}
+	}
+	for _, listUsers := range r.ListUsers {
+		if !listUsers.Passed() {
+			failures = append(failures, listUsers.String())
+		}
+	}
+	return failures
+}
+
+// CheckResult is the outcome of a check expectation. Allowed is nil when the check failed with Error.
+type CheckResult struct {
+	User     string
+	Relation string
+	Object   string
+	Expected bool
+	Allowed  *bool
+	Error    error
+}
+
+// Passed reports whether the check returned the expected result
+func (r CheckResult) Passed() bool {
+	return r.Error == nil && r.Allowed != nil && *r.Allowed == r.Expected
+}
+
+func (r CheckResult) String() string {
+	query := fmt.Sprintf("check %s %s %s", r.User, r.Relation, r.Object)
+	if r.Error != nil {
+		return fmt.Sprintf("%s: %s", query, r.Error)
+	}
+	if r.Allowed == nil {
+		return fmt.Sprintf("%s: expected %t, got no result", query, r.Expected)
+	}
+	return fmt.Sprintf("%s: expected %t, got %t", query, r.Expected, *r.Allowed)
+}
+
+// ListObjectsResult is the outcome of a list_objects expectation
+type ListObjectsResult struct {
+	User     string
+	Relation string
+	Type     string
+	Expected []string
+	Objects  []string
+	Error    error
+}
+
+// Passed reports whether exactly the expected objects were returned, in any order
+func (r ListObjectsResult) Passed() bool {
+	return r.Error == nil && len(diff(r.Expected, r.Objects)) == 0
+}
+
+// String describes the result, with the missing objects prefixed by "-" and the unexpected ones by "+"
+func (r ListObjectsResult) String() string {
+	return describeList(fmt.Sprintf("list_objects %s %s %s", r.User, r.Relation, r.Type), r.Expected, r.Objects, r.Error)
+}
+
+// ListUsersResult is the outcome of a list_users expectation
+type ListUsersResult struct {
+	Object     string
+	Relation   string
+	UserFilter []fgaSdk.UserTypeFilter
+	Expected   []string
+	Users      []string
+	Error      error
+}
+
+// Passed reports whether exactly the expected users were returned, in any order
+func (r ListUsersResult) Passed() bool {
+	return r.Error == nil && len(diff(r.Expected, r.Users)) == 0
+}
+
+// String describes the result, with the missing users prefixed by "-" and the unexpected ones by "+"
+func (r ListUsersResult) String() string {
+	filters := make([]string, 0, len(r.UserFilter))
+	for _, filter := range r.UserFilter {
+		if filter.Relation != nil {
+			filters = append(filters, filter.Type+"#"+*filter.Relation)
+		} else {
+			filters = append(filters, filter.Type)
+		}
+	}
+	query := fmt.Sprintf("list_users %s %s %s", strings.Join(filters, ","), r.Relation, r.Object)
+	return describeList(query, r.Expected, r.Users, r.Error)
+}
+
+func describeList(query string, expected []string, got []string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("%s: %s", query, err)
+	}
+	lines := []string{query + ":"}
+	return strings.Join(append(lines, diff(expected, got)...), "\n")
+}
+
+// diff returns the values missing from got prefixed by "- ", followed by the unexpected ones prefixed by "+ "
+func diff(expected []string, got []string) []string {
+	gotSet := make(map[string]bool, len(got))
+	for _, value := range got {
+		gotSet[value] = true
+	}
+	expectedSet := make(map[string]bool, len(expected))
+	var lines []string
+	for _, value := range sorted(expected) {
+		expectedSet[value] = true
+		if !gotSet[value] {
+			lines = append(lines, "- "+value)
+		}
+	}
+	for _, value := range sorted(got) {
+		if !expectedSet[value] {
+			lines = append(lines, "+ "+value)
+		}
+	}
+	return lines
+}
+
+func sorted(values []string) []string {
+	values = append([]string{}, values...)
+	sort.Strings(values)
+	return values
+}
+
+// Run creates a temporary store with the model and tuples of the store file, evaluates the expectations of its tests
+// and deletes the store. Expectations which fail, including on a query error, are reported in the Result; the
+// returned error is only set when the store could not be set up or deleted. options may be nil.
+func Run(ctx context.Context, fgaClient client.SdkClient, storeFile *StoreFile, options *RunOptions) (result *Result, err error) {
+	if options == nil {
+		options = &RunOptions{}
+	}
+	model, err := language.TransformDSLToModel(storeFile.Model)
+	if err != nil {
+		return nil, fmt.Errorf("parsing model: %w", err)
+	}
+	for _, test := range storeFile.Tests {
+		if len(test.Tuples) > maxTestTuples {
+			return nil, fmt.Errorf("test %s has %d tuples, more than the %d contextual tuples the server accepts per query: move them to the tuples of the store file",
+				test.Name, len(test.Tuples), maxTestTuples)
+		}
+	}
+
+	storeName := options.StoreName
+	if storeName == "" {
+		storeName = storeFile.Name
+	}
+	if storeName == "" {
+		storeName = DefaultStoreName
+	}
+	store, err := fgaClient.CreateStore(ctx).Body(client.ClientCreateStoreRequest{Name: storeName}).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("creating store: %w", err)
+	}
+	result = &Result{StoreId: store.Id}
+	if !options.KeepStore {
+		defer func() {
+			_, deleteErr := fgaClient.DeleteStore(ctx).Options(client.ClientDeleteStoreOptions{StoreId: &store.Id}).Execute()
+			if deleteErr != nil {
+				err = errors.Join(err, fmt.Errorf("deleting store: %w", deleteErr))
+			}
+		}()
+	}
+
+	written, err := fgaClient.WriteAuthorizationModel(ctx).Body(*model).Options(client.ClientWriteAuthorizationModelOptions{
+		StoreId: &store.Id,
+	}).Execute()
+	if err != nil {
+		return result, fmt.Errorf("writing model: %w", err)
+	}
+	result.AuthorizationModelId = written.AuthorizationModelId
+
+	r := &runner{ctx: ctx, client: fgaClient, storeId: store.Id, authorizationModelId: written.AuthorizationModelId}
+	if err := r.writeTuples(storeFile.Tuples); err != nil {
+		return result, err
+	}
+	for _, test := range storeFile.Tests {
+		result.Tests = append(result.Tests, r.runTest(test))
+	}
+
+	return result, nil
+}
+
+type runner struct {
+	ctx                  context.Context
+	client               client.SdkClient
+	storeId              string
+	authorizationModelId string
+}
+
+func (r *runner) writeTuples(tuples []fgaSdk.TupleKey) error {
+	for start := 0; start < len(tuples); start += tuplesPerWrite {
+		end := min(start+tuplesPerWrite, len(tuples))
+		_, err := r.client.WriteTuples(r.ctx).Body(tuples[start:end]).Options(client.ClientWriteOptions{
+			StoreId:              &r.storeId,
+			AuthorizationModelId: &r.authorizationModelId,
+		}).Execute()
+		if err != nil {
+			return fmt.Errorf("writing tuples: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *runner) runTest(test StoreTest) TestResult {
+	result := TestResult{Name: test.Name}
+	result.Checks = r.runChecks(test)
+
+	for _, listObjects := range test.ListObjects {
+		for _, relation := range sortedKeys(listObjects.Assertions) {
+			objectsResult := ListObjectsResult{
+				User:     listObjects.User,
+				Relation: relation,
+				Type:     listObjects.Type,
+				Expected: listObjects.Assertions[relation],
+			}
+			response, err := r.client.ListObjects(r.ctx).Body(client.ClientListObjectsRequest{
+				User:             listObjects.User,
+				Relation:         relation,
+				Type:             listObjects.Type,
+				Context:          listObjects.Context,
+				ContextualTuples: test.Tuples,
+			}).Options(client.ClientListObjectsOptions{
+				StoreId:              &r.storeId,
+				AuthorizationModelId: &r.authorizationModelId,
+			}).Execute()
+			if err != nil {
+				objectsResult.Error = err
+			} else {
+				objectsResult.Objects = response.Objects
+			}
+			result.ListObjects = append(result.ListObjects, objectsResult)
+		}
+	}
+
+	for _, listUsers := range test.ListUsers {
+		for _, relation := range sortedKeys(listUsers.Assertions) {
+			usersResult := ListUsersResult{
+				Object:     listUsers.Object,
+				Relation:   relation,
+				UserFilter: listUsers.UserFilter,
+				Expected:   listUsers.Assertions[relation].Users,
+			}
+			objectType, objectId, _ := strings.Cut(listUsers.Object, ":")
+			response, err := r.client.ListUsers(r.ctx).Body(client.ClientListUsersRequest{
+				Object:           fgaSdk.FgaObject{Type: objectType, Id: objectId},
+				Relation:         relation,
+				UserFilters:      listUsers.UserFilter,
+				Context:          listUsers.Context,
+				ContextualTuples: test.Tuples,
+			}).Options(client.ClientListUsersOptions{
+				StoreId:              &r.storeId,
+				AuthorizationModelId: &r.authorizationModelId,
+			}).Execute()
+			if err != nil {
+				usersResult.Error = err
+			} else {
+				usersResult.Users = userStrings(response.Users)
+			}
+			result.ListUsers = append(result.ListUsers, usersResult)
+		}
+	}
+
+	return result
+}
+
+// runChecks evaluates the check expectations of a test with a single BatchCheck
+func (r *runner) runChecks(test StoreTest) []CheckResult {
+	var results []CheckResult
+	var items []client.ClientBatchCheckItem
+	for _, check := range test.Check {
+		for _, user := range withSingle(check.User, check.Users) {
+			for _, object := range withSingle(check.Object, check.Objects) {
+				for _, relation := range sortedKeys(check.Assertions) {
+					results = append(results, CheckResult{User: user, Relation: relation, Object: object, Expected: check.Assertions[relation]})
+					items = append(items, client.ClientBatchCheckItem{
+						User:             user,
+						Relation:         relation,
+						Object:           object,
+						CorrelationId:    strconv.Itoa(len(items)),
+						ContextualTuples: test.Tuples,
+						Context:          check.Context,
+					})
+				}
+			}
+		}
+	}
+	if len(items) == 0 {
+		return results
+	}
+
+	response, err := r.client.BatchCheck(r.ctx).Body(client.ClientBatchCheckRequest{Checks: items}).Options(client.BatchCheckOptions{
+		StoreId:              &r.storeId,
+		AuthorizationModelId: &r.authorizationModelId,
+	}).Execute()
+	if err != nil {
+		for index := range results {
+			results[index].Error = err
+		}
+		return results
+	}
+
+	checkResults := response.GetResult()
+	for index := range results {
+		checkResult, ok := checkResults[strconv.Itoa(index)]
+		switch {
+		case !ok:
+			results[index].Error = fmt.Errorf("no result returned")
+		case checkResult.Error != nil:
+			results[index].Error = errors.New(checkResult.Error.GetMessage())
+		default:
+			results[index].Allowed = checkResult.Allowed
+		}
+	}
+	return results
+}
+
+func withSingle(value string, values []string) []string {
+	if value != "" {
+		return append([]string{value}, values...)
+	}
+	return values
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// userStrings formats the users returned by ListUsers as user strings
+func userStrings(users []fgaSdk.User) []string {
+	formatted := make([]string, 0, len(users))
+	for _, user := range users {
+		switch {
+		case user.Object != nil:
+			formatted = append(formatted, user.Object.Type+":"+user.Object.Id)
+		case user.Userset != nil:
+			formatted = append(formatted, user.Userset.Type+":"+user.Userset.Id+"#"+user.Userset.Relation)
+		case user.Wildcard != nil:
+			formatted = append(formatted, user.Wildcard.Type+":*")
+		}
+	}
+	return formatted
+}
@@ -0,0 +1,120 @@
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/client/fake"
+)
+
+func newFakeClient(t *testing.T) *fake.Client {
+	t.Helper()
+
+	fgaClient, err := fake.NewClient(nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return fgaClient
+}
+
+func TestRunFile(t *testing.T) {
+	fgaClient := newFakeClient(t)
+
+	result := RunFile(t, fgaClient, "testdata/documents.fga.yaml", nil)
+	if !result.Passed() || len(result.Tests) != 2 {
+		t.Fatalf("Expected the tests to pass:\n%s", result)
+	}
+	if len(result.Tests[0].Checks) != 4 || len(result.Tests[1].ListObjects) != 2 || len(result.Tests[1].ListUsers) != 1 {
+		t.Fatalf("Unexpected results %+v", result.Tests)
+	}
+
+	stores, err := fgaClient.ListStores(context.Background()).Options(client.ClientListStoresOptions{Name: fgaSdk.ToPtr("documents")}).Execute()
+	if err != nil || len(stores.Stores) != 0 {
+		t.Fatalf("Expected the temporary store to be deleted, got %+v (%v)", stores, err)
+	}
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	fgaClient := newFakeClient(t)
+	storeFile, err := ParseStoreFile([]byte(`
+model: |
+  model
+    schema 1.1
+  type user
+  type document
+    relations
+      define viewer: [user]
+tuples:
+  - user: user:anne
+    relation: viewer
+    object: document:1
+tests:
+  - name: failing
+    check:
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: false
+          owner: true
+    list_objects:
+      - user: user:anne
+        type: document
+        assertions:
+          viewer:
+            - document:2
+`), "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	result, err := Run(context.Background(), fgaClient, storeFile, &RunOptions{StoreName: "failing", KeepStore: true})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if result.Passed() {
+		t.Fatalf("Expected the test to fail")
+	}
+
+	expected := `FAIL failing
+    check user:anne owner document:1: ` // followed by the error of the invalid relation
+	report := result.String()
+	if !strings.HasPrefix(report, expected) {
+		t.Fatalf("Unexpected report:\n%s", report)
+	}
+	for _, line := range []string{
+		"    check user:anne viewer document:1: expected false, got true\n",
+		"    list_objects user:anne viewer document:\n    - document:2\n    + document:1\n",
+	} {
+		if !strings.Contains(report, line) {
+			t.Fatalf("Expected the report to contain %q, got:\n%s", line, report)
+		}
+	}
+
+	if _, err := fgaClient.GetStore(context.Background()).Options(client.ClientGetStoreOptions{StoreId: &result.StoreId}).Execute(); err != nil {
+		t.Fatalf("Expected the store to be kept, got %v", err)
+	}
+}
+
+func TestRunRejectsTooManyTestTuples(t *testing.T) {
+	fgaClient := newFakeClient(t)
+	tuples := make([]fgaSdk.TupleKey, 101)
+	for index := range tuples {
+		tuples[index] = fgaSdk.TupleKey{User: fmt.Sprintf("user:%d", index), Relation: "viewer", Object: "document:1"}
+	}
+	storeFile := &StoreFile{
+		Model: "model\n  schema 1.1\ntype user\ntype document\n  relations\n    define viewer: [user]\n",
+		Tests: []StoreTest{{Name: "large", Tuples: tuples}},
+	}
+
+	result, err := Run(context.Background(), fgaClient, storeFile, &RunOptions{StoreName: "large"})
+	if err == nil || !strings.Contains(err.Error(), "test large has 101 tuples") {
+		t.Fatalf("Expected the test file to be rejected, got %v", err)
+	}
+	if result != nil {
+		t.Fatalf("Expected no store to be created, got %+v", result)
+	}
+}
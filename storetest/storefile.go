@@ -0,0 +1,166 @@
+// Package storetest runs OpenFGA store test files (.fga.yaml) against a server.
+//
+// A store file holds a model, tuples and tests made of check, list_objects and list_users expectations, in the
+// format used by the OpenFGA CLI:
+//
+//	name: documents
+//	model_file: ./model.fga
+//	tuples:
+//	  - user: user:anne
+//	    relation: viewer
+//	    object: document:roadmap
+//	tests:
+//	  - name: viewers
+//	    check:
+//	      - user: user:anne
+//	        object: document:roadmap
+//	        assertions:
+//	          viewer: true
+//	          editor: false
+//
+// Run creates a temporary store, writes the model and tuples to it, evaluates the expectations and deletes the store.
+// RunFile does the same from a go test, reporting every test of the file as a subtest.
+package storetest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// StoreFile is the content of a store test file. Model and tuple files referenced by the file are loaded into Model
+// and Tuples by LoadStoreFile.
+type StoreFile struct {
+	Name       string            `yaml:"name"`
+	Model      string            `yaml:"model"`
+	ModelFile  string            `yaml:"model_file"`
+	Tuples     []fgaSdk.TupleKey `yaml:"tuples"`
+	TupleFile  string            `yaml:"tuple_file"`
+	TupleFiles []string          `yaml:"tuple_files"`
+	Tests      []StoreTest       `yaml:"tests"`
+}
+
+// StoreTest is a named group of expectations. Its tuples are sent as contextual tuples with every query of the test,
+// so a test can have at most 100 tuples.
+type StoreTest struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Tuples      []fgaSdk.TupleKey `yaml:"tuples"`
+	TupleFile   string            `yaml:"tuple_file"`
+	TupleFiles  []string          `yaml:"tuple_files"`
+	Check       []CheckTest       `yaml:"check"`
+	ListObjects []ListObjectsTest `yaml:"list_objects"`
+	ListUsers   []ListUsersTest   `yaml:"list_users"`
+}
+
+// CheckTest expects, for every user and object, whether the user has each relation of Assertions with the object
+type CheckTest struct {
+	User       string                  `yaml:"user"`
+	Users      []string                `yaml:"users"`
+	Object     string                  `yaml:"object"`
+	Objects    []string                `yaml:"objects"`
+	Context    *map[string]interface{} `yaml:"context"`
+	Assertions map[string]bool         `yaml:"assertions"`
+}
+
+// ListObjectsTest expects the objects of Type the user has each relation of Assertions with
+type ListObjectsTest struct {
+	User       string                  `yaml:"user"`
+	Type       string                  `yaml:"type"`
+	Context    *map[string]interface{} `yaml:"context"`
+	Assertions map[string][]string     `yaml:"assertions"`
+}
+
+// ListUsersTest expects the users matching UserFilter which have each relation of Assertions with the object
+type ListUsersTest struct {
+	Object     string                        `yaml:"object"`
+	UserFilter []fgaSdk.UserTypeFilter       `yaml:"user_filter"`
+	Context    *map[string]interface{}       `yaml:"context"`
+	Assertions map[string]ListUsersAssertion `yaml:"assertions"`
+}
+
+// ListUsersAssertion holds the users expected from a ListUsers query, as user strings such as "user:anne",
+// "user:*" or "group:eng#member"
+type ListUsersAssertion struct {
+	Users []string `yaml:"users"`
+}
+
+// LoadStoreFile reads a store test file, and loads the model and tuple files it references, relative to its directory
+func LoadStoreFile(path string) (*StoreFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStoreFile(data, filepath.Dir(path))
+}
+
+// ParseStoreFile parses the content of a store test file, and loads the model and tuple files it references,
+// relative to dir
+func ParseStoreFile(data []byte, dir string) (*StoreFile, error) {
+	var storeFile StoreFile
+	if err := yaml.Unmarshal(data, &storeFile); err != nil {
+		return nil, fmt.Errorf("parsing store file: %w", err)
+	}
+
+	if storeFile.ModelFile != "" {
+		if storeFile.Model != "" {
+			return nil, fmt.Errorf("store file sets both model and model_file")
+		}
+		model, err := os.ReadFile(filepath.Join(dir, storeFile.ModelFile))
+		if err != nil {
+			return nil, fmt.Errorf("reading model file: %w", err)
+		}
+		storeFile.Model = string(model)
+	}
+	if strings.TrimSpace(storeFile.Model) == "" {
+		return nil, fmt.Errorf("store file has no model")
+	}
+
+	tuples, err := loadTupleFiles(dir, storeFile.TupleFile, storeFile.TupleFiles)
+	if err != nil {
+		return nil, err
+	}
+	storeFile.Tuples = append(storeFile.Tuples, tuples...)
+
+	for index := range storeFile.Tests {
+		test := &storeFile.Tests[index]
+		tuples, err := loadTupleFiles(dir, test.TupleFile, test.TupleFiles)
+		if err != nil {
+			return nil, fmt.Errorf("test %s: %w", test.Name, err)
+		}
+		test.Tuples = append(test.Tuples, tuples...)
+	}
+
+	return &storeFile, nil
+}
+
+func loadTupleFiles(dir string, tupleFile string, tupleFiles []string) ([]fgaSdk.TupleKey, error) {
+	if tupleFile != "" {
+		tupleFiles = append([]string{tupleFile}, tupleFiles...)
+	}
+
+	var tuples []fgaSdk.TupleKey
+	for _, path := range tupleFiles {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil, fmt.Errorf("unsupported tuple file format %s, expected yaml or json", path)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			return nil, fmt.Errorf("reading tuple file: %w", err)
+		}
+		// JSON is valid YAML, so both formats are parsed the same way
+		var fileTuples []fgaSdk.TupleKey
+		if err := yaml.Unmarshal(data, &fileTuples); err != nil {
+			return nil, fmt.Errorf("parsing tuple file %s: %w", path, err)
+		}
+		tuples = append(tuples, fileTuples...)
+	}
+	return tuples, nil
+}
@@ -0,0 +1,46 @@
+package storetest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadStoreFile(t *testing.T) {
+	storeFile, err := LoadStoreFile("testdata/documents.fga.yaml")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if storeFile.Name != "documents" || !strings.HasPrefix(storeFile.Model, "model\n") {
+		t.Fatalf("Expected the model file to be loaded, got %+v", storeFile)
+	}
+	if len(storeFile.Tuples) != 3 || storeFile.Tuples[0].User != "user:beth" || storeFile.Tuples[1].User != "user:anne" {
+		t.Fatalf("Expected the inline tuples followed by the tuple file, got %+v", storeFile.Tuples)
+	}
+	if len(storeFile.Tests) != 2 || len(storeFile.Tests[0].Tuples) != 1 || len(storeFile.Tests[0].Check) != 2 {
+		t.Fatalf("Unexpected tests %+v", storeFile.Tests)
+	}
+	listUsers := storeFile.Tests[1].ListUsers[0]
+	if *listUsers.UserFilter[0].Relation != "member" || listUsers.Assertions["editor"].Users[0] != "group:eng#member" {
+		t.Fatalf("Unexpected list_users test %+v", listUsers)
+	}
+}
+
+func TestParseStoreFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "no model", content: "name: empty\n"},
+		{name: "model and model_file", content: "model: x\nmodel_file: documents.fga\n"},
+		{name: "missing model file", content: "model_file: missing.fga\n"},
+		{name: "csv tuple file", content: "model: x\ntuple_file: tuples.csv\n"},
+		{name: "invalid yaml", content: "tests: [\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseStoreFile([]byte(test.content), "testdata"); err == nil {
+				t.Fatalf("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openfga/go-sdk/client"
+)
+
+// RunFile loads the store file at path and runs it with Run, reporting every test of the file as a subtest of t which
+// fails with the expectations that were not met. options may be nil.
+func RunFile(t *testing.T, fgaClient client.SdkClient, path string, options *RunOptions) *Result {
+	t.Helper()
+
+	storeFile, err := LoadStoreFile(path)
+	if err != nil {
+		t.Fatalf("loading %s: %v", path, err)
+	}
+	result, err := Run(context.Background(), fgaClient, storeFile, options)
+	if err != nil {
+		t.Fatalf("running %s: %v", path, err)
+	}
+
+	for _, test := range result.Tests {
+		t.Run(test.Name, func(t *testing.T) {
+			for _, failure := range test.Failures() {
+				t.Error(failure)
+			}
+		})
+	}
+	return result
+}
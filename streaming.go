@@ -17,10 +17,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/openfga/go-sdk/telemetry"
 )
 
 // StreamResult represents a generic streaming result wrapper with either a result or an error
@@ -56,7 +61,20 @@ func (s *StreamingChannel[T]) Close() {
 //   - error: An error if the response is invalid
 func ProcessStreamingResponse[T any](ctx context.Context, httpResponse *http.Response, bufferSize int) (*StreamingChannel[T], error) {
 	streamCtx, cancel := context.WithCancel(ctx)
+	return processStreamingResponse[T](streamCtx, cancel, httpResponse, bufferSize, nil)
+}
+
+// streamObserver is notified of the progress of a stream
+type streamObserver struct {
+	// firstResult is called when the first result of the stream is received
+	firstResult func()
+	// end is called when the stream ends, with its number of results and the error ending it
+	end func(results int, err error)
+}
 
+// processStreamingResponse processes an HTTP response as a streaming NDJSON response read until streamCtx is done,
+// calling cancel when the stream ends or is closed. The observer, when set, is notified of the progress of the stream.
+func processStreamingResponse[T any](streamCtx context.Context, cancel context.CancelFunc, httpResponse *http.Response, bufferSize int, observer *streamObserver) (*StreamingChannel[T], error) {
 	// Use default buffer size of 10 if not specified or invalid
 	if bufferSize <= 0 {
 		bufferSize = 10
@@ -74,8 +92,20 @@ func ProcessStreamingResponse[T any](ctx context.Context, httpResponse *http.Res
 	}
 
 	go func() {
+		var results int
+		var streamErr error
+		fail := func(err error) {
+			streamErr = err
+			channel.Errors <- err
+		}
+
 		defer close(channel.Results)
 		defer close(channel.Errors)
+		defer func() {
+			if observer != nil {
+				observer.end(results, streamErr)
+			}
+		}()
 		defer cancel()
 		defer func() { _ = httpResponse.Body.Close() }()
 
@@ -87,7 +117,7 @@ func ProcessStreamingResponse[T any](ctx context.Context, httpResponse *http.Res
 		for scanner.Scan() {
 			select {
 			case <-streamCtx.Done():
-				channel.Errors <- streamCtx.Err()
+				fail(streamCtx.Err())
 				return
 			default:
 				line := scanner.Bytes()
@@ -97,7 +127,7 @@ func ProcessStreamingResponse[T any](ctx context.Context, httpResponse *http.Res
 
 				var streamResult StreamResult[T]
 				if err := json.Unmarshal(line, &streamResult); err != nil {
-					channel.Errors <- err
+					fail(err)
 					return
 				}
 
@@ -106,16 +136,20 @@ func ProcessStreamingResponse[T any](ctx context.Context, httpResponse *http.Res
 					if streamResult.Error.Message != nil {
 						msg = *streamResult.Error.Message
 					}
-					channel.Errors <- errors.New(msg)
+					fail(errors.New(msg))
 					return
 				}
 
 				if streamResult.Result != nil {
 					select {
 					case <-streamCtx.Done():
-						channel.Errors <- streamCtx.Err()
+						fail(streamCtx.Err())
 						return
 					case channel.Results <- *streamResult.Result:
+						results++
+						if results == 1 && observer != nil {
+							observer.firstResult()
+						}
 					}
 				}
 			}
@@ -124,10 +158,10 @@ func ProcessStreamingResponse[T any](ctx context.Context, httpResponse *http.Res
 		if err := scanner.Err(); err != nil {
 			// Prefer context error if we were canceled to avoid surfacing net/http "use of closed network connection".
 			if streamCtx.Err() != nil {
-				channel.Errors <- streamCtx.Err()
+				fail(streamCtx.Err())
 				return
 			}
-			channel.Errors <- err
+			fail(err)
 		}
 	}()
 
@@ -224,18 +258,134 @@ func executeStreamingRequest[TReq any, TRes any](
 		localVarHeaderParams[header] = val
 	}
 
+	started := time.Now()
+	attemptTimeout, deadline := client.cfg.Timeouts.timeouts(operationName)
+	if attemptTimeout == 0 && deadline == 0 {
+		httpResponse, err := startStreamingRequest(client, ctx, path, storeId, body, localVarHeaderParams, localVarQueryParams, operationName)
+		if err != nil {
+			return nil, err
+		}
+		streamCtx, cancel := context.WithCancel(ctx)
+		return processStreamingResponse[TRes](streamCtx, cancel, httpResponse, bufferSize, client.observeStream(ctx, operationName, storeId, started))
+	}
+
+	// the deadline bounds the whole stream, and the attempt timeout the time until it starts
+	var streamCtx context.Context
+	var cancel context.CancelFunc
+	if deadline > 0 {
+		streamCtx, cancel = context.WithTimeout(ctx, deadline)
+	} else {
+		streamCtx, cancel = context.WithCancel(ctx)
+	}
+	// the attempt timer and the start of the stream race to settle the attempt, so that a timer firing just after the
+	// stream started cannot cancel it
+	var settled atomic.Bool
+	attemptTimer := time.AfterFunc(attemptTimeout, func() {
+		if settled.CompareAndSwap(false, true) {
+			cancel()
+		}
+	})
+	httpResponse, err := startStreamingRequest(client, streamCtx, path, storeId, body, localVarHeaderParams, localVarQueryParams, operationName)
+	attemptTimer.Stop()
+	if timedOut := !settled.CompareAndSwap(false, true); timedOut {
+		// the stream started after its context was cancelled, and cannot be read
+		if err == nil {
+			_ = httpResponse.Body.Close()
+			err = streamCtx.Err()
+		}
+		cancel()
+		// the attempt timer cancels the context, which would otherwise surface as a cancellation by the caller
+		if ctx.Err() == nil && errors.Is(streamCtx.Err(), context.Canceled) {
+			return nil, fmt.Errorf("%s did not start within %v: %w", operationName, attemptTimeout, context.DeadlineExceeded)
+		}
+		return nil, err
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return processStreamingResponse[TRes](streamCtx, cancel, httpResponse, bufferSize, client.observeStream(ctx, operationName, storeId, started))
+}
+
+// observeStream logs the start of a stream of an operation sent at started, and returns the observer logging its end
+// and recording its metrics
+func (c *APIClient) observeStream(ctx context.Context, operationName string, storeId string, started time.Time) *streamObserver {
+	c.logStreamStarted(ctx, operationName, storeId)
+	attrs := map[*telemetry.Attribute]string{
+		telemetry.FGAClientRequestMethod:  operationName,
+		telemetry.FGAClientRequestStoreID: storeId,
+	}
+
+	return &streamObserver{
+		firstResult: func() {
+			_, _ = c.metrics().StreamFirstResult(float64(time.Since(started).Milliseconds()), attrs)
+		},
+		end: func(results int, err error) {
+			_, _ = c.metrics().StreamObjects(float64(results), attrs)
+			c.logStreamEnded(ctx, operationName, storeId, results, time.Since(started), err)
+		},
+	}
+}
+
+// startStreamingRequest sends a streaming request, returning its response once the stream started
+func startStreamingRequest[TReq any](
+	client *APIClient,
+	ctx context.Context,
+	path string,
+	storeId string,
+	body TReq,
+	localVarHeaderParams map[string]string,
+	localVarQueryParams url.Values,
+	operationName string,
+) (*http.Response, error) {
+	// the stream only holds its slot of the rate limiter until the response starts
+	release, err := client.rateLimiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	circuit := client.circuitBreakers.get(operationName, storeId)
+	generation, err := circuit.allow(operationName, storeId)
+	if err != nil {
+		release(rateLimiterIgnored)
+		return nil, err
+	}
+
+	// the span of the request ends once the stream starts
+	ctx, span := client.traces().StartRequest(ctx, http.MethodPost, operationName, 0)
 	req, err := client.prepareRequest(ctx, path, http.MethodPost, body, localVarHeaderParams, localVarQueryParams)
 	if err != nil {
+		release(rateLimiterIgnored)
+		circuit.record(generation, circuitIgnored)
+		span.End(err)
 		return nil, err
 	}
+	span.SetRequestAttributes(req, map[string]interface{}{"body": &body, "storeId": storeId})
 
+	done := client.trackInFlight(operationName)
 	httpResponse, err := client.callAPI(req)
+	done()
+	if err != nil || httpResponse == nil {
+		release(rateLimiterIgnored)
+	} else {
+		release(rateLimiterOutcomeOf(httpResponse.StatusCode))
+	}
+	span.SetResponseAttributes(httpResponse)
+	span.End(err)
 	if err != nil {
+		if ctx.Err() != nil {
+			circuit.record(generation, circuitIgnored)
+		} else {
+			circuit.record(generation, circuitFailure)
+		}
 		return nil, err
 	}
 	if httpResponse == nil {
+		circuit.record(generation, circuitFailure)
 		return nil, errors.New("nil HTTP response from API client")
 	}
+	circuit.record(generation, circuitOutcomeOf(httpResponse.StatusCode))
 
 	if httpResponse.StatusCode >= http.StatusMultipleChoices {
 		responseBody, readErr := io.ReadAll(httpResponse.Body)
@@ -247,5 +397,5 @@ func executeStreamingRequest[TReq any, TRes any](
 		return nil, err
 	}
 
-	return ProcessStreamingResponse[TRes](ctx, httpResponse, bufferSize)
+	return httpResponse, nil
 }
@@ -20,6 +20,11 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/openfga/go-sdk/telemetry"
 )
 
 func TestStreamingChannel_Close(t *testing.T) {
@@ -432,3 +437,58 @@ func TestProcessStreamingResponse_Generic(t *testing.T) {
 		t.Errorf("Expected document:2, got %s", receivedObjects[1])
 	}
 }
+
+func TestStreamedListObjectsWithChannel_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{\"result\":{\"object\":\"document:1\"}}\n{\"result\":{\"object\":\"document:2\"}}\n"))
+	}))
+	defer server.Close()
+
+	telemetryConfiguration := telemetry.DefaultTelemetryConfiguration()
+	reader := newTestMetricsReader(t, telemetryConfiguration)
+	config, err := NewConfiguration(Configuration{
+		ApiUrl:    server.URL,
+		Telemetry: telemetryConfiguration,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create configuration: %v", err)
+	}
+
+	request := ListObjectsRequest{Type: "document", Relation: "viewer", User: "user:anne"}
+	channel, err := ExecuteStreamedListObjects(NewAPIClient(config), context.Background(), "test-store", request, RequestOptions{})
+	if err != nil {
+		t.Fatalf("ExecuteStreamedListObjects failed: %v", err)
+	}
+	defer channel.Close()
+	for range channel.Objects {
+	}
+	if err := <-channel.Errors; err != nil {
+		t.Fatalf("Received error from channel: %v", err)
+	}
+
+	// the objects of the stream are recorded once its channels are closed
+	deadline := time.Now().Add(time.Second)
+	for {
+		objects, ok := collectMetric(t, reader, telemetry.METRIC_HISTOGRAM_STREAM_OBJECTS).(metricdata.Histogram[float64])
+		if ok && len(objects.DataPoints) == 1 {
+			if objects.DataPoints[0].Count != 1 || objects.DataPoints[0].Sum != 2 {
+				t.Fatalf("Expected a stream of 2 objects, got %v", objects.DataPoints[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the objects of the stream to be recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	firstResult, ok := collectMetric(t, reader, telemetry.METRIC_HISTOGRAM_STREAM_FIRST_RESULT).(metricdata.Histogram[float64])
+	if !ok || len(firstResult.DataPoints) != 1 || firstResult.DataPoints[0].Count != 1 {
+		t.Fatalf("Expected the time to the first result of the stream to be recorded, got %v", firstResult)
+	}
+	if method, _ := firstResult.DataPoints[0].Attributes.Value(attribute.Key(telemetry.ATTR_FGA_CLIENT_REQUEST_METHOD)); method.AsString() != "StreamedListObjects" {
+		t.Fatalf("Expected the method of the stream, got %v", method.AsString())
+	}
+}
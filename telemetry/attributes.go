@@ -10,13 +10,17 @@ import (
 )
 
 const (
+	ATTR_FGA_CLIENT_CIRCUIT_BREAKER_STATE    = "fga-client.circuit_breaker.state"
 	ATTR_FGA_CLIENT_REQUEST_CLIENT_ID        = "fga-client.request.client_id"
+	ATTR_FGA_CLIENT_REQUEST_HEDGE_WINNER     = "fga-client.request.hedge_winner"
 	ATTR_FGA_CLIENT_REQUEST_METHOD           = "fga-client.request.method"
+	ATTR_FGA_CLIENT_REQUEST_RETRY_REASON     = "fga-client.request.retry_reason"
 	ATTR_FGA_CLIENT_REQUEST_MODEL_ID         = "fga-client.request.model_id"
 	ATTR_FGA_CLIENT_REQUEST_STORE_ID         = "fga-client.request.store_id"
 	ATTR_FGA_CLIENT_REQUEST_BATCH_CHECK_SIZE = "fga-client.request.batch_check_size"
 	ATTR_FGA_CLIENT_RESPONSE_MODEL_ID        = "fga-client.response.model_id"
 	ATTR_FGA_CLIENT_USER                     = "fga-client.user"
+	ATTR_FGA_CLIENT_WRITE_OPERATION          = "fga-client.write.operation"
 	ATTR_HTTP_CLIENT_REQUEST_DURATION        = "http.client.request.duration"
 	ATTR_HTTP_HOST                           = "http.host"
 	ATTR_HTTP_REQUEST_METHOD                 = "http.request.method"
@@ -29,13 +33,17 @@ const (
 )
 
 var (
+	FGAClientCircuitBreakerState   = &Attribute{Name: ATTR_FGA_CLIENT_CIRCUIT_BREAKER_STATE}
 	FGAClientRequestClientID       = &Attribute{Name: ATTR_FGA_CLIENT_REQUEST_CLIENT_ID}
+	FGAClientRequestHedgeWinner    = &Attribute{Name: ATTR_FGA_CLIENT_REQUEST_HEDGE_WINNER}
 	FGAClientRequestMethod         = &Attribute{Name: ATTR_FGA_CLIENT_REQUEST_METHOD}
+	FGAClientRequestRetryReason    = &Attribute{Name: ATTR_FGA_CLIENT_REQUEST_RETRY_REASON}
 	FGAClientRequestModelID        = &Attribute{Name: ATTR_FGA_CLIENT_REQUEST_MODEL_ID}
 	FGAClientRequestStoreID        = &Attribute{Name: ATTR_FGA_CLIENT_REQUEST_STORE_ID}
 	FGAClientRequestBatchCheckSize = &Attribute{Name: ATTR_FGA_CLIENT_REQUEST_BATCH_CHECK_SIZE}
 	FGAClientResponseModelID       = &Attribute{Name: ATTR_FGA_CLIENT_RESPONSE_MODEL_ID}
 	FGAClientUser                  = &Attribute{Name: ATTR_FGA_CLIENT_USER}
+	FGAClientWriteOperation        = &Attribute{Name: ATTR_FGA_CLIENT_WRITE_OPERATION}
 	HTTPClientRequestDuration      = &Attribute{Name: ATTR_HTTP_CLIENT_REQUEST_DURATION}
 	HTTPHost                       = &Attribute{Name: ATTR_HTTP_HOST}
 	HTTPRequestMethod              = &Attribute{Name: ATTR_HTTP_REQUEST_METHOD}
@@ -47,118 +55,171 @@ var (
 	UserAgent                      = &Attribute{Name: ATTR_USER_AGENT_ORIGINAL}
 )
 
+// attributes are the attributes of the metrics, in the order EnabledAttributes returns them
+var attributes = []*Attribute{
+	FGAClientCircuitBreakerState,
+	FGAClientRequestClientID,
+	FGAClientRequestHedgeWinner,
+	FGAClientRequestMethod,
+	FGAClientRequestRetryReason,
+	FGAClientRequestModelID,
+	FGAClientRequestStoreID,
+	FGAClientRequestBatchCheckSize,
+	FGAClientResponseModelID,
+	FGAClientUser,
+	FGAClientWriteOperation,
+	HTTPClientRequestDuration,
+	HTTPHost,
+	HTTPRequestMethod,
+	HTTPRequestResendCount,
+	HTTPResponseStatusCode,
+	HTTPServerRequestDuration,
+	URLScheme,
+	URLFull,
+	UserAgent,
+}
+
 func (m *Metrics) PrepareAttributes(metric MetricInterface, attrs map[*Attribute]string, config *MetricsConfiguration) (attribute.Set, error) {
-	var prepared []attribute.KeyValue
-	var allowed *MetricConfiguration
+	return prepareAttributes(metric, attrs, config), nil
+}
 
+// prepareAttributes returns the attributes of attrs which config enables for the metric
+func prepareAttributes(metric MetricInterface, attrs map[*Attribute]string, config *MetricsConfiguration) attribute.Set {
 	if config == nil || metric == nil || attrs == nil {
-		return *attribute.EmptySet(), nil
+		return *attribute.EmptySet()
 	}
 
-	switch metric.GetName() {
-	case METRIC_COUNTER_CREDENTIALS_REQUEST:
-		if config.METRIC_COUNTER_CREDENTIALS_REQUEST == nil {
-			return *attribute.EmptySet(), nil
-		}
+	allowed := config.metricConfiguration(metric.GetName())
+	if allowed == nil {
+		return *attribute.EmptySet()
+	}
 
-		allowed = config.METRIC_COUNTER_CREDENTIALS_REQUEST
-	case METRIC_HISTOGRAM_REQUEST_DURATION:
-		if config.METRIC_HISTOGRAM_REQUEST_DURATION == nil {
-			return *attribute.EmptySet(), nil
+	var prepared []attribute.KeyValue
+	for attr, value := range attrs {
+		if attr == nil || !allowed.allows(attr) {
+			continue
 		}
 
-		allowed = config.METRIC_HISTOGRAM_REQUEST_DURATION
+		prepared = append(prepared, attribute.String(attr.Name, value))
+	}
+
+	return attribute.NewSet(prepared...)
+}
+
+// metricConfiguration returns the configuration of the metric named name, nil when the metric has no attributes
+func (c *MetricsConfiguration) metricConfiguration(name string) *MetricConfiguration {
+	switch name {
+	case METRIC_COUNTER_CREDENTIALS_REQUEST:
+		return c.METRIC_COUNTER_CREDENTIALS_REQUEST
+	case METRIC_HISTOGRAM_REQUEST_DURATION:
+		return c.METRIC_HISTOGRAM_REQUEST_DURATION
 	case METRIC_HISTOGRAM_QUERY_DURATION:
-		if config.METRIC_HISTOGRAM_QUERY_DURATION == nil {
-			return *attribute.EmptySet(), nil
-		}
+		return c.METRIC_HISTOGRAM_QUERY_DURATION
+	case METRIC_COUNTER_CHECK_CACHE_HIT:
+		return c.METRIC_COUNTER_CHECK_CACHE_HIT
+	case METRIC_COUNTER_CHECK_CACHE_MISS:
+		return c.METRIC_COUNTER_CHECK_CACHE_MISS
+	case METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE:
+		return c.METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE
+	case METRIC_COUNTER_REQUEST_HEDGED:
+		return c.METRIC_COUNTER_REQUEST_HEDGED
+	case METRIC_COUNTER_REQUEST_RETRY:
+		return c.METRIC_COUNTER_REQUEST_RETRY
+	case METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT:
+		return c.METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT
+	case METRIC_HISTOGRAM_BATCH_CHECK_SIZE:
+		return c.METRIC_HISTOGRAM_BATCH_CHECK_SIZE
+	case METRIC_HISTOGRAM_WRITE_TUPLES:
+		return c.METRIC_HISTOGRAM_WRITE_TUPLES
+	case METRIC_HISTOGRAM_STREAM_OBJECTS:
+		return c.METRIC_HISTOGRAM_STREAM_OBJECTS
+	case METRIC_HISTOGRAM_STREAM_FIRST_RESULT:
+		return c.METRIC_HISTOGRAM_STREAM_FIRST_RESULT
+	}
+
+	return nil
+}
 
-		allowed = config.METRIC_HISTOGRAM_QUERY_DURATION
+// EnabledAttributes returns the attributes recorded with the metric named name, always in the same order, e.g. to use
+// them as the labels of the metric in another backend
+func (c *MetricsConfiguration) EnabledAttributes(name string) []*Attribute {
+	if c == nil {
+		return nil
 	}
 
+	allowed := c.metricConfiguration(name)
 	if allowed == nil {
-		return *attribute.EmptySet(), nil
+		return nil
 	}
 
-	for attr, value := range attrs {
-		if attr == nil {
-			continue
+	var enabled []*Attribute
+	for _, attr := range attributes {
+		if allowed.allows(attr) {
+			enabled = append(enabled, attr)
 		}
+	}
 
-		switch attr {
-		case FGAClientRequestClientID:
-			if allowed.ATTR_FGA_CLIENT_REQUEST_CLIENT_ID == nil || !allowed.ATTR_FGA_CLIENT_REQUEST_CLIENT_ID.Enabled {
-				continue
-			}
-		case FGAClientRequestMethod:
-			if allowed.ATTR_HTTP_REQUEST_METHOD == nil || !allowed.ATTR_HTTP_REQUEST_METHOD.Enabled {
-				continue
-			}
-		case FGAClientRequestModelID:
-			if allowed.ATTR_FGA_CLIENT_REQUEST_MODEL_ID == nil || !allowed.ATTR_FGA_CLIENT_REQUEST_MODEL_ID.Enabled {
-				continue
-			}
-		case FGAClientRequestStoreID:
-			if allowed.ATTR_FGA_CLIENT_REQUEST_STORE_ID == nil || !allowed.ATTR_FGA_CLIENT_REQUEST_STORE_ID.Enabled {
-				continue
-			}
-		case FGAClientRequestBatchCheckSize:
-			if allowed.ATTR_FGA_CLIENT_REQUEST_BATCH_CHECK_SIZE == nil || !allowed.ATTR_FGA_CLIENT_REQUEST_BATCH_CHECK_SIZE.Enabled {
-				continue
-			}
-		case FGAClientResponseModelID:
-			if allowed.ATTR_FGA_CLIENT_RESPONSE_MODEL_ID == nil || !allowed.ATTR_FGA_CLIENT_RESPONSE_MODEL_ID.Enabled {
-				continue
-			}
-		case FGAClientUser:
-			if allowed.ATTR_FGA_CLIENT_USER == nil || !allowed.ATTR_FGA_CLIENT_USER.Enabled {
-				continue
-			}
-		case HTTPClientRequestDuration:
-			if allowed.ATTR_HTTP_CLIENT_REQUEST_DURATION == nil || !allowed.ATTR_HTTP_CLIENT_REQUEST_DURATION.Enabled {
-				continue
-			}
-		case HTTPHost:
-			if allowed.ATTR_HTTP_HOST == nil || !allowed.ATTR_HTTP_HOST.Enabled {
-				continue
-			}
-		case HTTPRequestMethod:
-			if allowed.ATTR_HTTP_REQUEST_METHOD == nil || !allowed.ATTR_HTTP_REQUEST_METHOD.Enabled {
-				continue
-			}
-		case HTTPRequestResendCount:
-			if allowed.ATTR_HTTP_REQUEST_RESEND_COUNT == nil || !allowed.ATTR_HTTP_REQUEST_RESEND_COUNT.Enabled {
-				continue
-			}
-		case HTTPResponseStatusCode:
-			if allowed.ATTR_HTTP_RESPONSE_STATUS_CODE == nil || !allowed.ATTR_HTTP_RESPONSE_STATUS_CODE.Enabled {
-				continue
-			}
-		case HTTPServerRequestDuration:
-			if allowed.ATTR_HTTP_SERVER_REQUEST_DURATION == nil || !allowed.ATTR_HTTP_SERVER_REQUEST_DURATION.Enabled {
-				continue
-			}
-		case URLScheme:
-			if allowed.ATTR_URL_SCHEME == nil || !allowed.ATTR_URL_SCHEME.Enabled {
-				continue
-			}
-		case URLFull:
-			if allowed.ATTR_URL_FULL == nil || !allowed.ATTR_URL_FULL.Enabled {
-				continue
-			}
-		case UserAgent:
-			if allowed.ATTR_USER_AGENT_ORIGINAL == nil || !allowed.ATTR_USER_AGENT_ORIGINAL.Enabled {
-				continue
-			}
-		}
+	return enabled
+}
 
-		prepared = append(prepared, attribute.String(attr.Name, value))
+// allows reports whether the attribute is enabled. Attributes which cannot be configured are always enabled.
+func (c *MetricConfiguration) allows(attr *Attribute) bool {
+	var configuration *AttributeConfiguration
+
+	switch attr {
+	case FGAClientCircuitBreakerState:
+		configuration = c.ATTR_FGA_CLIENT_CIRCUIT_BREAKER_STATE
+	case FGAClientRequestClientID:
+		configuration = c.ATTR_FGA_CLIENT_REQUEST_CLIENT_ID
+	case FGAClientRequestHedgeWinner:
+		configuration = c.ATTR_FGA_CLIENT_REQUEST_HEDGE_WINNER
+	case FGAClientRequestMethod:
+		configuration = c.ATTR_HTTP_REQUEST_METHOD
+	case FGAClientRequestModelID:
+		configuration = c.ATTR_FGA_CLIENT_REQUEST_MODEL_ID
+	case FGAClientRequestRetryReason:
+		configuration = c.ATTR_FGA_CLIENT_REQUEST_RETRY_REASON
+	case FGAClientRequestStoreID:
+		configuration = c.ATTR_FGA_CLIENT_REQUEST_STORE_ID
+	case FGAClientRequestBatchCheckSize:
+		configuration = c.ATTR_FGA_CLIENT_REQUEST_BATCH_CHECK_SIZE
+	case FGAClientResponseModelID:
+		configuration = c.ATTR_FGA_CLIENT_RESPONSE_MODEL_ID
+	case FGAClientUser:
+		configuration = c.ATTR_FGA_CLIENT_USER
+	case FGAClientWriteOperation:
+		configuration = c.ATTR_FGA_CLIENT_WRITE_OPERATION
+	case HTTPClientRequestDuration:
+		configuration = c.ATTR_HTTP_CLIENT_REQUEST_DURATION
+	case HTTPHost:
+		configuration = c.ATTR_HTTP_HOST
+	case HTTPRequestMethod:
+		configuration = c.ATTR_HTTP_REQUEST_METHOD
+	case HTTPRequestResendCount:
+		configuration = c.ATTR_HTTP_REQUEST_RESEND_COUNT
+	case HTTPResponseStatusCode:
+		configuration = c.ATTR_HTTP_RESPONSE_STATUS_CODE
+	case HTTPServerRequestDuration:
+		configuration = c.ATTR_HTTP_SERVER_REQUEST_DURATION
+	case URLScheme:
+		configuration = c.ATTR_URL_SCHEME
+	case URLFull:
+		configuration = c.ATTR_URL_FULL
+	case UserAgent:
+		configuration = c.ATTR_USER_AGENT_ORIGINAL
+	default:
+		return true
 	}
 
-	return attribute.NewSet(prepared...), nil
+	return configuration != nil && configuration.Enabled
 }
 
 func (m *Metrics) AttributesFromRequest(req *http.Request, params map[string]interface{}) (map[*Attribute]string, error) {
+	return attributesFromRequest(req, params), nil
+}
+
+// attributesFromRequest returns the attributes of a request sent with the parameters of an API method
+func attributesFromRequest(req *http.Request, params map[string]interface{}) map[*Attribute]string {
 	var request = map[*Attribute]string{
 		HTTPHost:          req.URL.Host,
 		HTTPRequestMethod: req.Method,
@@ -210,10 +271,15 @@ func (m *Metrics) AttributesFromRequest(req *http.Request, params map[string]int
 		}
 	}
 
-	return request, nil
+	return request
 }
 
 func (m *Metrics) AttributesFromResponse(res *http.Response, attrs map[*Attribute]string) (map[*Attribute]string, error) {
+	return attributesFromResponse(res, attrs), nil
+}
+
+// attributesFromResponse adds the attributes of a response to attrs
+func attributesFromResponse(res *http.Response, attrs map[*Attribute]string) map[*Attribute]string {
 	attrs[HTTPResponseStatusCode] = strconv.Itoa(res.StatusCode)
 
 	if res.Header.Get("openfga-authorization-model-id") != "" {
@@ -224,17 +290,30 @@ func (m *Metrics) AttributesFromResponse(res *http.Response, attrs map[*Attribut
 		attrs[HTTPServerRequestDuration] = res.Header.Get("fga-query-duration-ms")
 	}
 
-	return attrs, nil
+	return attrs
 }
 
 func (m *Metrics) AttributesFromRequestDuration(requestStarted time.Time, attrs map[*Attribute]string) (float64, map[*Attribute]string, error) {
+	requestDuration, attrs := attributesFromRequestDuration(requestStarted, attrs)
+
+	return requestDuration, attrs, nil
+}
+
+// attributesFromRequestDuration adds the duration of a request started at requestStarted to attrs, returning it in
+// milliseconds
+func attributesFromRequestDuration(requestStarted time.Time, attrs map[*Attribute]string) (float64, map[*Attribute]string) {
 	requestDurationFloat := time.Since(requestStarted).Seconds() * 1000
 	attrs[HTTPClientRequestDuration] = strconv.FormatFloat(requestDurationFloat, 'f', -1, 64)
 
-	return requestDurationFloat, attrs, nil
+	return requestDurationFloat, attrs
 }
 
 func (m *Metrics) AttributesFromQueryDuration(attrs map[*Attribute]string) (float64, map[*Attribute]string, error) {
+	return attributesFromQueryDuration(attrs)
+}
+
+// attributesFromQueryDuration returns the duration of the query reported by the server in attrs, in milliseconds
+func attributesFromQueryDuration(attrs map[*Attribute]string) (float64, map[*Attribute]string, error) {
 	if attrs[HTTPServerRequestDuration] == "" {
 		return 0, attrs, nil
 	}
@@ -249,25 +328,37 @@ func (m *Metrics) AttributesFromQueryDuration(attrs map[*Attribute]string) (floa
 }
 
 func (m *Metrics) AttributesFromResendCount(resendCount int, attrs map[*Attribute]string) (map[*Attribute]string, error) {
+	return attributesFromResendCount(resendCount, attrs), nil
+}
+
+// attributesFromResendCount adds the number of retries of a request to attrs
+func attributesFromResendCount(resendCount int, attrs map[*Attribute]string) map[*Attribute]string {
 	if resendCount > 0 {
 		attrs[HTTPRequestResendCount] = strconv.Itoa(resendCount)
 	}
 
-	return attrs, nil
+	return attrs
 }
 
 func (m *Metrics) BuildTelemetryAttributes(requestMethod string, methodParameters map[string]interface{}, req *http.Request, res *http.Response, requestStarted time.Time, resendCount int) (map[*Attribute]string, float64, float64, error) {
-	var attrs map[*Attribute]string
+	attrs, queryDuration, requestDuration := BuildTelemetryAttributes(requestMethod, methodParameters, req, res, requestStarted, resendCount)
 
-	attrs, _ = m.AttributesFromRequest(req, methodParameters)
-	attrs, _ = m.AttributesFromResponse(res, attrs)
-	attrs, _ = m.AttributesFromResendCount(resendCount, attrs)
+	return attrs, queryDuration, requestDuration, nil
+}
+
+// BuildTelemetryAttributes returns the attributes of a request of an API method and its response, with the durations
+// of the query and of the request in milliseconds. It implements MetricsInterface.BuildTelemetryAttributes for the
+// metrics of other backends.
+func BuildTelemetryAttributes(requestMethod string, methodParameters map[string]interface{}, req *http.Request, res *http.Response, requestStarted time.Time, resendCount int) (map[*Attribute]string, float64, float64) {
+	attrs := attributesFromRequest(req, methodParameters)
+	attrs = attributesFromResponse(res, attrs)
+	attrs = attributesFromResendCount(resendCount, attrs)
 
 	var requestDuration, queryDuration float64
-	queryDuration, attrs, _ = m.AttributesFromQueryDuration(attrs)
-	requestDuration, attrs, _ = m.AttributesFromRequestDuration(requestStarted, attrs)
+	queryDuration, attrs, _ = attributesFromQueryDuration(attrs)
+	requestDuration, attrs = attributesFromRequestDuration(requestStarted, attrs)
 
 	attrs[FGAClientRequestMethod] = requestMethod
 
-	return attrs, queryDuration, requestDuration, nil
+	return attrs, queryDuration, requestDuration
 }
@@ -67,3 +67,26 @@ func TestBuildTelemetryAttributes(t *testing.T) {
 		t.Errorf("Expected query duration to be 123.0, but got %v", queryDuration)
 	}
 }
+
+func TestEnabledAttributes(t *testing.T) {
+	configuration := DefaultTelemetryConfiguration().Metrics
+
+	enabled := configuration.EnabledAttributes(METRIC_HISTOGRAM_BATCH_CHECK_SIZE)
+	position := make(map[*Attribute]int)
+	for i, attr := range enabled {
+		position[attr] = i
+	}
+	method, hasMethod := position[FGAClientRequestMethod]
+	store, hasStore := position[FGAClientRequestStoreID]
+	if !hasMethod || !hasStore || method > store {
+		t.Errorf("Expected the method and the store in the order of the attributes, but got %v", enabled)
+	}
+	if _, ok := position[FGAClientUser]; ok {
+		t.Errorf("Expected the user not to be enabled, but got %v", enabled)
+	}
+
+	var nilConfiguration *MetricsConfiguration
+	if enabled := nilConfiguration.EnabledAttributes(METRIC_HISTOGRAM_BATCH_CHECK_SIZE); enabled != nil {
+		t.Errorf("Expected no attribute without configuration, but got %v", enabled)
+	}
+}
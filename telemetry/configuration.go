@@ -1,17 +1,26 @@
 package telemetry
 
+import (
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
 type AttributeConfiguration struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
 type MetricConfiguration struct {
+	ATTR_FGA_CLIENT_CIRCUIT_BREAKER_STATE    *AttributeConfiguration `json:"fga_client_circuit_breaker_state,omitempty"`
 	ATTR_FGA_CLIENT_REQUEST_CLIENT_ID        *AttributeConfiguration `json:"fga_client_request_client_id,omitempty"`
+	ATTR_FGA_CLIENT_REQUEST_HEDGE_WINNER     *AttributeConfiguration `json:"fga_client_request_hedge_winner,omitempty"`
 	ATTR_FGA_CLIENT_REQUEST_METHOD           *AttributeConfiguration `json:"fga_client_request_method,omitempty"`
 	ATTR_FGA_CLIENT_REQUEST_MODEL_ID         *AttributeConfiguration `json:"fga_client_request_model_id,omitempty"`
+	ATTR_FGA_CLIENT_REQUEST_RETRY_REASON     *AttributeConfiguration `json:"fga_client_request_retry_reason,omitempty"`
 	ATTR_FGA_CLIENT_REQUEST_STORE_ID         *AttributeConfiguration `json:"fga_client_request_store_id,omitempty"`
 	ATTR_FGA_CLIENT_REQUEST_BATCH_CHECK_SIZE *AttributeConfiguration `json:"fga_client_request_batch_check_size,omitempty"`
 	ATTR_FGA_CLIENT_RESPONSE_MODEL_ID        *AttributeConfiguration `json:"fga_client_response_model_id,omitempty"`
 	ATTR_FGA_CLIENT_USER                     *AttributeConfiguration `json:"fga_client_user,omitempty"`
+	ATTR_FGA_CLIENT_WRITE_OPERATION          *AttributeConfiguration `json:"fga_client_write_operation,omitempty"`
 	ATTR_HTTP_CLIENT_REQUEST_DURATION        *AttributeConfiguration `json:"http_client_request_duration,omitempty"`
 	ATTR_HTTP_HOST                           *AttributeConfiguration `json:"http_host,omitempty"`
 	ATTR_HTTP_REQUEST_METHOD                 *AttributeConfiguration `json:"http_request_method,omitempty"`
@@ -24,13 +33,46 @@ type MetricConfiguration struct {
 }
 
 type MetricsConfiguration struct {
-	METRIC_COUNTER_CREDENTIALS_REQUEST *MetricConfiguration `json:"fga_client_credentials_request,omitempty"`
-	METRIC_HISTOGRAM_REQUEST_DURATION  *MetricConfiguration `json:"fga_client_request_duration,omitempty"`
-	METRIC_HISTOGRAM_QUERY_DURATION    *MetricConfiguration `json:"fga_client_query_duration,omitempty"`
+	METRIC_COUNTER_CREDENTIALS_REQUEST          *MetricConfiguration `json:"fga_client_credentials_request,omitempty"`
+	METRIC_HISTOGRAM_REQUEST_DURATION           *MetricConfiguration `json:"fga_client_request_duration,omitempty"`
+	METRIC_HISTOGRAM_QUERY_DURATION             *MetricConfiguration `json:"fga_client_query_duration,omitempty"`
+	METRIC_COUNTER_CHECK_CACHE_HIT              *MetricConfiguration `json:"fga_client_check_cache_hit,omitempty"`
+	METRIC_COUNTER_CHECK_CACHE_MISS             *MetricConfiguration `json:"fga_client_check_cache_miss,omitempty"`
+	METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE *MetricConfiguration `json:"fga_client_circuit_breaker_state_change,omitempty"`
+	METRIC_COUNTER_REQUEST_HEDGED               *MetricConfiguration `json:"fga_client_request_hedged,omitempty"`
+	METRIC_COUNTER_REQUEST_RETRY                *MetricConfiguration `json:"fga_client_request_retry,omitempty"`
+	METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT   *MetricConfiguration `json:"fga_client_request_in_flight,omitempty"`
+	METRIC_HISTOGRAM_BATCH_CHECK_SIZE           *MetricConfiguration `json:"fga_client_batch_check_size,omitempty"`
+	METRIC_HISTOGRAM_WRITE_TUPLES               *MetricConfiguration `json:"fga_client_write_tuples,omitempty"`
+	METRIC_HISTOGRAM_STREAM_OBJECTS             *MetricConfiguration `json:"fga_client_stream_objects,omitempty"`
+	METRIC_HISTOGRAM_STREAM_FIRST_RESULT        *MetricConfiguration `json:"fga_client_stream_first_result,omitempty"`
+}
+
+// SpanConfiguration enables the attributes of a span, the same way MetricConfiguration does for a metric
+type SpanConfiguration = MetricConfiguration
+
+// TracesConfiguration enables the spans of the SDK. A span whose configuration is nil is not started.
+type TracesConfiguration struct {
+	SPAN_CLIENT_METHOD       *SpanConfiguration `json:"fga_client_method,omitempty"`
+	SPAN_HTTP_REQUEST        *SpanConfiguration `json:"fga_client_http_request,omitempty"`
+	SPAN_CREDENTIALS_REQUEST *SpanConfiguration `json:"fga_client_credentials_request,omitempty"`
 }
 
+// MetricsFactory creates the metrics of a client from the configuration of its metrics, to record them in another
+// backend than the OpenTelemetry meter
+type MetricsFactory func(configuration *MetricsConfiguration) MetricsInterface
+
 type Configuration struct {
 	Metrics *MetricsConfiguration `json:"metrics,omitempty"`
+	// Traces - optional spans of the SDK, no span is started and no trace context is propagated when nil
+	Traces *TracesConfiguration `json:"traces,omitempty"`
+	// MetricsFactory - optional, creates the metrics of the client instead of the OpenTelemetry metrics, e.g. the
+	// Prometheus metrics of the github.com/openfga/go-sdk/telemetry/prometheus module
+	MetricsFactory MetricsFactory `json:"-"`
+	// MeterProvider - optional provider of the meter of the metrics, the global MeterProvider when nil
+	MeterProvider metric.MeterProvider `json:"-"`
+	// TracerProvider - optional provider of the tracer of the spans, the global TracerProvider when nil
+	TracerProvider trace.TracerProvider `json:"-"`
 }
 
 func DefaultTelemetryConfiguration() *Configuration {
@@ -78,6 +120,80 @@ func DefaultTelemetryConfiguration() *Configuration {
 				ATTR_URL_SCHEME:                          &AttributeConfiguration{Enabled: true},
 				ATTR_USER_AGENT_ORIGINAL:                 &AttributeConfiguration{Enabled: true},
 			},
+			METRIC_COUNTER_CHECK_CACHE_HIT: &MetricConfiguration{
+				ATTR_HTTP_REQUEST_METHOD:         &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_MODEL_ID: &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID: &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_COUNTER_CHECK_CACHE_MISS: &MetricConfiguration{
+				ATTR_HTTP_REQUEST_METHOD:         &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_MODEL_ID: &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID: &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE: &MetricConfiguration{
+				ATTR_FGA_CLIENT_CIRCUIT_BREAKER_STATE: &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_REQUEST_METHOD:              &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID:      &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_HOST:                        &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_COUNTER_REQUEST_HEDGED: &MetricConfiguration{
+				ATTR_FGA_CLIENT_REQUEST_HEDGE_WINNER: &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_REQUEST_METHOD:             &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID:     &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_HOST:                       &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_COUNTER_REQUEST_RETRY: &MetricConfiguration{
+				ATTR_FGA_CLIENT_REQUEST_RETRY_REASON: &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_REQUEST_METHOD:             &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID:     &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_RESPONSE_STATUS_CODE:       &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT: &MetricConfiguration{
+				ATTR_HTTP_REQUEST_METHOD: &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_HISTOGRAM_BATCH_CHECK_SIZE: &MetricConfiguration{
+				ATTR_HTTP_REQUEST_METHOD:         &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID: &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_HISTOGRAM_WRITE_TUPLES: &MetricConfiguration{
+				ATTR_FGA_CLIENT_WRITE_OPERATION:  &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID: &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_HISTOGRAM_STREAM_OBJECTS: &MetricConfiguration{
+				ATTR_HTTP_REQUEST_METHOD:         &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID: &AttributeConfiguration{Enabled: true},
+			},
+			METRIC_HISTOGRAM_STREAM_FIRST_RESULT: &MetricConfiguration{
+				ATTR_HTTP_REQUEST_METHOD:         &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID: &AttributeConfiguration{Enabled: true},
+			},
+		},
+		Traces: &TracesConfiguration{
+			SPAN_CLIENT_METHOD: &SpanConfiguration{
+				ATTR_HTTP_REQUEST_METHOD:                 &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_MODEL_ID:         &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID:         &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_BATCH_CHECK_SIZE: &AttributeConfiguration{Enabled: true},
+			},
+			SPAN_HTTP_REQUEST: &SpanConfiguration{
+				ATTR_HTTP_REQUEST_METHOD:          &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_MODEL_ID:  &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_REQUEST_STORE_ID:  &AttributeConfiguration{Enabled: true},
+				ATTR_FGA_CLIENT_RESPONSE_MODEL_ID: &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_HOST:                    &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_REQUEST_RESEND_COUNT:    &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_RESPONSE_STATUS_CODE:    &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_SERVER_REQUEST_DURATION: &AttributeConfiguration{Enabled: true},
+				ATTR_URL_FULL:                     &AttributeConfiguration{Enabled: true},
+				ATTR_URL_SCHEME:                   &AttributeConfiguration{Enabled: true},
+				ATTR_USER_AGENT_ORIGINAL:          &AttributeConfiguration{Enabled: true},
+			},
+			SPAN_CREDENTIALS_REQUEST: &SpanConfiguration{
+				ATTR_FGA_CLIENT_REQUEST_CLIENT_ID: &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_HOST:                    &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_REQUEST_RESEND_COUNT:    &AttributeConfiguration{Enabled: true},
+				ATTR_HTTP_RESPONSE_STATUS_CODE:    &AttributeConfiguration{Enabled: true},
+			},
 		},
 	}
 }
@@ -59,3 +59,125 @@ func TestDefaultTelemetryConfiguration(t *testing.T) {
 	testMetricConfiguration(config.Metrics.METRIC_HISTOGRAM_REQUEST_DURATION, "METRIC_HISTOGRAM_REQUEST_DURATION")
 	testMetricConfiguration(config.Metrics.METRIC_HISTOGRAM_QUERY_DURATION, "METRIC_HISTOGRAM_QUERY_DURATION")
 }
+
+func TestDefaultTelemetryConfigurationCheckCache(t *testing.T) {
+	config := DefaultTelemetryConfiguration()
+
+	metricConfigs := map[string]*MetricConfiguration{
+		"METRIC_COUNTER_CHECK_CACHE_HIT":  config.Metrics.METRIC_COUNTER_CHECK_CACHE_HIT,
+		"METRIC_COUNTER_CHECK_CACHE_MISS": config.Metrics.METRIC_COUNTER_CHECK_CACHE_MISS,
+	}
+
+	for metricName, metricConfig := range metricConfigs {
+		if metricConfig == nil {
+			t.Fatalf("Expected non-nil MetricConfiguration for %s, but got nil", metricName)
+		}
+		if !metricConfig.ATTR_HTTP_REQUEST_METHOD.Enabled {
+			t.Errorf("Expected %s.ATTR_HTTP_REQUEST_METHOD to be enabled, but it was not", metricName)
+		}
+		if !metricConfig.ATTR_FGA_CLIENT_REQUEST_MODEL_ID.Enabled {
+			t.Errorf("Expected %s.ATTR_FGA_CLIENT_REQUEST_MODEL_ID to be enabled, but it was not", metricName)
+		}
+		if !metricConfig.ATTR_FGA_CLIENT_REQUEST_STORE_ID.Enabled {
+			t.Errorf("Expected %s.ATTR_FGA_CLIENT_REQUEST_STORE_ID to be enabled, but it was not", metricName)
+		}
+		if metricConfig.ATTR_URL_FULL != nil {
+			t.Errorf("Expected %s.ATTR_URL_FULL to be unset, but it was not", metricName)
+		}
+	}
+}
+
+func TestDefaultTelemetryConfigurationCircuitBreaker(t *testing.T) {
+	metricConfig := DefaultTelemetryConfiguration().Metrics.METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE
+
+	if metricConfig == nil {
+		t.Fatalf("Expected non-nil MetricConfiguration for METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE, but got nil")
+	}
+	if !metricConfig.ATTR_FGA_CLIENT_CIRCUIT_BREAKER_STATE.Enabled {
+		t.Errorf("Expected ATTR_FGA_CLIENT_CIRCUIT_BREAKER_STATE to be enabled, but it was not")
+	}
+	if !metricConfig.ATTR_HTTP_REQUEST_METHOD.Enabled {
+		t.Errorf("Expected ATTR_HTTP_REQUEST_METHOD to be enabled, but it was not")
+	}
+	if !metricConfig.ATTR_FGA_CLIENT_REQUEST_STORE_ID.Enabled {
+		t.Errorf("Expected ATTR_FGA_CLIENT_REQUEST_STORE_ID to be enabled, but it was not")
+	}
+	if metricConfig.ATTR_URL_FULL != nil {
+		t.Errorf("Expected ATTR_URL_FULL to be unset, but it was not")
+	}
+}
+
+func TestDefaultTelemetryConfigurationRequestHedged(t *testing.T) {
+	metricConfig := DefaultTelemetryConfiguration().Metrics.METRIC_COUNTER_REQUEST_HEDGED
+
+	if metricConfig == nil {
+		t.Fatalf("Expected non-nil MetricConfiguration for METRIC_COUNTER_REQUEST_HEDGED, but got nil")
+	}
+	if !metricConfig.ATTR_FGA_CLIENT_REQUEST_HEDGE_WINNER.Enabled {
+		t.Errorf("Expected ATTR_FGA_CLIENT_REQUEST_HEDGE_WINNER to be enabled, but it was not")
+	}
+	if !metricConfig.ATTR_HTTP_REQUEST_METHOD.Enabled {
+		t.Errorf("Expected ATTR_HTTP_REQUEST_METHOD to be enabled, but it was not")
+	}
+	if metricConfig.ATTR_FGA_CLIENT_USER != nil {
+		t.Errorf("Expected ATTR_FGA_CLIENT_USER to be unset, but it was not")
+	}
+}
+
+func TestDefaultTelemetryConfigurationRequestMetrics(t *testing.T) {
+	config := DefaultTelemetryConfiguration().Metrics
+
+	metrics := map[string]*MetricConfiguration{
+		METRIC_COUNTER_REQUEST_RETRY:              config.METRIC_COUNTER_REQUEST_RETRY,
+		METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT: config.METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT,
+		METRIC_HISTOGRAM_BATCH_CHECK_SIZE:         config.METRIC_HISTOGRAM_BATCH_CHECK_SIZE,
+		METRIC_HISTOGRAM_WRITE_TUPLES:             config.METRIC_HISTOGRAM_WRITE_TUPLES,
+		METRIC_HISTOGRAM_STREAM_OBJECTS:           config.METRIC_HISTOGRAM_STREAM_OBJECTS,
+		METRIC_HISTOGRAM_STREAM_FIRST_RESULT:      config.METRIC_HISTOGRAM_STREAM_FIRST_RESULT,
+	}
+	for name, metricConfig := range metrics {
+		if metricConfig == nil {
+			t.Fatalf("Expected non-nil MetricConfiguration for %s, but got nil", name)
+		}
+		if metricConfig.ATTR_FGA_CLIENT_USER != nil || metricConfig.ATTR_URL_FULL != nil {
+			t.Errorf("Expected the high cardinality attributes of %s to be unset", name)
+		}
+	}
+
+	if !config.METRIC_COUNTER_REQUEST_RETRY.ATTR_FGA_CLIENT_REQUEST_RETRY_REASON.Enabled {
+		t.Errorf("Expected ATTR_FGA_CLIENT_REQUEST_RETRY_REASON to be enabled, but it was not")
+	}
+	if !config.METRIC_HISTOGRAM_WRITE_TUPLES.ATTR_FGA_CLIENT_WRITE_OPERATION.Enabled {
+		t.Errorf("Expected ATTR_FGA_CLIENT_WRITE_OPERATION to be enabled, but it was not")
+	}
+}
+
+func TestDefaultTelemetryConfigurationTraces(t *testing.T) {
+	config := DefaultTelemetryConfiguration()
+
+	if config.Traces == nil {
+		t.Fatalf("Expected non-nil Traces configuration, but got nil")
+	}
+
+	spans := map[string]*SpanConfiguration{
+		"SPAN_CLIENT_METHOD":       config.Traces.SPAN_CLIENT_METHOD,
+		"SPAN_HTTP_REQUEST":        config.Traces.SPAN_HTTP_REQUEST,
+		"SPAN_CREDENTIALS_REQUEST": config.Traces.SPAN_CREDENTIALS_REQUEST,
+	}
+	for name, spanConfig := range spans {
+		if spanConfig == nil {
+			t.Fatalf("Expected non-nil SpanConfiguration for %s, but got nil", name)
+		}
+		if spanConfig.ATTR_FGA_CLIENT_USER != nil {
+			t.Errorf("Expected %s.ATTR_FGA_CLIENT_USER not to be enabled, but it was", name)
+		}
+	}
+
+	request := config.Traces.SPAN_HTTP_REQUEST
+	if !request.ATTR_HTTP_REQUEST_RESEND_COUNT.Enabled || !request.ATTR_HTTP_RESPONSE_STATUS_CODE.Enabled || !request.ATTR_HTTP_SERVER_REQUEST_DURATION.Enabled {
+		t.Errorf("Expected the retry number, status code and query duration of the requests to be enabled")
+	}
+	if !request.ATTR_FGA_CLIENT_REQUEST_STORE_ID.Enabled || !request.ATTR_FGA_CLIENT_REQUEST_MODEL_ID.Enabled {
+		t.Errorf("Expected the store and model of the requests to be enabled")
+	}
+}
@@ -1,10 +1,42 @@
 package telemetry
 
 const (
-	METRIC_COUNTER_CREDENTIALS_REQUEST string = "fga-client.credentials.request"
+	METRIC_COUNTER_CREDENTIALS_REQUEST          string = "fga-client.credentials.request"
+	METRIC_COUNTER_CHECK_CACHE_HIT              string = "fga-client.check_cache.hit"
+	METRIC_COUNTER_CHECK_CACHE_MISS             string = "fga-client.check_cache.miss"
+	METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE string = "fga-client.circuit_breaker.state_change"
+	METRIC_COUNTER_REQUEST_HEDGED               string = "fga-client.request.hedged"
+	METRIC_COUNTER_REQUEST_RETRY                string = "fga-client.request.retry"
 )
 
-var CredentialsRequest = &Counter{
-	Name:        METRIC_COUNTER_CREDENTIALS_REQUEST,
-	Description: "The total number of times new access tokens have been requested using ClientCredentials.",
-}
+var (
+	CredentialsRequest = &Counter{
+		Name:        METRIC_COUNTER_CREDENTIALS_REQUEST,
+		Description: "The total number of times new access tokens have been requested using ClientCredentials.",
+	}
+
+	CheckCacheHit = &Counter{
+		Name:        METRIC_COUNTER_CHECK_CACHE_HIT,
+		Description: "The total number of checks that were answered from the client-side check cache.",
+	}
+
+	CheckCacheMiss = &Counter{
+		Name:        METRIC_COUNTER_CHECK_CACHE_MISS,
+		Description: "The total number of checks that were not found in the client-side check cache and were sent to the server.",
+	}
+
+	CircuitBreakerStateChange = &Counter{
+		Name:        METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE,
+		Description: "The total number of times a circuit breaker of the API client changed state.",
+	}
+
+	RequestHedged = &Counter{
+		Name:        METRIC_COUNTER_REQUEST_HEDGED,
+		Description: "The total number of requests for which a hedged request was sent, by the request which won.",
+	}
+
+	RequestRetry = &Counter{
+		Name:        METRIC_COUNTER_REQUEST_RETRY,
+		Description: "The total number of times a failed request was retried, by the reason of the retry.",
+	}
+)
@@ -20,3 +20,54 @@ func TestCredentialsRequestCounter(t *testing.T) {
 		t.Errorf("Expected Description to be '%s', but got '%s'", expectedDescription, CredentialsRequest.GetDescription())
 	}
 }
+
+func TestCheckCacheCounters(t *testing.T) {
+	counters := map[*Counter]string{
+		CheckCacheHit:  METRIC_COUNTER_CHECK_CACHE_HIT,
+		CheckCacheMiss: METRIC_COUNTER_CHECK_CACHE_MISS,
+	}
+
+	for counter, expectedName := range counters {
+		if counter == nil {
+			t.Fatalf("Expected counter %s to be initialized, but got nil", expectedName)
+		}
+
+		if counter.GetName() != expectedName {
+			t.Errorf("Expected Name to be '%s', but got '%s'", expectedName, counter.GetName())
+		}
+
+		if counter.GetDescription() == "" {
+			t.Errorf("Expected counter %s to have a description", expectedName)
+		}
+	}
+}
+
+func TestCircuitBreakerStateChangeCounter(t *testing.T) {
+	if CircuitBreakerStateChange.GetName() != METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE {
+		t.Errorf("Expected Name to be '%s', but got '%s'", METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE, CircuitBreakerStateChange.GetName())
+	}
+
+	if CircuitBreakerStateChange.GetDescription() == "" {
+		t.Errorf("Expected counter %s to have a description", METRIC_COUNTER_CIRCUIT_BREAKER_STATE_CHANGE)
+	}
+}
+
+func TestRequestHedgedCounter(t *testing.T) {
+	if RequestHedged.GetName() != METRIC_COUNTER_REQUEST_HEDGED {
+		t.Errorf("Expected Name to be '%s', but got '%s'", METRIC_COUNTER_REQUEST_HEDGED, RequestHedged.GetName())
+	}
+
+	if RequestHedged.GetDescription() == "" {
+		t.Errorf("Expected counter %s to have a description", METRIC_COUNTER_REQUEST_HEDGED)
+	}
+}
+
+func TestRequestRetryCounter(t *testing.T) {
+	if RequestRetry.GetName() != METRIC_COUNTER_REQUEST_RETRY {
+		t.Errorf("Expected Name to be '%s', but got '%s'", METRIC_COUNTER_REQUEST_RETRY, RequestRetry.GetName())
+	}
+
+	if RequestRetry.GetDescription() == "" {
+		t.Errorf("Expected counter %s to have a description", METRIC_COUNTER_REQUEST_RETRY)
+	}
+}
@@ -1,8 +1,12 @@
 package telemetry
 
 const (
-	METRIC_HISTOGRAM_REQUEST_DURATION string = "fga-client.request.duration"
-	METRIC_HISTOGRAM_QUERY_DURATION   string = "fga-client.query.duration"
+	METRIC_HISTOGRAM_REQUEST_DURATION    string = "fga-client.request.duration"
+	METRIC_HISTOGRAM_QUERY_DURATION      string = "fga-client.query.duration"
+	METRIC_HISTOGRAM_BATCH_CHECK_SIZE    string = "fga-client.batch_check.size"
+	METRIC_HISTOGRAM_WRITE_TUPLES        string = "fga-client.write.tuples"
+	METRIC_HISTOGRAM_STREAM_OBJECTS      string = "fga-client.stream.objects"
+	METRIC_HISTOGRAM_STREAM_FIRST_RESULT string = "fga-client.stream.first_result"
 )
 
 var (
@@ -17,4 +21,28 @@ var (
 		Unit:        "milliseconds",
 		Description: "The total time it took (in milliseconds) for the FGA server to process and evaluate the request.",
 	}
+
+	BatchCheckSize = &Histogram{
+		Name:        METRIC_HISTOGRAM_BATCH_CHECK_SIZE,
+		Unit:        "{check}",
+		Description: "The number of checks ClientBatchCheck sends as parallel Check requests, and BatchCheck sends in each BatchCheck request.",
+	}
+
+	WriteTuples = &Histogram{
+		Name:        METRIC_HISTOGRAM_WRITE_TUPLES,
+		Unit:        "{tuple}",
+		Description: "The number of tuples written or deleted by a Write, by operation.",
+	}
+
+	StreamObjects = &Histogram{
+		Name:        METRIC_HISTOGRAM_STREAM_OBJECTS,
+		Unit:        "{object}",
+		Description: "The number of objects received in a stream of StreamedListObjects.",
+	}
+
+	StreamFirstResult = &Histogram{
+		Name:        METRIC_HISTOGRAM_STREAM_FIRST_RESULT,
+		Unit:        "milliseconds",
+		Description: "The time (in milliseconds) from sending a streamed request to receiving its first result.",
+	}
 )
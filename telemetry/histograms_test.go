@@ -47,3 +47,22 @@ func TestQueryDurationHistogram(t *testing.T) {
 		t.Errorf("Expected QueryDuration Description to be '%s', but got '%s'", expectedDescription, QueryDuration.GetDescription())
 	}
 }
+
+func TestSizeHistograms(t *testing.T) {
+	histograms := map[string]*Histogram{
+		METRIC_HISTOGRAM_BATCH_CHECK_SIZE:    BatchCheckSize,
+		METRIC_HISTOGRAM_WRITE_TUPLES:        WriteTuples,
+		METRIC_HISTOGRAM_STREAM_OBJECTS:      StreamObjects,
+		METRIC_HISTOGRAM_STREAM_FIRST_RESULT: StreamFirstResult,
+	}
+
+	for expectedName, histogram := range histograms {
+		if histogram.GetName() != expectedName {
+			t.Errorf("Expected Name to be '%s', but got '%s'", expectedName, histogram.GetName())
+		}
+
+		if histogram.GetUnit() == "" || histogram.GetDescription() == "" {
+			t.Errorf("Expected histogram %s to have a unit and a description", expectedName)
+		}
+	}
+}
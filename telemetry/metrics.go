@@ -10,12 +10,14 @@ import (
 )
 
 type Metrics struct {
-	Meter          metric.Meter
-	countersLock   sync.Mutex
-	Counters       map[string]metric.Int64Counter
-	histogramsLock sync.Mutex
-	Histograms     map[string]metric.Float64Histogram
-	Configuration  *MetricsConfiguration
+	Meter              metric.Meter
+	countersLock       sync.Mutex
+	Counters           map[string]metric.Int64Counter
+	histogramsLock     sync.Mutex
+	Histograms         map[string]metric.Float64Histogram
+	Configuration      *MetricsConfiguration
+	upDownCountersLock sync.Mutex
+	UpDownCounters     map[string]metric.Int64UpDownCounter
 }
 
 type MetricsInterface interface {
@@ -24,6 +26,17 @@ type MetricsInterface interface {
 	CredentialsRequest(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error)
 	RequestDuration(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error)
 	QueryDuration(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error)
+	CheckCacheHit(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error)
+	CheckCacheMiss(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error)
+	CircuitBreakerStateChange(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error)
+	RequestHedged(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error)
+	GetUpDownCounter(name string, description string) (metric.Int64UpDownCounter, error)
+	RequestRetry(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error)
+	RequestsInFlight(value int64, attrs map[*Attribute]string) (metric.Int64UpDownCounter, error)
+	BatchCheckSize(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error)
+	WriteTuples(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error)
+	StreamObjects(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error)
+	StreamFirstResult(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error)
 	BuildTelemetryAttributes(requestMethod string, methodParameters map[string]interface{}, req *http.Request, res *http.Response, requestStarted time.Time, resendCount int) (map[*Attribute]string, float64, float64, error)
 }
 
@@ -94,3 +107,161 @@ func (m *Metrics) QueryDuration(value float64, attrs map[*Attribute]string) (met
 
 	return histogram, err
 }
+
+func (m *Metrics) CheckCacheHit(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	var counter, err = m.GetCounter(CheckCacheHit.Name, CheckCacheHit.Description)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(CheckCacheHit, attrs, m.Configuration)
+
+		if err == nil {
+			counter.Add(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return counter, err
+}
+
+func (m *Metrics) CheckCacheMiss(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	var counter, err = m.GetCounter(CheckCacheMiss.Name, CheckCacheMiss.Description)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(CheckCacheMiss, attrs, m.Configuration)
+
+		if err == nil {
+			counter.Add(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return counter, err
+}
+
+func (m *Metrics) CircuitBreakerStateChange(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	var counter, err = m.GetCounter(CircuitBreakerStateChange.Name, CircuitBreakerStateChange.Description)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(CircuitBreakerStateChange, attrs, m.Configuration)
+
+		if err == nil {
+			counter.Add(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return counter, err
+}
+
+func (m *Metrics) RequestHedged(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	var counter, err = m.GetCounter(RequestHedged.Name, RequestHedged.Description)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(RequestHedged, attrs, m.Configuration)
+
+		if err == nil {
+			counter.Add(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return counter, err
+}
+
+// GetUpDownCounter returns the up-down counter of a metric whose value goes up and down, e.g. the requests in flight
+func (m *Metrics) GetUpDownCounter(name string, description string) (metric.Int64UpDownCounter, error) {
+	m.upDownCountersLock.Lock()
+	defer m.upDownCountersLock.Unlock()
+
+	if m.UpDownCounters == nil {
+		m.UpDownCounters = make(map[string]metric.Int64UpDownCounter)
+	}
+	if counter, exists := m.UpDownCounters[name]; exists {
+		return counter, nil
+	}
+
+	counter, _ := m.Meter.Int64UpDownCounter(name, metric.WithDescription(description))
+	m.UpDownCounters[name] = counter
+
+	return counter, nil
+}
+
+func (m *Metrics) RequestRetry(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	var counter, err = m.GetCounter(RequestRetry.Name, RequestRetry.Description)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(RequestRetry, attrs, m.Configuration)
+
+		if err == nil {
+			counter.Add(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return counter, err
+}
+
+func (m *Metrics) RequestsInFlight(value int64, attrs map[*Attribute]string) (metric.Int64UpDownCounter, error) {
+	var counter, err = m.GetUpDownCounter(RequestsInFlight.Name, RequestsInFlight.Description)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(RequestsInFlight, attrs, m.Configuration)
+
+		if err == nil {
+			counter.Add(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return counter, err
+}
+
+func (m *Metrics) BatchCheckSize(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error) {
+	var histogram, err = m.GetHistogram(BatchCheckSize.Name, BatchCheckSize.Description, BatchCheckSize.Unit)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(BatchCheckSize, attrs, m.Configuration)
+
+		if err == nil {
+			histogram.Record(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return histogram, err
+}
+
+func (m *Metrics) WriteTuples(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error) {
+	var histogram, err = m.GetHistogram(WriteTuples.Name, WriteTuples.Description, WriteTuples.Unit)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(WriteTuples, attrs, m.Configuration)
+
+		if err == nil {
+			histogram.Record(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return histogram, err
+}
+
+func (m *Metrics) StreamObjects(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error) {
+	var histogram, err = m.GetHistogram(StreamObjects.Name, StreamObjects.Description, StreamObjects.Unit)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(StreamObjects, attrs, m.Configuration)
+
+		if err == nil {
+			histogram.Record(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return histogram, err
+}
+
+func (m *Metrics) StreamFirstResult(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error) {
+	var histogram, err = m.GetHistogram(StreamFirstResult.Name, StreamFirstResult.Description, StreamFirstResult.Unit)
+
+	if err == nil {
+		attrs, err := m.PrepareAttributes(StreamFirstResult, attrs, m.Configuration)
+
+		if err == nil {
+			histogram.Record(context.Background(), value, metric.WithAttributeSet(attrs))
+		}
+	}
+
+	return histogram, err
+}
@@ -28,11 +28,34 @@ func (m *MockFloat64Histogram) Record(ctx context.Context, value float64, opts .
 	m.recordCalled = true
 }
 
+// Mock Int64UpDownCounter implementation
+type MockInt64UpDownCounter struct {
+	metric.Int64UpDownCounter
+	value int64
+}
+
+func (m *MockInt64UpDownCounter) Add(ctx context.Context, value int64, opts ...metric.AddOption) {
+	m.value += value
+}
+
 // Mock Meter implementation
 type MockMeter struct {
 	metric.Meter
-	counters   map[string]metric.Int64Counter
-	histograms map[string]metric.Float64Histogram
+	counters       map[string]metric.Int64Counter
+	histograms     map[string]metric.Float64Histogram
+	upDownCounters map[string]metric.Int64UpDownCounter
+}
+
+func (m *MockMeter) Int64UpDownCounter(name string, opts ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	if m.upDownCounters == nil {
+		m.upDownCounters = make(map[string]metric.Int64UpDownCounter)
+	}
+	if counter, exists := m.upDownCounters[name]; exists {
+		return counter, nil
+	}
+	counter := &MockInt64UpDownCounter{}
+	m.upDownCounters[name] = counter
+	return counter, nil
 }
 
 func (m *MockMeter) Int64Counter(name string, opts ...metric.Int64CounterOption) (metric.Int64Counter, error) {
@@ -257,3 +280,232 @@ func TestQueryDuration(t *testing.T) {
 		t.Fatalf("Expected Record method to be called on histogram")
 	}
 }
+
+func TestMetricsCheckCache(t *testing.T) {
+	mockMeter := &MockMeter{
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+	}
+	metrics := &Metrics{
+		Meter:         mockMeter,
+		Counters:      make(map[string]metric.Int64Counter),
+		Configuration: DefaultTelemetryConfiguration().Metrics,
+	}
+
+	attrs := map[*Attribute]string{
+		FGAClientRequestMethod:  "Check",
+		FGAClientRequestStoreID: "01GXSB9YR785C4FYS3C0RTG7B2",
+	}
+
+	hitCounter, err := metrics.CheckCacheHit(1, attrs)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	missCounter, err := metrics.CheckCacheMiss(1, attrs)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if hitCounter == missCounter {
+		t.Fatalf("Expected hit and miss counters to be distinct")
+	}
+
+	for _, counter := range []metric.Int64Counter{hitCounter, missCounter} {
+		mockCounter, ok := counter.(*MockInt64Counter)
+		if !ok || !mockCounter.addCalled {
+			t.Fatalf("Expected Add method to be called on counter")
+		}
+	}
+}
+
+func TestMetricsCircuitBreakerStateChange(t *testing.T) {
+	mockMeter := &MockMeter{
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+	}
+	metrics := &Metrics{
+		Meter:         mockMeter,
+		Counters:      make(map[string]metric.Int64Counter),
+		Configuration: DefaultTelemetryConfiguration().Metrics,
+	}
+
+	counter, err := metrics.CircuitBreakerStateChange(1, map[*Attribute]string{
+		FGAClientCircuitBreakerState: "open",
+		FGAClientRequestMethod:       "Check",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	mockCounter, ok := counter.(*MockInt64Counter)
+	if !ok || !mockCounter.addCalled {
+		t.Fatalf("Expected Add method to be called on counter")
+	}
+}
+
+func TestPrepareAttributesCircuitBreakerStateChange(t *testing.T) {
+	metrics := &Metrics{}
+	config := DefaultTelemetryConfiguration().Metrics
+
+	set, err := metrics.PrepareAttributes(CircuitBreakerStateChange, map[*Attribute]string{
+		FGAClientCircuitBreakerState: "half_open",
+		FGAClientRequestStoreID:      "01GXSB9YR785C4FYS3C0RTG7B2",
+		URLFull:                      "https://api.fga.example/stores",
+	}, config)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if value, ok := set.Value(ATTR_FGA_CLIENT_CIRCUIT_BREAKER_STATE); !ok || value.AsString() != "half_open" {
+		t.Errorf("Expected the circuit breaker state to be 'half_open', but got %v", value.AsString())
+	}
+	if _, ok := set.Value(ATTR_FGA_CLIENT_REQUEST_STORE_ID); !ok {
+		t.Errorf("Expected the store id to be kept")
+	}
+	if _, ok := set.Value(ATTR_URL_FULL); ok {
+		t.Errorf("Expected the full url to be dropped")
+	}
+}
+
+func TestMetricsRequestHedged(t *testing.T) {
+	mockMeter := &MockMeter{
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+	}
+	metrics := &Metrics{
+		Meter:         mockMeter,
+		Counters:      make(map[string]metric.Int64Counter),
+		Configuration: DefaultTelemetryConfiguration().Metrics,
+	}
+
+	counter, err := metrics.RequestHedged(1, map[*Attribute]string{
+		FGAClientRequestHedgeWinner: "hedge",
+		FGAClientRequestMethod:      "Check",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	mockCounter, ok := counter.(*MockInt64Counter)
+	if !ok || !mockCounter.addCalled {
+		t.Fatalf("Expected Add method to be called on counter")
+	}
+}
+
+func TestPrepareAttributesRequestHedged(t *testing.T) {
+	metrics := &Metrics{}
+	config := DefaultTelemetryConfiguration().Metrics
+
+	set, err := metrics.PrepareAttributes(RequestHedged, map[*Attribute]string{
+		FGAClientRequestHedgeWinner: "primary",
+		FGAClientRequestMethod:      "ListObjects",
+		FGAClientUser:               "user:anne",
+	}, config)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if value, ok := set.Value(ATTR_FGA_CLIENT_REQUEST_HEDGE_WINNER); !ok || value.AsString() != "primary" {
+		t.Errorf("Expected the hedge winner to be 'primary', but got %v", value.AsString())
+	}
+	if _, ok := set.Value(ATTR_FGA_CLIENT_USER); ok {
+		t.Errorf("Expected the user to be dropped")
+	}
+}
+
+func TestMetricsRequestRetry(t *testing.T) {
+	mockMeter := &MockMeter{
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+	}
+	metrics := &Metrics{
+		Meter:         mockMeter,
+		Counters:      make(map[string]metric.Int64Counter),
+		Configuration: DefaultTelemetryConfiguration().Metrics,
+	}
+
+	counter, err := metrics.RequestRetry(1, map[*Attribute]string{
+		FGAClientRequestRetryReason: "rate_limit",
+		FGAClientRequestMethod:      "Check",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	mockCounter, ok := counter.(*MockInt64Counter)
+	if !ok || !mockCounter.addCalled {
+		t.Fatalf("Expected Add method to be called on counter")
+	}
+}
+
+func TestMetricsRequestsInFlight(t *testing.T) {
+	metrics := &Metrics{
+		Meter:         &MockMeter{},
+		Configuration: DefaultTelemetryConfiguration().Metrics,
+	}
+	attrs := map[*Attribute]string{FGAClientRequestMethod: "Check"}
+
+	_, _ = metrics.RequestsInFlight(1, attrs)
+	_, _ = metrics.RequestsInFlight(1, attrs)
+	counter, err := metrics.RequestsInFlight(-1, attrs)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	mockCounter, ok := counter.(*MockInt64UpDownCounter)
+	if !ok || mockCounter.value != 1 {
+		t.Fatalf("Expected 1 request in flight, got %v", counter)
+	}
+}
+
+func TestMetricsSizeHistograms(t *testing.T) {
+	mockMeter := &MockMeter{
+		counters:   make(map[string]metric.Int64Counter),
+		histograms: make(map[string]metric.Float64Histogram),
+	}
+	metrics := &Metrics{
+		Meter:         mockMeter,
+		Histograms:    make(map[string]metric.Float64Histogram),
+		Configuration: DefaultTelemetryConfiguration().Metrics,
+	}
+
+	record := map[string]func(float64, map[*Attribute]string) (metric.Float64Histogram, error){
+		METRIC_HISTOGRAM_BATCH_CHECK_SIZE:    metrics.BatchCheckSize,
+		METRIC_HISTOGRAM_WRITE_TUPLES:        metrics.WriteTuples,
+		METRIC_HISTOGRAM_STREAM_OBJECTS:      metrics.StreamObjects,
+		METRIC_HISTOGRAM_STREAM_FIRST_RESULT: metrics.StreamFirstResult,
+	}
+	for name, recordMetric := range record {
+		histogram, err := recordMetric(10, map[*Attribute]string{FGAClientRequestStoreID: "01GXSB9YR785C4FYS3C0RTG7B2"})
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		mockHistogram, ok := histogram.(*MockFloat64Histogram)
+		if !ok || !mockHistogram.recordCalled || mockMeter.histograms[name] != histogram {
+			t.Errorf("Expected Record method to be called on the histogram %s", name)
+		}
+	}
+}
+
+func TestPrepareAttributesWriteTuples(t *testing.T) {
+	metrics := &Metrics{}
+	config := DefaultTelemetryConfiguration().Metrics
+
+	set, err := metrics.PrepareAttributes(WriteTuples, map[*Attribute]string{
+		FGAClientWriteOperation: "delete",
+		FGAClientRequestStoreID: "01GXSB9YR785C4FYS3C0RTG7B2",
+		FGAClientUser:           "user:anne",
+	}, config)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if value, ok := set.Value(ATTR_FGA_CLIENT_WRITE_OPERATION); !ok || value.AsString() != "delete" {
+		t.Errorf("Expected the write operation to be 'delete', but got %v", value.AsString())
+	}
+	if _, ok := set.Value(ATTR_FGA_CLIENT_USER); ok {
+		t.Errorf("Expected the user to be dropped")
+	}
+}
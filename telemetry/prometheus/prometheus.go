@@ -0,0 +1,348 @@
+// Package prometheus records the metrics of the OpenFGA Go SDK in Prometheus collectors, for the applications using
+// prometheus/client_golang without an OpenTelemetry pipeline. It is a module of its own so that the SDK does not
+// depend on prometheus/client_golang.
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/openfga/go-sdk/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+var (
+	// MillisecondsBuckets are the default buckets of the histograms of durations, in milliseconds
+	MillisecondsBuckets = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+	// SizeBuckets are the default buckets of the histograms of sizes, e.g. the checks of a batch check
+	SizeBuckets = []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000}
+
+	invalidChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+// Configuration of the Prometheus collectors of the metrics
+type Configuration struct {
+	// Registerer - registry of the collectors, prometheus.DefaultRegisterer when nil
+	Registerer prometheus.Registerer
+	// Buckets - optional buckets of the histograms by metric name, MillisecondsBuckets for the durations and
+	// SizeBuckets for the sizes when not set
+	Buckets map[string][]float64
+}
+
+// MetricsFactory returns the factory of the Prometheus metrics of configuration, to set as the MetricsFactory of the
+// telemetry configuration of a client
+func MetricsFactory(configuration *Configuration) telemetry.MetricsFactory {
+	return func(metrics *telemetry.MetricsConfiguration) telemetry.MetricsInterface {
+		return NewMetrics(configuration, metrics)
+	}
+}
+
+// Metrics records the metrics of the SDK in Prometheus collectors registered on a registerer. The labels of a
+// collector are the attributes the MetricConfiguration of its metric enables.
+type Metrics struct {
+	Registerer     prometheus.Registerer
+	Buckets        map[string][]float64
+	Configuration  *telemetry.MetricsConfiguration
+	lock           sync.Mutex
+	counters       map[string]metric.Int64Counter
+	histograms     map[string]metric.Float64Histogram
+	upDownCounters map[string]metric.Int64UpDownCounter
+}
+
+// NewMetrics returns the metrics registering their collectors on the registerer of configuration, or on
+// prometheus.DefaultRegisterer when it has none
+func NewMetrics(configuration *Configuration, metrics *telemetry.MetricsConfiguration) *Metrics {
+	registerer := prometheus.DefaultRegisterer
+	var buckets map[string][]float64
+	if configuration != nil {
+		if configuration.Registerer != nil {
+			registerer = configuration.Registerer
+		}
+		buckets = configuration.Buckets
+	}
+
+	return &Metrics{
+		Registerer:     registerer,
+		Buckets:        buckets,
+		Configuration:  metrics,
+		counters:       make(map[string]metric.Int64Counter),
+		histograms:     make(map[string]metric.Float64Histogram),
+		upDownCounters: make(map[string]metric.Int64UpDownCounter),
+	}
+}
+
+// GetCounter returns the counter of a metric, registering its collector named after it with the "_total" suffix,
+// e.g. fga_client_credentials_request_total
+func (m *Metrics) GetCounter(name string, description string) (metric.Int64Counter, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if counter, exists := m.counters[name]; exists {
+		return counter, nil
+	}
+
+	labels := m.Configuration.EnabledAttributes(name)
+	vec, err := registerCollector(m.Registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricName(name) + "_total",
+		Help: description,
+	}, labelNames(labels)))
+	if err != nil {
+		return nil, err
+	}
+
+	instrument := &counter{vec: vec, labels: labels}
+	m.counters[name] = instrument
+
+	return instrument, nil
+}
+
+// GetHistogram returns the histogram of a metric, registering its collector named after it with the unit as suffix
+// when it is in milliseconds, e.g. fga_client_request_duration_milliseconds
+func (m *Metrics) GetHistogram(name string, description string, unit string) (metric.Float64Histogram, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if histogram, exists := m.histograms[name]; exists {
+		return histogram, nil
+	}
+
+	collectorName := metricName(name)
+	buckets := SizeBuckets
+	if unit == "milliseconds" {
+		collectorName += "_milliseconds"
+		buckets = MillisecondsBuckets
+	}
+	if configured, ok := m.Buckets[name]; ok {
+		buckets = configured
+	}
+
+	labels := m.Configuration.EnabledAttributes(name)
+	vec, err := registerCollector(m.Registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    collectorName,
+		Help:    description,
+		Buckets: buckets,
+	}, labelNames(labels)))
+	if err != nil {
+		return nil, err
+	}
+
+	instrument := &histogram{vec: vec, labels: labels}
+	m.histograms[name] = instrument
+
+	return instrument, nil
+}
+
+// GetUpDownCounter returns the up-down counter of a metric, registering its gauge named after it, e.g.
+// fga_client_request_in_flight
+func (m *Metrics) GetUpDownCounter(name string, description string) (metric.Int64UpDownCounter, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if counter, exists := m.upDownCounters[name]; exists {
+		return counter, nil
+	}
+
+	labels := m.Configuration.EnabledAttributes(name)
+	vec, err := registerCollector(m.Registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricName(name),
+		Help: description,
+	}, labelNames(labels)))
+	if err != nil {
+		return nil, err
+	}
+
+	instrument := &upDownCounter{vec: vec, labels: labels}
+	m.upDownCounters[name] = instrument
+
+	return instrument, nil
+}
+
+func (m *Metrics) CredentialsRequest(value int64, attrs map[*telemetry.Attribute]string) (metric.Int64Counter, error) {
+	return m.add(telemetry.CredentialsRequest, value, attrs)
+}
+
+func (m *Metrics) RequestDuration(value float64, attrs map[*telemetry.Attribute]string) (metric.Float64Histogram, error) {
+	return m.record(telemetry.RequestDuration, value, attrs)
+}
+
+func (m *Metrics) QueryDuration(value float64, attrs map[*telemetry.Attribute]string) (metric.Float64Histogram, error) {
+	return m.record(telemetry.QueryDuration, value, attrs)
+}
+
+func (m *Metrics) CheckCacheHit(value int64, attrs map[*telemetry.Attribute]string) (metric.Int64Counter, error) {
+	return m.add(telemetry.CheckCacheHit, value, attrs)
+}
+
+func (m *Metrics) CheckCacheMiss(value int64, attrs map[*telemetry.Attribute]string) (metric.Int64Counter, error) {
+	return m.add(telemetry.CheckCacheMiss, value, attrs)
+}
+
+func (m *Metrics) CircuitBreakerStateChange(value int64, attrs map[*telemetry.Attribute]string) (metric.Int64Counter, error) {
+	return m.add(telemetry.CircuitBreakerStateChange, value, attrs)
+}
+
+func (m *Metrics) RequestHedged(value int64, attrs map[*telemetry.Attribute]string) (metric.Int64Counter, error) {
+	return m.add(telemetry.RequestHedged, value, attrs)
+}
+
+func (m *Metrics) RequestRetry(value int64, attrs map[*telemetry.Attribute]string) (metric.Int64Counter, error) {
+	return m.add(telemetry.RequestRetry, value, attrs)
+}
+
+func (m *Metrics) RequestsInFlight(value int64, attrs map[*telemetry.Attribute]string) (metric.Int64UpDownCounter, error) {
+	name := telemetry.RequestsInFlight.Name
+	counter, err := m.GetUpDownCounter(name, telemetry.RequestsInFlight.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	counter.Add(context.Background(), value, metric.WithAttributeSet(m.attributeSet(name, attrs)))
+
+	return counter, nil
+}
+
+func (m *Metrics) BatchCheckSize(value float64, attrs map[*telemetry.Attribute]string) (metric.Float64Histogram, error) {
+	return m.record(telemetry.BatchCheckSize, value, attrs)
+}
+
+func (m *Metrics) WriteTuples(value float64, attrs map[*telemetry.Attribute]string) (metric.Float64Histogram, error) {
+	return m.record(telemetry.WriteTuples, value, attrs)
+}
+
+func (m *Metrics) StreamObjects(value float64, attrs map[*telemetry.Attribute]string) (metric.Float64Histogram, error) {
+	return m.record(telemetry.StreamObjects, value, attrs)
+}
+
+func (m *Metrics) StreamFirstResult(value float64, attrs map[*telemetry.Attribute]string) (metric.Float64Histogram, error) {
+	return m.record(telemetry.StreamFirstResult, value, attrs)
+}
+
+func (m *Metrics) BuildTelemetryAttributes(requestMethod string, methodParameters map[string]interface{}, req *http.Request, res *http.Response, requestStarted time.Time, resendCount int) (map[*telemetry.Attribute]string, float64, float64, error) {
+	attrs, queryDuration, requestDuration := telemetry.BuildTelemetryAttributes(requestMethod, methodParameters, req, res, requestStarted, resendCount)
+
+	return attrs, queryDuration, requestDuration, nil
+}
+
+// add adds value to the counter of a metric, with the attributes of attrs enabled for it
+func (m *Metrics) add(counter *telemetry.Counter, value int64, attrs map[*telemetry.Attribute]string) (metric.Int64Counter, error) {
+	instrument, err := m.GetCounter(counter.Name, counter.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	instrument.Add(context.Background(), value, metric.WithAttributeSet(m.attributeSet(counter.Name, attrs)))
+
+	return instrument, nil
+}
+
+// record records value in the histogram of a metric, with the attributes of attrs enabled for it
+func (m *Metrics) record(histogram *telemetry.Histogram, value float64, attrs map[*telemetry.Attribute]string) (metric.Float64Histogram, error) {
+	instrument, err := m.GetHistogram(histogram.Name, histogram.Description, histogram.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	instrument.Record(context.Background(), value, metric.WithAttributeSet(m.attributeSet(histogram.Name, attrs)))
+
+	return instrument, nil
+}
+
+// attributeSet returns the attributes of attrs enabled for the metric named name
+func (m *Metrics) attributeSet(name string, attrs map[*telemetry.Attribute]string) attribute.Set {
+	var kvs []attribute.KeyValue
+	for _, attr := range m.Configuration.EnabledAttributes(name) {
+		if value, ok := attrs[attr]; ok {
+			kvs = append(kvs, attribute.String(attr.Name, value))
+		}
+	}
+
+	return attribute.NewSet(kvs...)
+}
+
+// registerCollector registers collector, returning the collector already registered in its place, e.g. by another
+// client sharing the registerer
+func registerCollector[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	err := registerer.Register(collector)
+	if err == nil {
+		return collector, nil
+	}
+
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		if existing, ok := alreadyRegistered.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+
+	return collector, err
+}
+
+// metricName returns the name of a metric or an attribute in Prometheus, e.g. fga_client_request_duration
+func metricName(name string) string {
+	return invalidChars.ReplaceAllString(name, "_")
+}
+
+func labelNames(labels []*telemetry.Attribute) []string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = metricName(label.Name)
+	}
+
+	return names
+}
+
+// labelValues returns the values of labels in attrs, empty for the attributes it does not have
+func labelValues(labels []*telemetry.Attribute, attrs attribute.Set) prometheus.Labels {
+	values := make(prometheus.Labels, len(labels))
+	for _, label := range labels {
+		value := ""
+		if attr, ok := attrs.Value(attribute.Key(label.Name)); ok {
+			value = attr.Emit()
+		}
+		values[metricName(label.Name)] = value
+	}
+
+	return values
+}
+
+type counter struct {
+	embedded.Int64Counter
+	vec    *prometheus.CounterVec
+	labels []*telemetry.Attribute
+}
+
+func (c *counter) Add(_ context.Context, incr int64, options ...metric.AddOption) {
+	// a counter only goes up, as the OpenTelemetry counters which drop the negative increments
+	if incr < 0 {
+		return
+	}
+
+	c.vec.With(labelValues(c.labels, metric.NewAddConfig(options).Attributes())).Add(float64(incr))
+}
+
+type histogram struct {
+	embedded.Float64Histogram
+	vec    *prometheus.HistogramVec
+	labels []*telemetry.Attribute
+}
+
+func (h *histogram) Record(_ context.Context, value float64, options ...metric.RecordOption) {
+	h.vec.With(labelValues(h.labels, metric.NewRecordConfig(options).Attributes())).Observe(value)
+}
+
+type upDownCounter struct {
+	embedded.Int64UpDownCounter
+	vec    *prometheus.GaugeVec
+	labels []*telemetry.Attribute
+}
+
+func (c *upDownCounter) Add(_ context.Context, incr int64, options ...metric.AddOption) {
+	c.vec.With(labelValues(c.labels, metric.NewAddConfig(options).Attributes())).Add(float64(incr))
+}
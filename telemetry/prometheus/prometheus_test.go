@@ -0,0 +1,197 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/telemetry"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func gatherFamily(t *testing.T, registry *prometheus.Registry, name string) *dto.MetricFamily {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather the metrics: %v", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() == name {
+			return family
+		}
+	}
+
+	t.Fatalf("Expected the metric %s to be registered", name)
+	return nil
+}
+
+func labelValuesOf(m *dto.Metric) map[string]string {
+	values := make(map[string]string)
+	for _, label := range m.GetLabel() {
+		values[label.GetName()] = label.GetValue()
+	}
+
+	return values
+}
+
+func TestMetricsRecordsCollectors(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics := NewMetrics(&Configuration{Registerer: registry}, telemetry.DefaultTelemetryConfiguration().Metrics)
+	attrs := map[*telemetry.Attribute]string{
+		telemetry.FGAClientRequestMethod:  "Check",
+		telemetry.FGAClientRequestStoreID: "01GXSB9YR785C4FYS3C0RTG7B2",
+		telemetry.FGAClientUser:           "user:anne",
+	}
+
+	if _, err := metrics.RequestDuration(12, attrs); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := metrics.CredentialsRequest(1, map[*telemetry.Attribute]string{telemetry.FGAClientRequestClientID: "client"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, _ = metrics.RequestsInFlight(1, attrs)
+	_, _ = metrics.RequestsInFlight(1, attrs)
+	_, _ = metrics.RequestsInFlight(-1, attrs)
+
+	requestDuration := gatherFamily(t, registry, "fga_client_request_duration_milliseconds")
+	if requestDuration.GetType() != dto.MetricType_HISTOGRAM || len(requestDuration.GetMetric()) != 1 {
+		t.Fatalf("Expected a histogram of the request duration, got %v", requestDuration)
+	}
+	histogram := requestDuration.GetMetric()[0]
+	if histogram.GetHistogram().GetSampleCount() != 1 || histogram.GetHistogram().GetSampleSum() != 12 {
+		t.Fatalf("Expected a request of 12ms, got %v", histogram.GetHistogram())
+	}
+	labels := labelValuesOf(histogram)
+	if labels["fga_client_request_store_id"] != "01GXSB9YR785C4FYS3C0RTG7B2" || labels["fga_client_request_method"] != "Check" {
+		t.Fatalf("Expected the store and the method as labels, got %v", labels)
+	}
+	if _, ok := labels["fga_client_user"]; ok {
+		t.Fatalf("Expected the user not to be a label when it is not enabled, got %v", labels)
+	}
+	if labels["http_host"] != "" {
+		t.Fatalf("Expected the labels missing from the attributes to be empty, got %v", labels)
+	}
+
+	credentialsRequest := gatherFamily(t, registry, "fga_client_credentials_request_total")
+	if value := credentialsRequest.GetMetric()[0].GetCounter().GetValue(); value != 1 {
+		t.Fatalf("Expected a credentials request, got %v", value)
+	}
+
+	inFlight := gatherFamily(t, registry, "fga_client_request_in_flight")
+	if inFlight.GetType() != dto.MetricType_GAUGE || inFlight.GetMetric()[0].GetGauge().GetValue() != 1 {
+		t.Fatalf("Expected a request in flight, got %v", inFlight)
+	}
+	if labels := labelValuesOf(inFlight.GetMetric()[0]); labels["fga_client_request_method"] != "Check" || labels["fga_client_request_store_id"] != "" {
+		t.Fatalf("Expected the method and not the store as labels of the requests in flight, got %v", labels)
+	}
+}
+
+func TestMetricsSharedRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	configuration := telemetry.DefaultTelemetryConfiguration().Metrics
+
+	for i := 0; i < 2; i++ {
+		metrics := NewMetrics(&Configuration{Registerer: registry}, configuration)
+		if _, err := metrics.CheckCacheHit(1, map[*telemetry.Attribute]string{telemetry.FGAClientRequestStoreID: "store"}); err != nil {
+			t.Fatalf("Expected the collector registered by another client to be reused, got %v", err)
+		}
+	}
+
+	if value := gatherFamily(t, registry, "fga_client_check_cache_hit_total").GetMetric()[0].GetCounter().GetValue(); value != 2 {
+		t.Fatalf("Expected the hits of both clients, got %v", value)
+	}
+
+	// a collector of the same name with other labels cannot be registered
+	metrics := NewMetrics(&Configuration{Registerer: registry}, &telemetry.MetricsConfiguration{})
+	if _, err := metrics.CheckCacheHit(1, nil); err == nil {
+		t.Fatalf("Expected an error registering a collector with other labels")
+	}
+}
+
+func TestMetricsBuckets(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics := NewMetrics(&Configuration{
+		Registerer: registry,
+		Buckets:    map[string][]float64{telemetry.METRIC_HISTOGRAM_QUERY_DURATION: {1, 2}},
+	}, nil)
+
+	_, _ = metrics.QueryDuration(1.5, nil)
+	_, _ = metrics.BatchCheckSize(3, nil)
+
+	if buckets := gatherFamily(t, registry, "fga_client_query_duration_milliseconds").GetMetric()[0].GetHistogram().GetBucket(); len(buckets) != 2 {
+		t.Fatalf("Expected the configured buckets, got %v", buckets)
+	}
+	batchCheckSize := gatherFamily(t, registry, "fga_client_batch_check_size").GetMetric()[0]
+	if len(batchCheckSize.GetHistogram().GetBucket()) != len(SizeBuckets) || len(batchCheckSize.GetLabel()) != 0 {
+		t.Fatalf("Expected the size buckets and no labels without configuration, got %v", batchCheckSize)
+	}
+}
+
+func TestMetricsFactory(t *testing.T) {
+	configuration := telemetry.DefaultTelemetryConfiguration()
+	configuration.MetricsFactory = MetricsFactory(&Configuration{Registerer: prometheus.NewRegistry()})
+
+	configured, _ := telemetry.Configure(configuration)
+	metrics, ok := configured.Metrics.(*Metrics)
+	if !ok {
+		t.Fatalf("Expected the Prometheus metrics, got %T", configured.Metrics)
+	}
+	if metrics.Configuration != configuration.Metrics {
+		t.Fatalf("Expected the Prometheus metrics to use the configuration of the metrics")
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	if name := metricName(telemetry.ATTR_FGA_CLIENT_REQUEST_STORE_ID); name != "fga_client_request_store_id" {
+		t.Fatalf("Expected the name to be fga_client_request_store_id, got %s", name)
+	}
+}
+
+func TestApiClientRecordsMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("fga-query-duration-ms", "3")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	registry := prometheus.NewRegistry()
+	telemetryConfiguration := telemetry.DefaultTelemetryConfiguration()
+	telemetryConfiguration.MetricsFactory = MetricsFactory(&Configuration{Registerer: registry})
+	cfg, err := openfga.NewConfiguration(openfga.Configuration{
+		ApiUrl:     server.URL,
+		HTTPClient: &http.Client{},
+		Telemetry:  telemetryConfiguration,
+	})
+	if err != nil {
+		t.Fatalf("failed to create configuration: %v", err)
+	}
+
+	_, _, err = openfga.NewAPIClient(cfg).OpenFgaApi.Check(context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2").
+		Body(openfga.CheckRequest{TupleKey: openfga.CheckRequestTupleKey{User: "user:anne", Relation: "viewer", Object: "document:roadmap"}}).
+		Execute()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather the metrics: %v", err)
+	}
+	histograms := make(map[string]uint64)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			if m.GetHistogram() != nil {
+				histograms[family.GetName()] += m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	if histograms["fga_client_request_duration_milliseconds"] != 1 || histograms["fga_client_query_duration_milliseconds"] != 1 {
+		t.Fatalf("Expected the durations of the request in the registry, got %v", histograms)
+	}
+}
@@ -15,11 +15,15 @@ type TelemetryInterface interface {
 
 type Telemetry struct {
 	Metrics       MetricsInterface
+	Traces        *Traces
 	Configuration *Configuration
 }
 
 type TelemetryFactoryParameters struct {
 	Configuration *Configuration
+	// Telemetry - optional instance to report to, e.g. the instance of an API client, instead of the instance
+	// registered for Configuration
+	Telemetry *Telemetry
 }
 
 type CredentialsRequestMetricParameters struct {
@@ -40,35 +44,115 @@ type QueryDurationMetricParameters struct {
 	TelemetryFactoryParameters
 }
 
+type CheckCacheMetricParameters struct {
+	Value int64
+	Attrs map[*Attribute]string
+	TelemetryFactoryParameters
+}
+
+type CircuitBreakerStateChangeMetricParameters struct {
+	Value int64
+	Attrs map[*Attribute]string
+	TelemetryFactoryParameters
+}
+
+type HedgedRequestMetricParameters struct {
+	Value int64
+	Attrs map[*Attribute]string
+	TelemetryFactoryParameters
+}
+
+type RequestRetryMetricParameters struct {
+	Value int64
+	Attrs map[*Attribute]string
+	TelemetryFactoryParameters
+}
+
+type RequestsInFlightMetricParameters struct {
+	Value int64
+	Attrs map[*Attribute]string
+	TelemetryFactoryParameters
+}
+
+type BatchCheckSizeMetricParameters struct {
+	Value float64
+	Attrs map[*Attribute]string
+	TelemetryFactoryParameters
+}
+
+type WriteTuplesMetricParameters struct {
+	Value float64
+	Attrs map[*Attribute]string
+	TelemetryFactoryParameters
+}
+
+type StreamMetricParameters struct {
+	Value float64
+	Attrs map[*Attribute]string
+	TelemetryFactoryParameters
+}
+
 type TelemetryContextKey struct{}
 
+// InstrumentationName is the name of the meter and the tracer of the SDK
+const InstrumentationName = "openfga-sdk"
+
 var (
 	telemetryInstancesLock sync.Mutex
+	// TelemetryInstances holds the instances returned by Get, until they are removed by Unregister. The API clients
+	// own their instance and do not register it.
 	// Warning: do not use directly, it may cause data race.
 	// Deprecated: this map will be renamed to telemetryInstances.
 	TelemetryInstances map[*Configuration]*Telemetry
 	TelemetryContext   TelemetryContextKey
 )
 
+// Configure returns a new instance reporting with the providers of configuration, or with the global providers when it
+// has none. The instance is not registered, so it is not shared with the other instances of the configuration.
 func Configure(configuration *Configuration) (*Telemetry, error) {
+	if configuration == nil {
+		configuration = DefaultTelemetryConfiguration()
+	}
+
+	meterProvider := configuration.MeterProvider
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	tracerProvider := configuration.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+
+	var metrics MetricsInterface = &Metrics{
+		Meter:          meterProvider.Meter(InstrumentationName),
+		Counters:       make(map[string]metric.Int64Counter),
+		Histograms:     make(map[string]metric.Float64Histogram),
+		UpDownCounters: make(map[string]metric.Int64UpDownCounter),
+		Configuration:  configuration.Metrics,
+	}
+	if configuration.MetricsFactory != nil {
+		metrics = configuration.MetricsFactory(configuration.Metrics)
+	}
+
 	return &Telemetry{
-		Metrics: &Metrics{
-			Meter:         otel.Meter("openfga-sdk"),
-			Counters:      make(map[string]metric.Int64Counter),
-			Histograms:    make(map[string]metric.Float64Histogram),
-			Configuration: configuration.Metrics,
+		Metrics: metrics,
+		Traces: &Traces{
+			Tracer:        tracerProvider.Tracer(InstrumentationName),
+			Configuration: configuration.Traces,
 		},
 		Configuration: configuration,
 	}, nil
 }
 
+// Get returns the instance of factory when it has one, or else the instance registered for its configuration,
+// configuring and registering it the first time. The instances of a nil configuration share the default configuration.
 func Get(factory TelemetryFactoryParameters) *Telemetry {
-	configuration := factory.Configuration
-
-	if configuration == nil {
-		configuration = DefaultTelemetryConfiguration()
+	if factory.Telemetry != nil {
+		return factory.Telemetry
 	}
 
+	configuration := factory.Configuration
+
 	telemetryInstancesLock.Lock()
 	defer telemetryInstancesLock.Unlock()
 
@@ -84,6 +168,15 @@ func Get(factory TelemetryFactoryParameters) *Telemetry {
 	return TelemetryInstances[configuration]
 }
 
+// Unregister removes the instance registered by Get for configuration, e.g. once a short-lived client using it is done,
+// so that the registered instances do not grow with the configurations
+func Unregister(configuration *Configuration) {
+	telemetryInstancesLock.Lock()
+	defer telemetryInstancesLock.Unlock()
+
+	delete(TelemetryInstances, configuration)
+}
+
 func Bind(ctx context.Context, instance *Telemetry) context.Context {
 	return context.WithValue(ctx, TelemetryContext, instance)
 }
@@ -108,14 +201,58 @@ func GetMetrics(factory TelemetryFactoryParameters) MetricsInterface {
 	return Get(factory).Metrics
 }
 
+func GetTraces(factory TelemetryFactoryParameters) *Traces {
+	return Get(factory).Traces
+}
+
 func CredentialsRequestMetric(factory CredentialsRequestMetricParameters) (metric.Int64Counter, error) {
-	return GetMetrics(TelemetryFactoryParameters{Configuration: factory.Configuration}).CredentialsRequest(factory.Value, factory.Attrs)
+	return GetMetrics(factory.TelemetryFactoryParameters).CredentialsRequest(factory.Value, factory.Attrs)
 }
 
 func RequestDurationMetric(factory RequestDurationMetricParameters) (metric.Float64Histogram, error) {
-	return GetMetrics(TelemetryFactoryParameters{Configuration: factory.Configuration}).RequestDuration(factory.Value, factory.Attrs)
+	return GetMetrics(factory.TelemetryFactoryParameters).RequestDuration(factory.Value, factory.Attrs)
 }
 
 func QueryDurationMetric(factory QueryDurationMetricParameters) (metric.Float64Histogram, error) {
-	return GetMetrics(TelemetryFactoryParameters{Configuration: factory.Configuration}).QueryDuration(factory.Value, factory.Attrs)
+	return GetMetrics(factory.TelemetryFactoryParameters).QueryDuration(factory.Value, factory.Attrs)
+}
+
+func CheckCacheHitMetric(factory CheckCacheMetricParameters) (metric.Int64Counter, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).CheckCacheHit(factory.Value, factory.Attrs)
+}
+
+func CheckCacheMissMetric(factory CheckCacheMetricParameters) (metric.Int64Counter, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).CheckCacheMiss(factory.Value, factory.Attrs)
+}
+
+func CircuitBreakerStateChangeMetric(factory CircuitBreakerStateChangeMetricParameters) (metric.Int64Counter, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).CircuitBreakerStateChange(factory.Value, factory.Attrs)
+}
+
+func HedgedRequestMetric(factory HedgedRequestMetricParameters) (metric.Int64Counter, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).RequestHedged(factory.Value, factory.Attrs)
+}
+
+func RequestRetryMetric(factory RequestRetryMetricParameters) (metric.Int64Counter, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).RequestRetry(factory.Value, factory.Attrs)
+}
+
+func RequestsInFlightMetric(factory RequestsInFlightMetricParameters) (metric.Int64UpDownCounter, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).RequestsInFlight(factory.Value, factory.Attrs)
+}
+
+func BatchCheckSizeMetric(factory BatchCheckSizeMetricParameters) (metric.Float64Histogram, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).BatchCheckSize(factory.Value, factory.Attrs)
+}
+
+func WriteTuplesMetric(factory WriteTuplesMetricParameters) (metric.Float64Histogram, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).WriteTuples(factory.Value, factory.Attrs)
+}
+
+func StreamObjectsMetric(factory StreamMetricParameters) (metric.Float64Histogram, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).StreamObjects(factory.Value, factory.Attrs)
+}
+
+func StreamFirstResultMetric(factory StreamMetricParameters) (metric.Float64Histogram, error) {
+	return GetMetrics(factory.TelemetryFactoryParameters).StreamFirstResult(factory.Value, factory.Attrs)
 }
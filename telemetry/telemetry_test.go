@@ -8,6 +8,10 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // Mock implementation of MetricsInterface
@@ -49,6 +53,59 @@ func (m *MockMetrics) QueryDuration(value float64, attrs map[*Attribute]string)
 	return histogram, nil
 }
 
+func (m *MockMetrics) CheckCacheHit(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	counter, _ := m.GetCounter("check_cache_hit", "A check cache hit")
+	return counter, nil
+}
+
+func (m *MockMetrics) CheckCacheMiss(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	counter, _ := m.GetCounter("check_cache_miss", "A check cache miss")
+	return counter, nil
+}
+
+func (m *MockMetrics) CircuitBreakerStateChange(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	counter, _ := m.GetCounter("circuit_breaker_state_change", "A circuit breaker state change")
+	return counter, nil
+}
+
+func (m *MockMetrics) RequestHedged(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	counter, _ := m.GetCounter("request_hedged", "A hedged request")
+	return counter, nil
+}
+
+func (m *MockMetrics) GetUpDownCounter(name string, description string) (metric.Int64UpDownCounter, error) {
+	return &MockInt64UpDownCounter{}, nil
+}
+
+func (m *MockMetrics) RequestRetry(value int64, attrs map[*Attribute]string) (metric.Int64Counter, error) {
+	counter, _ := m.GetCounter("request_retry", "A retry")
+	return counter, nil
+}
+
+func (m *MockMetrics) RequestsInFlight(value int64, attrs map[*Attribute]string) (metric.Int64UpDownCounter, error) {
+	return m.GetUpDownCounter("request_in_flight", "The requests in flight")
+}
+
+func (m *MockMetrics) BatchCheckSize(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error) {
+	histogram, _ := m.GetHistogram("batch_check_size", "A batch check size", "{check}")
+	return histogram, nil
+}
+
+func (m *MockMetrics) WriteTuples(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error) {
+	histogram, _ := m.GetHistogram("write_tuples", "A number of tuples written", "{tuple}")
+	return histogram, nil
+}
+
+func (m *MockMetrics) StreamObjects(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error) {
+	histogram, _ := m.GetHistogram("stream_objects", "A number of objects streamed", "{object}")
+	return histogram, nil
+}
+
+func (m *MockMetrics) StreamFirstResult(value float64, attrs map[*Attribute]string) (metric.Float64Histogram, error) {
+	histogram, _ := m.GetHistogram("stream_first_result", "A time to the first result", "ms")
+	return histogram, nil
+}
+
 func (m *MockMetrics) BuildTelemetryAttributes(requestMethod string, methodParameters map[string]interface{}, req *http.Request, res *http.Response, requestStarted time.Time, resendCount int) (map[*Attribute]string, float64, float64, error) {
 	attrs := map[*Attribute]string{
 		HTTPRequestMethod: requestMethod,
@@ -247,3 +304,96 @@ func TestGetRace(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestConfigureProviders(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	recorder := tracetest.NewSpanRecorder()
+	config := DefaultTelemetryConfiguration()
+	config.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	config.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	telemetry, err := Configure(config)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if _, err := telemetry.Metrics.CredentialsRequest(1, map[*Attribute]string{}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	_, span := telemetry.Traces.StartClientMethod(context.Background(), "Check", map[*Attribute]string{})
+	span.End(nil)
+
+	var resourceMetrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &resourceMetrics); err != nil {
+		t.Fatalf("failed to collect the metrics: %v", err)
+	}
+	if len(resourceMetrics.ScopeMetrics) != 1 || resourceMetrics.ScopeMetrics[0].Scope.Name != InstrumentationName {
+		t.Fatalf("Expected the metrics to be recorded by the meter provider of the configuration, got %v", resourceMetrics.ScopeMetrics)
+	}
+	if spans := recorder.Ended(); len(spans) != 1 || spans[0].InstrumentationScope().Name != InstrumentationName {
+		t.Fatalf("Expected the span to be started by the tracer provider of the configuration, got %v", spans)
+	}
+}
+
+func TestConfigureMetricsFactory(t *testing.T) {
+	metrics := &Metrics{}
+	var configured *MetricsConfiguration
+	config := DefaultTelemetryConfiguration()
+	config.MetricsFactory = func(configuration *MetricsConfiguration) MetricsInterface {
+		configured = configuration
+		return metrics
+	}
+
+	telemetry, _ := Configure(config)
+	if telemetry.Metrics != metrics || configured != config.Metrics {
+		t.Fatalf("Expected the metrics of the factory, created from the configuration of the metrics")
+	}
+
+	if telemetry, _ := Configure(&Configuration{}); telemetry.Metrics.(*Metrics).Meter == nil {
+		t.Fatalf("Expected the OpenTelemetry metrics without a factory")
+	}
+}
+
+func TestConfigureIsNotRegistered(t *testing.T) {
+	config := &Configuration{}
+
+	first, _ := Configure(config)
+	second, _ := Configure(config)
+	if first == second || first == Get(TelemetryFactoryParameters{Configuration: config}) {
+		t.Fatalf("Expected each configured instance to be owned by its caller")
+	}
+	Unregister(config)
+
+	if telemetry, _ := Configure(nil); telemetry.Configuration == nil || telemetry.Configuration.Metrics == nil {
+		t.Fatalf("Expected the default configuration without configuration")
+	}
+}
+
+func TestGetInstance(t *testing.T) {
+	instance := &Telemetry{}
+	if Get(TelemetryFactoryParameters{Configuration: &Configuration{}, Telemetry: instance}) != instance {
+		t.Fatalf("Expected the instance of the parameters")
+	}
+
+	if Get(TelemetryFactoryParameters{}) != Get(TelemetryFactoryParameters{}) {
+		t.Fatalf("Expected the instances without configuration to be shared")
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	config := &Configuration{}
+	registered := Get(TelemetryFactoryParameters{Configuration: config})
+
+	Unregister(config)
+
+	telemetryInstancesLock.Lock()
+	_, exists := TelemetryInstances[config]
+	telemetryInstancesLock.Unlock()
+	if exists {
+		t.Fatalf("Expected the instance to be unregistered")
+	}
+	if Get(TelemetryFactoryParameters{Configuration: config}) == registered {
+		t.Fatalf("Expected a new instance once unregistered")
+	}
+	Unregister(config)
+}
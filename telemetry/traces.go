@@ -0,0 +1,144 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SPAN_NAME_CREDENTIALS_REQUEST = "fga-client.credentials.request"
+
+type Traces struct {
+	Tracer        trace.Tracer
+	Configuration *TracesConfiguration
+}
+
+// Span is a span of the SDK, which only records the attributes enabled in its configuration. A nil Span, returned
+// when the span is disabled, ignores every call.
+type Span struct {
+	span          trace.Span
+	configuration *SpanConfiguration
+}
+
+// StartClientMethod starts the span of a method of the SDK client, e.g. ClientBatchCheck or Write, which is the parent
+// of the spans of the requests sent by the method
+func (t *Traces) StartClientMethod(ctx context.Context, method string, attrs map[*Attribute]string) (context.Context, *Span) {
+	if t == nil || t.Configuration == nil {
+		return ctx, nil
+	}
+
+	// the attributes of the caller are left untouched
+	spanAttrs := make(map[*Attribute]string, len(attrs)+1)
+	for attr, value := range attrs {
+		spanAttrs[attr] = value
+	}
+	spanAttrs[FGAClientRequestMethod] = method
+
+	return t.start(ctx, method, trace.SpanKindInternal, t.Configuration.SPAN_CLIENT_METHOD, spanAttrs)
+}
+
+// StartRequest starts the span of an attempt of an HTTP request of an API method, resendCount being 0 for the first
+// attempt
+func (t *Traces) StartRequest(ctx context.Context, httpMethod string, requestMethod string, resendCount int) (context.Context, *Span) {
+	if t == nil || t.Configuration == nil {
+		return ctx, nil
+	}
+
+	attrs := map[*Attribute]string{
+		FGAClientRequestMethod: requestMethod,
+		HTTPRequestMethod:      httpMethod,
+		HTTPRequestResendCount: strconv.Itoa(resendCount),
+	}
+
+	return t.start(ctx, fmt.Sprintf("%s %s", httpMethod, requestMethod), trace.SpanKindClient, t.Configuration.SPAN_HTTP_REQUEST, attrs)
+}
+
+// StartCredentialsRequest starts the span of the acquisition of an access token in the client credentials flow,
+// including its retries
+func (t *Traces) StartCredentialsRequest(ctx context.Context, attrs map[*Attribute]string) (context.Context, *Span) {
+	if t == nil || t.Configuration == nil {
+		return ctx, nil
+	}
+
+	return t.start(ctx, SPAN_NAME_CREDENTIALS_REQUEST, trace.SpanKindClient, t.Configuration.SPAN_CREDENTIALS_REQUEST, attrs)
+}
+
+// Inject propagates the trace context of ctx on the headers of an outgoing request, in the W3C Trace Context format
+func (t *Traces) Inject(ctx context.Context, header http.Header) {
+	if t == nil || t.Configuration == nil {
+		return
+	}
+
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+func (t *Traces) start(ctx context.Context, name string, kind trace.SpanKind, configuration *SpanConfiguration, attrs map[*Attribute]string) (context.Context, *Span) {
+	if configuration == nil || t.Tracer == nil {
+		return ctx, nil
+	}
+
+	ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(kind))
+	result := &Span{span: span, configuration: configuration}
+	result.SetAttributes(attrs)
+
+	return ctx, result
+}
+
+// SetAttributes records the enabled attributes on the span
+func (s *Span) SetAttributes(attrs map[*Attribute]string) {
+	if s == nil {
+		return
+	}
+
+	var prepared []attribute.KeyValue
+	for attr, value := range attrs {
+		if attr == nil || !s.configuration.allows(attr) {
+			continue
+		}
+
+		prepared = append(prepared, attribute.String(attr.Name, value))
+	}
+
+	s.span.SetAttributes(prepared...)
+}
+
+// SetRequestAttributes records the attributes of a request sent with the parameters of an API method
+func (s *Span) SetRequestAttributes(req *http.Request, params map[string]interface{}) {
+	if s == nil || req == nil {
+		return
+	}
+
+	s.SetAttributes(attributesFromRequest(req, params))
+}
+
+// SetResponseAttributes records the attributes of a response, including the fga-query-duration-ms header, and marks
+// the span as failed when the status code is an error
+func (s *Span) SetResponseAttributes(res *http.Response) {
+	if s == nil || res == nil {
+		return
+	}
+
+	s.SetAttributes(attributesFromResponse(res, map[*Attribute]string{}))
+	if res.StatusCode >= http.StatusBadRequest {
+		s.span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+}
+
+// End ends the span, recording err when it is not nil
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
@@ -0,0 +1,168 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTraces(configuration *TracesConfiguration) (*Traces, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	return &Traces{Tracer: provider.Tracer("openfga-sdk"), Configuration: configuration}, recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[string]string {
+	attrs := make(map[string]string)
+	for _, attr := range span.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.AsString()
+	}
+
+	return attrs
+}
+
+func TestTracesStartClientMethod(t *testing.T) {
+	traces, recorder := newTestTraces(&TracesConfiguration{
+		SPAN_CLIENT_METHOD: &SpanConfiguration{
+			ATTR_HTTP_REQUEST_METHOD:         &AttributeConfiguration{Enabled: true},
+			ATTR_FGA_CLIENT_REQUEST_STORE_ID: &AttributeConfiguration{Enabled: false},
+		},
+	})
+
+	methodAttrs := map[*Attribute]string{
+		FGAClientRequestStoreID: "01GXSB9YR785C4FYS3C0RTG7B2",
+	}
+	ctx, span := traces.StartClientMethod(context.Background(), "ClientBatchCheck", methodAttrs)
+	_, child := traces.StartClientMethod(ctx, "Check", nil)
+	child.End(nil)
+	if _, ok := methodAttrs[FGAClientRequestMethod]; ok || len(methodAttrs) != 1 {
+		t.Fatalf("Expected the attributes of the caller to be left untouched, got %v", methodAttrs)
+	}
+	span.End(errors.New("failed"))
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(ended))
+	}
+	if ended[0].Parent().SpanID() != ended[1].SpanContext().SpanID() {
+		t.Fatalf("Expected the span of Check to be a child of the span of ClientBatchCheck")
+	}
+
+	parent := ended[1]
+	if parent.Name() != "ClientBatchCheck" || parent.SpanKind() != trace.SpanKindInternal {
+		t.Fatalf("Expected an internal span named ClientBatchCheck, got %s (%s)", parent.Name(), parent.SpanKind())
+	}
+	attrs := spanAttributes(parent)
+	if attrs[ATTR_FGA_CLIENT_REQUEST_METHOD] != "ClientBatchCheck" {
+		t.Fatalf("Expected the method attribute, got %v", attrs)
+	}
+	if _, ok := attrs[ATTR_FGA_CLIENT_REQUEST_STORE_ID]; ok {
+		t.Fatalf("Expected the disabled store id attribute not to be recorded, got %v", attrs)
+	}
+	if parent.Status().Code != codes.Error || len(parent.Events()) != 1 {
+		t.Fatalf("Expected the error to be recorded, got %v", parent.Status())
+	}
+}
+
+func TestTracesStartRequest(t *testing.T) {
+	traces, recorder := newTestTraces(DefaultTelemetryConfiguration().Traces)
+
+	ctx, span := traces.StartRequest(context.Background(), http.MethodPost, "Check", 1)
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Scheme: "https", Host: "api.fga.example", Path: "/stores/01GXSB9YR785C4FYS3C0RTG7B2/check"},
+		Header: http.Header{},
+	}
+	traces.Inject(ctx, req.Header)
+	span.SetRequestAttributes(req, map[string]interface{}{"storeId": "01GXSB9YR785C4FYS3C0RTG7B2"})
+	span.SetResponseAttributes(&http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Fga-Query-Duration-Ms": []string{"12"}},
+	})
+	span.End(nil)
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(ended))
+	}
+	request := ended[0]
+	if request.Name() != "POST Check" || request.SpanKind() != trace.SpanKindClient {
+		t.Fatalf("Expected a client span named POST Check, got %s (%s)", request.Name(), request.SpanKind())
+	}
+	if request.Status().Code != codes.Error {
+		t.Fatalf("Expected the span of a failed response to be an error, got %v", request.Status())
+	}
+
+	expected := map[string]string{
+		ATTR_FGA_CLIENT_REQUEST_METHOD:    "Check",
+		ATTR_FGA_CLIENT_REQUEST_STORE_ID:  "01GXSB9YR785C4FYS3C0RTG7B2",
+		ATTR_HTTP_REQUEST_RESEND_COUNT:    "1",
+		ATTR_HTTP_RESPONSE_STATUS_CODE:    "503",
+		ATTR_HTTP_SERVER_REQUEST_DURATION: "12",
+		ATTR_HTTP_HOST:                    "api.fga.example",
+	}
+	attrs := spanAttributes(request)
+	for name, value := range expected {
+		if attrs[name] != value {
+			t.Errorf("Expected %s to be %q, got %q", name, value, attrs[name])
+		}
+	}
+
+	traceparent := req.Header.Get("traceparent")
+	if traceparent != "00-"+request.SpanContext().TraceID().String()+"-"+request.SpanContext().SpanID().String()+"-01" {
+		t.Fatalf("Expected the trace context of the span to be propagated, got %q", traceparent)
+	}
+}
+
+func TestTracesDisabled(t *testing.T) {
+	traces, recorder := newTestTraces(&TracesConfiguration{})
+
+	ctx, span := traces.StartRequest(context.Background(), http.MethodPost, "Check", 0)
+	if span != nil || ctx != context.Background() {
+		t.Fatalf("Expected no span when it is not configured")
+	}
+	span.SetAttributes(map[*Attribute]string{HTTPHost: "api.fga.example"})
+	span.End(errors.New("failed"))
+
+	var disabled *Traces
+	_, span = disabled.StartCredentialsRequest(context.Background(), map[*Attribute]string{})
+	span.End(nil)
+
+	header := http.Header{}
+	parent, _ := newTestTraces(&TracesConfiguration{})
+	parentCtx, parentSpan := parent.Tracer.Start(context.Background(), "parent")
+	defer parentSpan.End()
+	disabled.Inject(parentCtx, header)
+	if header.Get("traceparent") != "" {
+		t.Fatalf("Expected no trace context to be propagated when tracing is disabled")
+	}
+	traces.Inject(parentCtx, header)
+	if header.Get("traceparent") == "" {
+		t.Fatalf("Expected the trace context of the caller to be propagated")
+	}
+
+	if len(recorder.Ended()) != 0 {
+		t.Fatalf("Expected no span, got %d", len(recorder.Ended()))
+	}
+}
+
+func TestSpanConfigurationAllows(t *testing.T) {
+	configuration := &SpanConfiguration{ATTR_HTTP_HOST: &AttributeConfiguration{Enabled: true}}
+	if !configuration.allows(HTTPHost) {
+		t.Errorf("Expected an enabled attribute to be allowed")
+	}
+	if configuration.allows(FGAClientUser) {
+		t.Errorf("Expected an attribute without configuration not to be allowed")
+	}
+	if !configuration.allows(&Attribute{Name: "custom"}) {
+		t.Errorf("Expected an attribute which cannot be configured to be allowed")
+	}
+}
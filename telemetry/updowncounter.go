@@ -0,0 +1,14 @@
+package telemetry
+
+type UpDownCounter struct {
+	Name        string
+	Description string
+}
+
+func (m *UpDownCounter) GetName() string {
+	return m.Name
+}
+
+func (m *UpDownCounter) GetDescription() string {
+	return m.Description
+}
@@ -0,0 +1,23 @@
+package telemetry
+
+import (
+	"testing"
+)
+
+func TestUpDownCounterCreation(t *testing.T) {
+	counterName := "test-up-down-counter"
+	counterDescription := "This is a test up-down counter."
+
+	counter := &UpDownCounter{
+		Name:        counterName,
+		Description: counterDescription,
+	}
+
+	if counter.GetName() != counterName {
+		t.Errorf("Expected UpDownCounter Name to be '%s', but got '%s'", counterName, counter.GetName())
+	}
+
+	if counter.GetDescription() != counterDescription {
+		t.Errorf("Expected UpDownCounter Description to be '%s', but got '%s'", counterDescription, counter.GetDescription())
+	}
+}
@@ -0,0 +1,12 @@
+package telemetry
+
+const (
+	METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT string = "fga-client.request.in_flight"
+)
+
+var (
+	RequestsInFlight = &UpDownCounter{
+		Name:        METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT,
+		Description: "The number of requests sent to the API which have not received their response yet.",
+	}
+)
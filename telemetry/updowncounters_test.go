@@ -0,0 +1,15 @@
+package telemetry
+
+import (
+	"testing"
+)
+
+func TestRequestsInFlightUpDownCounter(t *testing.T) {
+	if RequestsInFlight.GetName() != METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT {
+		t.Errorf("Expected Name to be '%s', but got '%s'", METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT, RequestsInFlight.GetName())
+	}
+
+	if RequestsInFlight.GetDescription() == "" {
+		t.Errorf("Expected up-down counter %s to have a description", METRIC_UP_DOWN_COUNTER_REQUESTS_IN_FLIGHT)
+	}
+}
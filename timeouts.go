@@ -0,0 +1,94 @@
+package openfga
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/openfga/go-sdk/internal/constants"
+)
+
+// OperationTimeouts overrides the timeouts of the client for an operation. A field left to 0 keeps the value of the
+// client.
+type OperationTimeouts struct {
+	AttemptTimeoutInMs int `json:"attempt_timeout_in_ms,omitempty"`
+	DeadlineInMs       int `json:"deadline_in_ms,omitempty"`
+}
+
+// TimeoutConfiguration bounds each attempt of a request and the request as a whole, on top of the deadline of the
+// context of the call
+type TimeoutConfiguration struct {
+	// AttemptTimeoutInMs bounds each attempt of a request, from waiting for the rate limiter to reading the response
+	// (default = 10000). For a streamed request, it bounds the time until the stream starts.
+	AttemptTimeoutInMs int `json:"attempt_timeout_in_ms,omitempty"`
+	// DeadlineInMs bounds a request including its retries and the waits between them, unlimited when 0. A retry
+	// which would wait past the deadline is not attempted. For a streamed request, it bounds the whole stream.
+	DeadlineInMs int `json:"deadline_in_ms,omitempty"`
+	// ConnectionTimeoutInMs bounds establishing a connection to the API or to the token issuer (default = 10000). It
+	// only applies to the HTTP client created by the SDK, i.e. when Configuration.HTTPClient is not set.
+	ConnectionTimeoutInMs int `json:"connection_timeout_in_ms,omitempty"`
+	// Operations overrides the timeouts by operation name, e.g. "Check" or "StreamedListObjects"
+	Operations map[string]OperationTimeouts `json:"operations,omitempty"`
+}
+
+// Validate ensures that the timeout configuration is valid
+func (c *TimeoutConfiguration) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.AttemptTimeoutInMs < 0 || c.DeadlineInMs < 0 || c.ConnectionTimeoutInMs < 0 {
+		return reportError("Timeouts cannot be negative")
+	}
+
+	for operationName, timeouts := range c.Operations {
+		if timeouts.AttemptTimeoutInMs < 0 || timeouts.DeadlineInMs < 0 {
+			return reportError("Timeouts.Operations[%s] cannot be negative", operationName)
+		}
+	}
+
+	return nil
+}
+
+// timeouts returns the attempt timeout and the deadline of an operation, 0 when they are not set
+func (c *TimeoutConfiguration) timeouts(operationName string) (time.Duration, time.Duration) {
+	if c == nil {
+		return 0, 0
+	}
+
+	attemptTimeoutInMs, deadlineInMs := c.AttemptTimeoutInMs, c.DeadlineInMs
+	if attemptTimeoutInMs == 0 {
+		attemptTimeoutInMs = constants.DefaultRequestTimeoutInMs
+	}
+	if overrides, ok := c.Operations[operationName]; ok {
+		if overrides.AttemptTimeoutInMs > 0 {
+			attemptTimeoutInMs = overrides.AttemptTimeoutInMs
+		}
+		if overrides.DeadlineInMs > 0 {
+			deadlineInMs = overrides.DeadlineInMs
+		}
+	}
+
+	return time.Duration(attemptTimeoutInMs) * time.Millisecond, time.Duration(deadlineInMs) * time.Millisecond
+}
+
+// httpClient returns the HTTP client bounding the time to connect with the connection timeout, or http.DefaultClient
+// when the timeouts are not configured
+func (c *TimeoutConfiguration) httpClient() *http.Client {
+	if c == nil {
+		return http.DefaultClient
+	}
+
+	connectionTimeoutInMs := c.ConnectionTimeoutInMs
+	if connectionTimeoutInMs == 0 {
+		connectionTimeoutInMs = constants.DefaultConnectionTimeoutInMs
+	}
+	dialer := &net.Dialer{
+		Timeout:   time.Duration(connectionTimeoutInMs) * time.Millisecond,
+		KeepAlive: 30 * time.Second,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,194 @@
+package openfga
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openfga/go-sdk/credentials"
+)
+
+func TestTimeoutConfigurationValidate(t *testing.T) {
+	invalid := map[string]TimeoutConfiguration{
+		"negative attempt timeout":    {AttemptTimeoutInMs: -1},
+		"negative deadline":           {DeadlineInMs: -1},
+		"negative connection timeout": {ConnectionTimeoutInMs: -1},
+		"negative operation deadline": {Operations: map[string]OperationTimeouts{"Check": {DeadlineInMs: -1}}},
+	}
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080", Timeouts: &config})
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestTimeoutConfigurationTimeouts(t *testing.T) {
+	var disabled *TimeoutConfiguration
+	if attemptTimeout, deadline := disabled.timeouts("Check"); attemptTimeout != 0 || deadline != 0 {
+		t.Fatalf("Expected no timeouts when they are not configured, got %v and %v", attemptTimeout, deadline)
+	}
+
+	config := &TimeoutConfiguration{
+		DeadlineInMs: 30000,
+		Operations: map[string]OperationTimeouts{
+			"Check":               {AttemptTimeoutInMs: 500, DeadlineInMs: 2000},
+			"StreamedListObjects": {DeadlineInMs: 120000},
+		},
+	}
+	timeouts := map[string][2]time.Duration{
+		"Check":               {500 * time.Millisecond, 2 * time.Second},
+		"StreamedListObjects": {10 * time.Second, 2 * time.Minute},
+		"Write":               {10 * time.Second, 30 * time.Second},
+	}
+	for operationName, expected := range timeouts {
+		if attemptTimeout, deadline := config.timeouts(operationName); attemptTimeout != expected[0] || deadline != expected[1] {
+			t.Errorf("Expected the timeouts of %s to be %v, got %v and %v", operationName, expected, attemptTimeout, deadline)
+		}
+	}
+}
+
+func TestTimeoutsConnectionTimeout(t *testing.T) {
+	clients := map[string]*Configuration{
+		"no credentials": {ApiUrl: "http://localhost:8080"},
+		"api token": {ApiUrl: "http://localhost:8080", Credentials: &credentials.Credentials{
+			Method: credentials.CredentialsMethodApiToken,
+			Config: &credentials.Config{ApiToken: "token"},
+		}},
+	}
+	for name, config := range clients {
+		t.Run(name, func(t *testing.T) {
+			config.Timeouts = &TimeoutConfiguration{ConnectionTimeoutInMs: 500}
+			cfg, err := NewConfiguration(*config)
+			if err != nil {
+				t.Fatalf("failed to create configuration: %v", err)
+			}
+
+			NewAPIClient(cfg)
+			if cfg.HTTPClient == http.DefaultClient {
+				t.Fatalf("Expected a client bounding the time to connect, got the default client")
+			}
+			if _, ok := cfg.HTTPClient.Transport.(*http.Transport); !ok {
+				t.Fatalf("Expected the transport of the client to dial with the connection timeout, got %T", cfg.HTTPClient.Transport)
+			}
+		})
+	}
+
+	cfg, err := NewConfiguration(Configuration{ApiUrl: "http://localhost:8080"})
+	if err != nil {
+		t.Fatalf("failed to create configuration: %v", err)
+	}
+	NewAPIClient(cfg)
+	if cfg.HTTPClient != http.DefaultClient {
+		t.Fatalf("Expected the default client when the timeouts are not configured")
+	}
+}
+
+// withTimeouts configures the timeouts of a test client
+func withTimeouts(timeouts *TimeoutConfiguration) func(*Configuration) {
+	return func(cfg *Configuration) {
+		cfg.Timeouts = timeouts
+	}
+}
+
+func TestTimeoutsAttemptTimeout(t *testing.T) {
+	var attempts int32
+	apiClient := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			_, _ = io.Copy(io.Discard, r.Body)
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"allowed":true}`))
+	}, withTimeouts(&TimeoutConfiguration{Operations: map[string]OperationTimeouts{"Check": {AttemptTimeoutInMs: 50}}}))
+
+	started := time.Now()
+	response, err := checkWith(apiClient)
+	if err != nil {
+		t.Fatalf("Expected the attempt which timed out to be retried, got %v", err)
+	}
+	if !response.GetAllowed() {
+		t.Fatalf("Expected the response of the second attempt")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", got)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Expected the first attempt to time out after 50ms, took %v", elapsed)
+	}
+}
+
+func TestTimeoutsDeadlineCapsRetries(t *testing.T) {
+	var attempts int32
+	apiClient := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"code":"internal_error","message":"unavailable"}`))
+	}, withTimeouts(&TimeoutConfiguration{DeadlineInMs: 300}))
+
+	started := time.Now()
+	_, err := checkWith(apiClient)
+	var internalErr FgaApiInternalError
+	if !errors.As(err, &internalErr) {
+		t.Fatalf("Expected the error of the last attempt, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("Expected a retry waiting past the deadline not to be attempted, got %d attempts", got)
+	}
+	if elapsed := time.Since(started); elapsed > 200*time.Millisecond {
+		t.Fatalf("Expected the request not to wait for the retry, took %v", elapsed)
+	}
+}
+
+func TestTimeoutsStreamedListObjects(t *testing.T) {
+	var attempts int32
+	apiClient := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		// the stream outlives the attempt timeout once it started
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"result":{"object":"document:1"}}`))
+	}, withTimeouts(&TimeoutConfiguration{Operations: map[string]OperationTimeouts{"StreamedListObjects": {AttemptTimeoutInMs: 50}}}))
+	request := ListObjectsRequest{Type: "document", Relation: "viewer", User: "user:anne"}
+
+	_, err := ExecuteStreamedListObjects(apiClient, context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2", request, RequestOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the stream not to start within the attempt timeout, got %v", err)
+	}
+
+	channel, err := ExecuteStreamedListObjects(apiClient, context.Background(), "01GXSB9YR785C4FYS3C0RTG7B2", request, RequestOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer channel.Close()
+
+	var objects []string
+	for object := range channel.Objects {
+		objects = append(objects, object.Object)
+	}
+	if err := <-channel.Errors; err != nil {
+		t.Fatalf("Expected the stream to end without error, got %v", err)
+	}
+	if len(objects) != 1 {
+		t.Fatalf("Expected the object of the stream, got %v", objects)
+	}
+}
@@ -0,0 +1,76 @@
+package tupleio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+)
+
+// ExportOptions configures Export
+type ExportOptions struct {
+	client.RequestOptions
+
+	StoreId     *string
+	Consistency *fgaSdk.ConsistencyPreference
+	// Filter limits the export to the tuples matching it. Defaults to every tuple of the store.
+	Filter client.ClientReadRequest
+	// PageSize is the number of tuples read per request
+	PageSize *int32
+	// Progress, when set, is called after every page with the number of tuples exported so far
+	Progress func(exported int)
+}
+
+// TupleReader reads the tuples of a store, following the continuation tokens, as client.OpenFgaClient does
+type TupleReader interface {
+	ReadAll(ctx context.Context, body client.ClientReadRequest, options client.ClientReadAllOptions) iter.Seq2[fgaSdk.Tuple, error]
+}
+
+// Export writes the tuples of a store to w in the format, reading them with ReadAll until every tuple is written. It
+// returns the number of tuples exported.
+func Export(ctx context.Context, fgaClient TupleReader, w io.Writer, format Format, options ExportOptions) (int, error) {
+	encoder, err := NewEncoder(w, format)
+	if err != nil {
+		return 0, err
+	}
+
+	exported := 0
+	cursor := client.ClientPaginationCursor{}
+	tuples := fgaClient.ReadAll(ctx, options.Filter, client.ClientReadAllOptions{
+		ClientReadOptions: client.ClientReadOptions{
+			RequestOptions: options.RequestOptions,
+			StoreId:        options.StoreId,
+			PageSize:       options.PageSize,
+			Consistency:    options.Consistency,
+		},
+		ClientIteratorOptions: client.ClientIteratorOptions{Cursor: &cursor},
+	})
+	pageToken := cursor.ContinuationToken
+	for tuple, err := range tuples {
+		if err != nil {
+			return exported, fmt.Errorf("reading tuples: %w", err)
+		}
+		// the cursor moves once a page was consumed
+		if cursor.ContinuationToken != pageToken {
+			pageToken = cursor.ContinuationToken
+			if options.Progress != nil {
+				options.Progress(exported)
+			}
+		}
+		if err := encoder.Encode(tuple.Key); err != nil {
+			return exported, fmt.Errorf("encoding tuple %d: %w", exported+1, err)
+		}
+		exported++
+	}
+	if options.Progress != nil {
+		options.Progress(exported)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return exported, err
+	}
+	return exported, nil
+}
@@ -0,0 +1,88 @@
+package tupleio
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	fgaSdk "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/openfga/go-sdk/client/fake"
+	"github.com/openfga/go-sdk/language"
+)
+
+func newFakeClient(t *testing.T, tuples []fgaSdk.TupleKey) *fake.Client {
+	t.Helper()
+
+	model, err := language.TransformDSLToModel(`model
+  schema 1.1
+
+type user
+
+type group
+  relations
+    define member: [user]
+
+type document
+  relations
+    define viewer: [user, group#member, user with in_office, group#member with in_office]
+
+condition in_office(floor: int, building: string) {
+  floor > 1
+}
+`)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	fgaClient, err := fake.NewClient(&fake.ClientOptions{AuthorizationModel: model, Tuples: tuples})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return fgaClient
+}
+
+func TestExport(t *testing.T) {
+	fgaClient := newFakeClient(t, testTuples)
+
+	var buffer bytes.Buffer
+	var progress []int
+	exported, err := Export(context.Background(), fgaClient, &buffer, FormatJSONL, ExportOptions{
+		PageSize: fgaSdk.ToPtr(int32(2)),
+		Progress: func(exported int) { progress = append(progress, exported) },
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if exported != 3 || !reflect.DeepEqual(progress, []int{2, 3}) {
+		t.Fatalf("Expected 3 tuples exported over two pages, got %d (%v)", exported, progress)
+	}
+	if tuples := decodeAll(t, &buffer, FormatJSONL); !reflect.DeepEqual(tuples, testTuples) {
+		t.Fatalf("Expected %v, got %v", testTuples, tuples)
+	}
+}
+
+func TestExportFilter(t *testing.T) {
+	fgaClient := newFakeClient(t, testTuples)
+
+	var buffer bytes.Buffer
+	exported, err := Export(context.Background(), fgaClient, &buffer, FormatCSV, ExportOptions{
+		Filter: client.ClientReadRequest{User: fgaSdk.ToPtr("user:anne"), Object: fgaSdk.ToPtr("document:")},
+	})
+	if err != nil || exported != 1 {
+		t.Fatalf("Expected 1 tuple exported, got %d (%v)", exported, err)
+	}
+	expected := "user,relation,object,condition_name,condition_context\nuser:anne,viewer,document:1,,\n"
+	if buffer.String() != expected {
+		t.Fatalf("Expected %q, got %q", expected, buffer.String())
+	}
+}
+
+func TestExportReadError(t *testing.T) {
+	fgaClient := newFakeClient(t, nil)
+
+	_, err := Export(context.Background(), fgaClient, &bytes.Buffer{}, FormatJSON, ExportOptions{StoreId: fgaSdk.ToPtr("01ARZ3NDEKTSV4RRFFQ69G5FAV")})
+	if err == nil {
+		t.Fatalf("Expected an error for a missing store")
+	}
+}
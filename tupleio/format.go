@@ -0,0 +1,365 @@
+// Package tupleio exports the tuples of a store to JSON, JSONL, CSV and YAML, and imports them back.
+//
+// Export streams every tuple with paginated Read calls, and Import streams records into chunked, parallel
+// non-transactional Write calls, reporting progress and the tuples which failed to be written.
+package tupleio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	fgaSdk "github.com/openfga/go-sdk"
+)
+
+// Format is the encoding of a tuple file
+type Format string
+
+const (
+	// FormatJSON is a JSON array of tuples
+	FormatJSON Format = "json"
+	// FormatJSONL is one JSON tuple per line
+	FormatJSONL Format = "jsonl"
+	// FormatCSV is a header row followed by one tuple per row. The condition context is a JSON object.
+	FormatCSV Format = "csv"
+	// FormatYAML is a YAML sequence of tuples
+	FormatYAML Format = "yaml"
+)
+
+// FormatFromPath returns the format matching the extension of a file path
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unsupported tuple file extension '%s'", filepath.Ext(path))
+	}
+}
+
+// csvHeader is the header written to CSV files
+var csvHeader = []string{"user", "relation", "object", "condition_name", "condition_context"}
+
+// Encoder writes tuples to a stream in a Format. Close must be called once every tuple is encoded; it does not close
+// the underlying writer.
+type Encoder interface {
+	Encode(tuple fgaSdk.TupleKey) error
+	Close() error
+}
+
+// NewEncoder returns an Encoder writing tuples to w in the format
+func NewEncoder(w io.Writer, format Format) (Encoder, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonEncoder{w: w}, nil
+	case FormatJSONL:
+		return &jsonlEncoder{encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	case FormatYAML:
+		return &yamlEncoder{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tuple format '%s'", format)
+	}
+}
+
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(tuple fgaSdk.TupleKey) error {
+	data, err := json.Marshal(tuple)
+	if err != nil {
+		return err
+	}
+	separator := ",\n  "
+	if e.count == 0 {
+		separator = "[\n  "
+	}
+	e.count++
+	_, err = io.WriteString(e.w, separator+string(data))
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+type jsonlEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *jsonlEncoder) Encode(tuple fgaSdk.TupleKey) error {
+	return e.encoder.Encode(tuple)
+}
+
+func (e *jsonlEncoder) Close() error {
+	return nil
+}
+
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvEncoder) Encode(tuple fgaSdk.TupleKey) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	conditionName, conditionContext := "", ""
+	if tuple.Condition != nil {
+		conditionName = tuple.Condition.Name
+		if len(tuple.Condition.GetContext()) > 0 {
+			data, err := json.Marshal(tuple.Condition.GetContext())
+			if err != nil {
+				return err
+			}
+			conditionContext = string(data)
+		}
+	}
+	return e.w.Write([]string{tuple.User, tuple.Relation, tuple.Object, conditionName, conditionContext})
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.w.Write(csvHeader)
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type yamlEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *yamlEncoder) Encode(tuple fgaSdk.TupleKey) error {
+	// a sequence of one tuple is an item of the sequence of every tuple, so items can be written as they come
+	data, err := yaml.Marshal([]fgaSdk.TupleKey{tuple})
+	if err != nil {
+		return err
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *yamlEncoder) Close() error {
+	if e.count > 0 {
+		return nil
+	}
+	_, err := io.WriteString(e.w, "[]\n")
+	return err
+}
+
+// Decoder reads tuples from a stream in a Format. Decode returns io.EOF once every tuple is read.
+type Decoder interface {
+	Decode() (fgaSdk.TupleKey, error)
+}
+
+// NewDecoder returns a Decoder reading tuples from r in the format. JSON, JSONL and CSV are decoded as they are read;
+// YAML is read in full on the first call to Decode.
+func NewDecoder(r io.Reader, format Format) (Decoder, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonDecoder{decoder: json.NewDecoder(r)}, nil
+	case FormatJSONL:
+		return &jsonlDecoder{decoder: json.NewDecoder(r)}, nil
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		return &csvDecoder{r: reader}, nil
+	case FormatYAML:
+		return &yamlDecoder{r: r}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tuple format '%s'", format)
+	}
+}
+
+type jsonDecoder struct {
+	decoder *json.Decoder
+	started bool
+	record  int
+}
+
+func (d *jsonDecoder) Decode() (fgaSdk.TupleKey, error) {
+	if !d.started {
+		token, err := d.decoder.Token()
+		if err == io.EOF {
+			return fgaSdk.TupleKey{}, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return fgaSdk.TupleKey{}, err
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return fgaSdk.TupleKey{}, errors.New("expected a JSON array of tuples")
+		}
+		d.started = true
+	}
+	if !d.decoder.More() {
+		if _, err := d.decoder.Token(); err != nil {
+			return fgaSdk.TupleKey{}, err
+		}
+		return fgaSdk.TupleKey{}, io.EOF
+	}
+
+	d.record++
+	var tuple fgaSdk.TupleKey
+	if err := d.decoder.Decode(&tuple); err != nil {
+		return fgaSdk.TupleKey{}, fmt.Errorf("tuple %d: %w", d.record, err)
+	}
+	return tuple, nil
+}
+
+type jsonlDecoder struct {
+	decoder *json.Decoder
+	record  int
+}
+
+func (d *jsonlDecoder) Decode() (fgaSdk.TupleKey, error) {
+	var tuple fgaSdk.TupleKey
+	err := d.decoder.Decode(&tuple)
+	if err == io.EOF {
+		return fgaSdk.TupleKey{}, io.EOF
+	}
+	d.record++
+	if err != nil {
+		return fgaSdk.TupleKey{}, fmt.Errorf("tuple %d: %w", d.record, err)
+	}
+	return tuple, nil
+}
+
+type csvDecoder struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+func (d *csvDecoder) Decode() (fgaSdk.TupleKey, error) {
+	if d.columns == nil {
+		header, err := d.r.Read()
+		if err == io.EOF {
+			return fgaSdk.TupleKey{}, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return fgaSdk.TupleKey{}, err
+		}
+		if err := d.readHeader(header); err != nil {
+			return fgaSdk.TupleKey{}, err
+		}
+	}
+
+	row, err := d.r.Read()
+	if err != nil {
+		return fgaSdk.TupleKey{}, err
+	}
+	line, _ := d.r.FieldPos(0)
+	tuple, err := d.parseRow(row)
+	if err != nil {
+		return fgaSdk.TupleKey{}, fmt.Errorf("line %d: %w", line, err)
+	}
+	return tuple, nil
+}
+
+// readHeader accepts the columns written by the encoder, and the user_type, user_id, user_relation, object_type and
+// object_id columns of the OpenFGA CLI as an alternative to user and object
+func (d *csvDecoder) readHeader(header []string) error {
+	d.columns = make(map[string]int, len(header))
+	for index, column := range header {
+		d.columns[strings.TrimSpace(column)] = index
+	}
+	_, hasUser := d.columns["user"]
+	_, hasUserId := d.columns["user_id"]
+	_, hasObject := d.columns["object"]
+	_, hasObjectId := d.columns["object_id"]
+	if _, ok := d.columns["relation"]; !ok || (!hasUser && !hasUserId) || (!hasObject && !hasObjectId) {
+		return errors.New("the CSV header needs user, relation and object columns")
+	}
+	return nil
+}
+
+func (d *csvDecoder) field(row []string, column string) string {
+	index, ok := d.columns[column]
+	if !ok || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+func (d *csvDecoder) parseRow(row []string) (fgaSdk.TupleKey, error) {
+	tuple := fgaSdk.TupleKey{
+		User:     d.field(row, "user"),
+		Relation: d.field(row, "relation"),
+		Object:   d.field(row, "object"),
+	}
+	if tuple.User == "" && d.field(row, "user_id") != "" {
+		tuple.User = d.field(row, "user_type") + ":" + d.field(row, "user_id")
+		if relation := d.field(row, "user_relation"); relation != "" {
+			tuple.User += "#" + relation
+		}
+	}
+	if tuple.Object == "" && d.field(row, "object_id") != "" {
+		tuple.Object = d.field(row, "object_type") + ":" + d.field(row, "object_id")
+	}
+
+	if name := d.field(row, "condition_name"); name != "" {
+		tuple.Condition = &fgaSdk.RelationshipCondition{Name: name}
+		if context := d.field(row, "condition_context"); context != "" {
+			var conditionContext map[string]interface{}
+			if err := json.Unmarshal([]byte(context), &conditionContext); err != nil {
+				return fgaSdk.TupleKey{}, fmt.Errorf("invalid condition context: %w", err)
+			}
+			tuple.Condition.Context = &conditionContext
+		}
+	}
+	return tuple, nil
+}
+
+type yamlDecoder struct {
+	r      io.Reader
+	tuples []fgaSdk.TupleKey
+	read   bool
+}
+
+func (d *yamlDecoder) Decode() (fgaSdk.TupleKey, error) {
+	if !d.read {
+		d.read = true
+		data, err := io.ReadAll(d.r)
+		if err != nil {
+			return fgaSdk.TupleKey{}, err
+		}
+		if err := yaml.Unmarshal(data, &d.tuples); err != nil {
+			return fgaSdk.TupleKey{}, err
+		}
+	}
+	if len(d.tuples) == 0 {
+		return fgaSdk.TupleKey{}, io.EOF
+	}
+	tuple := d.tuples[0]
+	d.tuples = d.tuples[1:]
+	return tuple, nil
+}
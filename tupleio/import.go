@@ -0,0 +1,159 @@
+package tupleio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/openfga/go-sdk/client"
+)
+
+// defaultBatchSize is the number of records read before they are written
+const defaultBatchSize = 1000
+
+// ImportOptions configures Import
+type ImportOptions struct {
+	client.RequestOptions
+
+	AuthorizationModelId *string
+	StoreId              *string
+	Conflict             client.ClientWriteConflictOptions
+	// Offset is the number of records to skip before importing. Pass the Offset of the last progress of an interrupted
+	// import to resume it.
+	Offset int
+	// BatchSize is the number of records read and written at a time (default = 1000). Batches are written one after
+	// another, so at most one batch is written twice when an import is resumed.
+	BatchSize int
+	// MaxPerChunk is the number of tuples per Write request (default = 1). A failed request fails every tuple in it, so
+	// the failures are only reported per tuple with the default.
+	MaxPerChunk int32
+	// MaxParallelRequests is the number of Write requests issued in parallel
+	MaxParallelRequests int32
+	// TransientFailureRetries is the number of times the requests which failed with a rate limit or a transient error
+	// are sent again
+	TransientFailureRetries int32
+	// Progress, when set, is called after every batch
+	Progress func(progress ImportProgress)
+}
+
+// ImportProgress reports how far an import went
+type ImportProgress struct {
+	// Offset is the number of records handled, including the skipped ones
+	Offset  int
+	Written int
+	Failed  int
+}
+
+// ImportFailure is a tuple the server did not write
+type ImportFailure struct {
+	// Record is the position of the tuple in the file, starting at 1
+	Record   int
+	TupleKey client.ClientTupleKey
+	Error    error
+	Category client.ClientWriteFailureCategory
+}
+
+// ImportResult is the outcome of an import
+type ImportResult struct {
+	// Offset is the number of records handled, including the skipped ones
+	Offset   int
+	Written  int
+	Failures []ImportFailure
+}
+
+// Import reads tuples from r in the format and writes them to a store with non-transactional writes. A tuple the server
+// rejects is reported in the failures of the result without stopping the import. Import stops on a malformed record, an
+// authentication error or when ctx is done, returning the result so far with the error; the import can then be resumed
+// from the Offset of the result.
+func Import(ctx context.Context, fgaClient client.SdkClient, r io.Reader, format Format, options ImportOptions) (*ImportResult, error) {
+	decoder, err := NewDecoder(r, format)
+	if err != nil {
+		return nil, err
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	result := &ImportResult{}
+	for result.Offset < options.Offset {
+		if _, err := decoder.Decode(); err != nil {
+			if err == io.EOF {
+				return result, nil
+			}
+			return result, err
+		}
+		result.Offset++
+	}
+
+	for {
+		batch := make([]client.ClientTupleKey, 0, batchSize)
+		var decodeErr error
+		for len(batch) < batchSize {
+			tuple, err := decoder.Decode()
+			if err != nil {
+				decodeErr = err
+				break
+			}
+			batch = append(batch, tuple)
+		}
+
+		if len(batch) > 0 {
+			if err := writeBatch(ctx, fgaClient, batch, result, options); err != nil {
+				return result, err
+			}
+			if options.Progress != nil {
+				options.Progress(ImportProgress{Offset: result.Offset, Written: result.Written, Failed: len(result.Failures)})
+			}
+		}
+
+		if decodeErr == io.EOF {
+			return result, nil
+		}
+		if decodeErr != nil {
+			return result, fmt.Errorf("tuple %d: %w", result.Offset+1, decodeErr)
+		}
+	}
+}
+
+func writeBatch(ctx context.Context, fgaClient client.SdkClient, batch []client.ClientTupleKey, result *ImportResult, options ImportOptions) error {
+	response, err := fgaClient.Write(ctx).Body(client.ClientWriteRequest{Writes: batch}).Options(client.ClientWriteOptions{
+		RequestOptions:       options.RequestOptions,
+		AuthorizationModelId: options.AuthorizationModelId,
+		StoreId:              options.StoreId,
+		Transaction: &client.TransactionOptions{
+			Disable:                 true,
+			MaxPerChunk:             options.MaxPerChunk,
+			MaxParallelRequests:     options.MaxParallelRequests,
+			TransientFailureRetries: options.TransientFailureRetries,
+		},
+		Conflict: options.Conflict,
+	}).Execute()
+	if err != nil {
+		return err
+	}
+	// the requests failed because ctx is done rather than because of their tuples, so the batch is left to be resumed
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if len(response.Writes) != len(batch) {
+		return errors.New("the write response does not match the written tuples")
+	}
+
+	// the responses are in the order of the writes
+	for index, write := range response.Writes {
+		if write.Status == client.SUCCESS {
+			result.Written++
+			continue
+		}
+		result.Failures = append(result.Failures, ImportFailure{
+			Record:   result.Offset + index + 1,
+			TupleKey: write.TupleKey,
+			Error:    write.Error,
+			Category: write.FailureCategory,
+		})
+	}
+	result.Offset += len(batch)
+	return nil
+}
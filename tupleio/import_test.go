@@ -0,0 +1,101 @@
+package tupleio
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/openfga/go-sdk/client"
+)
+
+const importData = `{"user": "user:anne", "relation": "viewer", "object": "document:1"}
+{"user": "user:beth", "relation": "owner", "object": "document:1"}
+{"user": "user:carl", "relation": "viewer", "object": "document:2", "condition": {"name": "in_office", "context": {"floor": 2}}}
+{"user": "user:dave", "relation": "viewer", "object": "document:3"}
+{"user": "user:erin", "relation": "viewer", "object": "document:4"}
+`
+
+func readStoreTuples(t *testing.T, fgaClient client.SdkClient) []string {
+	t.Helper()
+
+	response, err := fgaClient.Read(context.Background()).Body(client.ClientReadRequest{}).Execute()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	var tuples []string
+	for _, tuple := range response.Tuples {
+		tuples = append(tuples, tuple.Key.User+" "+tuple.Key.Object)
+	}
+	sort.Strings(tuples)
+	return tuples
+}
+
+func TestImport(t *testing.T) {
+	fgaClient := newFakeClient(t, nil)
+
+	var progress []ImportProgress
+	result, err := Import(context.Background(), fgaClient, strings.NewReader(importData), FormatJSONL, ImportOptions{
+		BatchSize:           2,
+		MaxParallelRequests: 2,
+		Progress:            func(p ImportProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if result.Offset != 5 || result.Written != 4 || len(result.Failures) != 1 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	failure := result.Failures[0]
+	if failure.Record != 2 || failure.TupleKey.User != "user:beth" || failure.Error == nil || failure.Category != client.CLIENT_WRITE_FAILURE_INVALID_TUPLE {
+		t.Fatalf("Unexpected failure %+v", failure)
+	}
+	expectedProgress := []ImportProgress{{Offset: 2, Written: 1, Failed: 1}, {Offset: 4, Written: 3, Failed: 1}, {Offset: 5, Written: 4, Failed: 1}}
+	if !reflect.DeepEqual(progress, expectedProgress) {
+		t.Fatalf("Expected progress %+v, got %+v", expectedProgress, progress)
+	}
+	if tuples := readStoreTuples(t, fgaClient); len(tuples) != 4 {
+		t.Fatalf("Expected 4 tuples in the store, got %v", tuples)
+	}
+}
+
+func TestImportResume(t *testing.T) {
+	fgaClient := newFakeClient(t, nil)
+
+	result, err := Import(context.Background(), fgaClient, strings.NewReader(importData), FormatJSONL, ImportOptions{Offset: 3})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if result.Offset != 5 || result.Written != 2 || len(result.Failures) != 0 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	expected := []string{"user:dave document:3", "user:erin document:4"}
+	if tuples := readStoreTuples(t, fgaClient); !reflect.DeepEqual(tuples, expected) {
+		t.Fatalf("Expected %v, got %v", expected, tuples)
+	}
+}
+
+func TestImportStopsOnMalformedRecord(t *testing.T) {
+	fgaClient := newFakeClient(t, nil)
+
+	data := "{\"user\": \"user:anne\", \"relation\": \"viewer\", \"object\": \"document:1\"}\n{\"user\":\n"
+	result, err := Import(context.Background(), fgaClient, strings.NewReader(data), FormatJSONL, ImportOptions{})
+	if err == nil || !strings.Contains(err.Error(), "tuple 2") {
+		t.Fatalf("Expected an error on tuple 2, got %v", err)
+	}
+	if result.Offset != 1 || result.Written != 1 {
+		t.Fatalf("Expected the tuples before the malformed record to be imported, got %+v", result)
+	}
+}
+
+func TestImportCanceled(t *testing.T) {
+	fgaClient := newFakeClient(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := Import(ctx, fgaClient, strings.NewReader(importData), FormatJSONL, ImportOptions{})
+	if err == nil || result.Offset != 0 || len(result.Failures) != 0 {
+		t.Fatalf("Expected the import to stop without handling the batch, got %+v (%v)", result, err)
+	}
+}